@@ -23,6 +23,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cloudwego/thriftgo/pkg/diagnostic"
+	"github.com/cloudwego/thriftgo/pkg/reserved"
 	"github.com/cloudwego/thriftgo/version"
 
 	"github.com/cloudwego/thriftgo/generator"
@@ -52,12 +54,26 @@ type Arguments struct {
 	Verbose         bool
 	Quiet           bool
 	CheckKeyword    bool
+	RenameKeywords  bool
+	KeywordLangs    StringSlice
+	KeywordSeverity string
 	OutputPath      string
 	Includes        StringSlice
 	Plugins         StringSlice
 	Langs           StringSlice
 	IDL             string
 	PluginTimeLimit time.Duration
+	Cache           bool
+	CacheDir        string
+	Watch           bool
+	PluginServer    bool
+	PluginDirs      StringSlice
+	DryRun          bool
+	Diff            bool
+	Check           bool
+	Clean           bool
+
+	DiagnosticsFormat string
 }
 
 // Output returns an output path for generated codes for the target language.
@@ -119,25 +135,20 @@ func (a *Arguments) Targets() (specs []*generator.LangSpec, err error) {
 func (a *Arguments) checkOptions(opts []plugin.Option) ([]plugin.Option, error) {
 	params := plugin.Pack(opts)
 	cu := golang.NewCodeUtils(backend.DummyLogFunc())
-	cu.HandleOptions(params)
-	if cu.Features().EnableNestedStruct {
-		// In nested mode, if template is not 'slim', it is automatically converted to slim
-		if cu.Template() != "slim" || cu.Template() != "raw_struct" {
-			found := false
-			for _, opt := range opts {
-				if opt.Name == "template" {
-					log.Printf("[WARN] EnableNestedStruct is only available under the \"slim\" and \"raw_struct\" template, so adapt the template to \"slim\"")
-					opt.Desc = "slim"
-					found = true
-					break
-				}
-			}
-			if !found {
-				log.Printf("[WARN] EnableNestedStruct is only available under the \"slim\" and \"raw_struct\"  template, so adapt the template to \"slim\"")
-				opts = append(opts, plugin.Option{Name: "template", Desc: "slim"})
+	if err := cu.HandleOptions(params); err != nil {
+		return nil, err
+	}
+	if cu.Features().EnableNestedStruct && !golang.NestableTemplate(cu.Template()) {
+		for _, opt := range opts {
+			if opt.Name == "template" {
+				return nil, fmt.Errorf("enable_nested_struct requires the 'slim' or 'raw_struct' template, got '%s'", opt.Desc)
 			}
-
 		}
+		// In nested mode, a missing template defaults to 'slim'
+		if !a.Quiet {
+			log.Printf("[WARN] enable_nested_struct is only available under the \"slim\" and \"raw_struct\" templates, so the template defaults to \"slim\"")
+		}
+		opts = append(opts, plugin.Option{Name: "template", Desc: "slim"})
 	}
 	return opts, nil
 }
@@ -193,10 +204,27 @@ func (a *Arguments) BuildFlags() *flag.FlagSet {
 	f.Var(&a.Plugins, "p", "")
 	f.Var(&a.Plugins, "plugin", "")
 
-	f.BoolVar(&a.CheckKeyword, "check-keywords", true, "")
+	f.BoolVar(&a.CheckKeyword, "check-keywords", false, "")
+	f.BoolVar(&a.RenameKeywords, "rename-keywords", false, "")
+	f.Var(&a.KeywordLangs, "keyword-langs", "")
+	f.StringVar(&a.KeywordSeverity, "keyword-severity", "warning", "")
 
 	f.DurationVar(&a.PluginTimeLimit, "plugin-time-limit", time.Minute, "")
 
+	f.BoolVar(&a.Cache, "cache", false, "")
+	f.StringVar(&a.CacheDir, "cache-dir", "", "")
+
+	f.BoolVar(&a.Watch, "watch", false, "")
+	f.BoolVar(&a.PluginServer, "plugin-server", false, "")
+	f.Var(&a.PluginDirs, "plugin-allow-dir", "")
+
+	f.BoolVar(&a.DryRun, "dry-run", false, "")
+	f.BoolVar(&a.Diff, "diff", false, "")
+	f.BoolVar(&a.Check, "check", false, "")
+	f.BoolVar(&a.Clean, "clean", false, "")
+
+	f.StringVar(&a.DiagnosticsFormat, "diagnostics-format", diagnostic.FormatText, "")
+
 	f.Usage = help
 	return f
 }
@@ -212,6 +240,19 @@ func (a *Arguments) Parse(argv []string) error {
 		return nil
 	}
 
+	if !diagnostic.IsValidFormat(a.DiagnosticsFormat) {
+		return fmt.Errorf("invalid diagnostics format %q, expecting one of: %s",
+			a.DiagnosticsFormat, strings.Join(diagnostic.Formats, ", "))
+	}
+
+	if err := a.checkKeywordOptions(); err != nil {
+		return err
+	}
+
+	if a.CacheDir != "" {
+		a.Cache = true
+	}
+
 	rest := f.Args()
 	if len(rest) != 1 {
 		return fmt.Errorf("require exactly 1 argument for the IDL parameter, got: %d", len(rest))
@@ -221,6 +262,27 @@ func (a *Arguments) Parse(argv []string) error {
 	return nil
 }
 
+// checkKeywordOptions validates the options of keyword checking and converts
+// the languages to their registered names.
+func (a *Arguments) checkKeywordOptions() error {
+	if a.KeywordSeverity != "warning" && a.KeywordSeverity != "error" {
+		return fmt.Errorf("invalid keyword severity %q, expecting warning or error", a.KeywordSeverity)
+	}
+	var langs StringSlice
+	for _, str := range a.KeywordLangs {
+		for _, l := range strings.Split(str, ",") {
+			name, ok := reserved.Lookup(strings.TrimSpace(l))
+			if !ok {
+				return fmt.Errorf("unknown keyword language %q, expecting some of: %s",
+					l, strings.Join(reserved.Languages(), ", "))
+			}
+			langs = append(langs, name)
+		}
+	}
+	a.KeywordLangs = langs
+	return nil
+}
+
 func help() {
 	println("Version:", version.ThriftgoVersion)
 	println(`Usage: thriftgo [options] file
@@ -241,8 +303,36 @@ Options:
                       Example: thriftgo -g go:naming_style=golint,ignore_initialisms,gen_setter,gen_deep_equal example.thrift
   -p, --plugin STR    Specify an external plugin to invoke.
                       STR has the form plugin[=path][:key1=val1[,key2[,key3=val3]]].
-  --check-keywords    Check if any identifier using a keyword in common languages. 
+  --check-keywords    Check if any identifier uses a keyword in the target languages.
+  --rename-keywords   Append "_" to names of types, constants and enum values that use a keyword
+                      in the target languages and update their references, so that every backend
+                      sees the same names. Names on the wire (services, functions, fields) are kept.
+  --keyword-langs STR Set the target languages of keyword checking, separated by commas.
+                      Available: C, C++, Go, Java, JavaScript, Python. Default: all.
+  --keyword-severity STR
+                      Report keywords found by --check-keywords as warning (default) or error.
   --plugin-time-limit Set the execution time limit for plugins. Naturally 0 means no limit.
+  --plugin-server     Keep the plugins that support the persistent mode running between
+                      generations instead of starting them for each IDL.
+  --plugin-allow-dir dir
+                      Allow WebAssembly plugins to read the directory. Can be repeated.
+                      They have no access to the file system by default.
+  --cache             Skip the generation if the IDLs, the options and the outputs are
+                      unchanged since the last run, and skip formatting unchanged files.
+  --cache-dir dir     Set the cache directory and enable --cache.
+                      Default: thriftgo in the user cache directory.
+  --watch             Generate codes again whenever the IDL or its includes change.
+                      Errors are printed and thriftgo keeps watching until interrupted.
+  --dry-run           Print the files that would be written instead of writing them.
+  --diff              Print unified diffs between the existing files and the generated
+                      ones instead of writing them.
+  --check             Do not write files and exit with an error if any generated file
+                      differs from the existing one.
+  --clean             Remove the files generated by previous runs that are no longer
+                      generated. Files without the header of thriftgo are kept.
+  --diagnostics-format STR
+                      Set the format of warnings and errors: text (default), json or sarif.
+                      With json or sarif, all diagnostics are written to stdout when thriftgo exits.
 
 Available generators (and options): go
 `)
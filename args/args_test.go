@@ -15,9 +15,11 @@
 package args
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/plugin"
 )
 
 func TestArgs(t *testing.T) {
@@ -177,6 +179,26 @@ func TestArgs(t *testing.T) {
 			test.Assert(t, a.Plugins.String() == "[a b]")
 		}
 	})
+	t.Run("keywords", func(t *testing.T) {
+		{
+			var a Arguments
+			err := a.Parse([]string{"bin", "--check-keywords", "--keyword-langs", "go,java", "--keyword-langs", "Python", "idl-path"})
+			test.Assert(t, err == nil, err)
+			test.Assert(t, a.CheckKeyword && !a.RenameKeywords)
+			test.Assert(t, a.KeywordLangs.String() == "[Go Java Python]", a.KeywordLangs)
+			test.Assert(t, a.KeywordSeverity == "warning")
+		}
+		{
+			var a Arguments
+			err := a.Parse([]string{"bin", "--keyword-langs", "cobol", "idl-path"})
+			test.Assert(t, err != nil)
+		}
+		{
+			var a Arguments
+			err := a.Parse([]string{"bin", "--keyword-severity", "info", "idl-path"})
+			test.Assert(t, err != nil)
+		}
+	})
 	t.Run("all", func(t *testing.T) {
 		var a Arguments
 		err := a.Parse([]string{"bin", "--recurse", "--g", "a", "--g", "b", "--out", "./out", "--include", "a", "--include", "b", "--verbose", "--plugin", "a", "--plugin", "b", "--quiet", "idl-path"})
@@ -190,4 +212,28 @@ func TestArgs(t *testing.T) {
 		test.Assert(t, a.Plugins.String() == "[a b]")
 		test.Assert(t, a.Langs.String() == "[a b]")
 	})
+	t.Run("options", func(t *testing.T) {
+		targets := func(lang string) ([]plugin.Option, error) {
+			a := Arguments{Quiet: true}
+			a.Langs.Set(lang)
+			specs, err := a.Targets()
+			if err != nil {
+				return nil, err
+			}
+			return specs[0].Options, nil
+		}
+		opts, err := targets("go:enable_nested_struct")
+		test.Assert(t, err == nil, err)
+		test.Assert(t, opts[len(opts)-1] == plugin.Option{Name: "template", Desc: "slim"}, opts)
+
+		opts, err = targets("go:enable_nested_struct,template=raw_struct")
+		test.Assert(t, err == nil, err)
+		test.Assert(t, len(opts) == 2 && opts[1].Desc == "raw_struct", opts)
+
+		_, err = targets("go:enable_nested_struct,template=default")
+		test.Assert(t, err != nil)
+
+		_, err = targets("go:with_field_mask")
+		test.Assert(t, err != nil && strings.Contains(err.Error(), "with_reflection"), err)
+	})
 }
@@ -17,23 +17,17 @@ package config
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"github.com/cloudwego/thriftgo/pkg/vfs"
 	"github.com/cloudwego/thriftgo/utils/dir_utils"
 
 	"gopkg.in/yaml.v3"
 )
 
-func init() {
-	err := LoadConfig()
-	if err != nil {
-		panic(err)
-	}
-}
-
 type RawConfig struct {
 	Ref   map[string]interface{} `yaml:"ref"`
 	Debug bool                   `yaml:"debug"`
@@ -42,6 +36,8 @@ type RawConfig struct {
 type Config struct {
 	Ref   map[string]*RefConfig `yaml:"ref"`
 	Debug bool                  `yaml:"debug"`
+
+	dir string // the directory to resolve relative IDL names against
 }
 
 type RefConfig struct {
@@ -90,15 +86,58 @@ func (r *RefConfig) IsAllFieldsEmpty() bool {
 var globalConfig *Config
 var useAbs bool = true
 
+// loadGlobal loads the global config from the working directory when it is
+// first used, unless LoadConfig has been called.
+var (
+	loadGlobal sync.Once
+	loadErr    error
+)
+
+// Global returns the global config, which is the idl ref config in the
+// working directory by default. It is loaded when first used.
+func Global() (*Config, error) {
+	loadGlobal.Do(func() {
+		loadErr = loadGlobalConfig(vfs.OS)
+	})
+	return globalConfig, loadErr
+}
+
+// GetRef returns the ref config of the IDL file in the global config. It
+// panics if the global config fails to load.
 func GetRef(name string) *RefConfig {
-	if globalConfig == nil {
+	c, err := Global()
+	if err != nil {
+		panic(err)
+	}
+	return c.GetRef(name)
+}
+
+// WithDir returns a copy of the config that resolves relative IDL names
+// against dir instead of the working directory of the process. It returns
+// nil for a nil Config.
+func (c *Config) WithDir(dir string) *Config {
+	if c == nil {
+		return nil
+	}
+	cc := *c
+	cc.dir = dir
+	return &cc
+}
+
+// GetRef returns the ref config of the IDL file, or nil if there is none.
+// It is safe to call on a nil Config.
+func (c *Config) GetRef(name string) *RefConfig {
+	if c == nil {
 		return nil
 	}
 	if useAbs {
+		if c.dir != "" && !filepath.IsAbs(name) {
+			name = filepath.Join(c.dir, name)
+		}
 		name, _ = filepath.Abs(name)
 	}
-	refConfig, ok := globalConfig.Ref[name]
-	if globalConfig.Debug {
+	refConfig, ok := c.Ref[name]
+	if c.Debug {
 		if ok {
 			fmt.Printf("[idl-ref-get]Successfully Get: %s\n", name)
 		} else {
@@ -111,7 +150,12 @@ func GetRef(name string) *RefConfig {
 // LoadConfig by default, config will load only once when the program is invoked, also the same for each plugin
 // but for sdk mode, config should be reloaded each time when the sdk is called. so we provide this api and manually call this in sdk mode.
 func LoadConfig() error {
-	config, err := initConfig()
+	loadGlobal.Do(func() {})
+	return loadGlobalConfig(vfs.OS)
+}
+
+func loadGlobalConfig(fsys vfs.FS) error {
+	config, err := initConfig(fsys)
 	if err != nil {
 		return errors.New("failed to parse idl ref config: " + err.Error())
 	}
@@ -119,29 +163,72 @@ func LoadConfig() error {
 	return nil
 }
 
-func initConfig() (*Config, error) {
-	configPaths := []string{"idl-ref.yml", "idl-ref.yaml"}
-	for _, path := range configPaths {
+func initConfig(fsys vfs.FS) (*Config, error) {
+	path, err := configFile(fsys)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return loadConfig(fsys, path, dir_utils.ToAbsolute)
+}
+
+// Load reads the idl ref config in dir from fsys. The IDL paths in the config
+// are relative to dir. It returns nil if there is no config, which has no ref.
+// Unlike LoadConfig, the global config is left untouched.
+func Load(fsys vfs.FS, dir string) (*Config, error) {
+	for _, name := range configNames {
+		path := filepath.Join(dir, name)
+		if _, err := fsys.Stat(path); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		config, err := loadConfig(fsys, path, func(k string) (string, error) {
+			if !filepath.IsAbs(k) {
+				k = filepath.Join(dir, k)
+			}
+			return filepath.Abs(k)
+		})
+		if err != nil {
+			return nil, errors.New("failed to parse idl ref config: " + err.Error())
+		}
+		return config, nil
+	}
+	return nil, nil
+}
+
+// ConfigFile returns the path of the idl ref config in the working directory,
+// or an empty string if there is none.
+func ConfigFile() (string, error) {
+	return configFile(vfs.OS)
+}
+
+var configNames = []string{"idl-ref.yml", "idl-ref.yaml"}
+
+func configFile(fsys vfs.FS) (string, error) {
+	for _, path := range configNames {
 		if dir_utils.HasGlobalWd() {
 			dirpath, err := dir_utils.Getwd()
 			if err != nil {
-				return nil, err
+				return "", err
 			}
 			path = filepath.Join(dirpath, path)
 		}
-		_, err := os.Stat(path)
+		_, err := fsys.Stat(path)
 		if err == nil {
-			return loadConfig(path)
+			return path, nil
 		}
-		if !os.IsNotExist(err) {
-			return nil, err
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
 		}
 	}
-	return nil, nil
+	return "", nil
 }
 
-func loadConfig(filename string) (*Config, error) {
-	data, err := ioutil.ReadFile(filename)
+// loadConfig reads the config file, converting the IDL paths in it with
+// resolve when absolute paths are used to match IDLs.
+func loadConfig(fsys vfs.FS, filename string, resolve func(string) (string, error)) (*Config, error) {
+	data, err := fsys.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +246,7 @@ func loadConfig(filename string) (*Config, error) {
 		// if use absolute path to match idl-ref path and current file path
 		// convert the idl path to absoulute path
 		if useAbs {
-			k, err = dir_utils.ToAbsolute(k)
+			k, err = resolve(k)
 			if err != nil {
 				return nil, err
 			}
@@ -15,12 +15,44 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+	"github.com/cloudwego/thriftgo/utils/dir_utils"
 )
 
 func TestLoad(t *testing.T) {
-	_, err := loadConfig("idl-ref.yml")
+	_, err := loadConfig(vfs.OS, "idl-ref.yml", dir_utils.ToAbsolute)
 	test.Assert(t, err == nil)
 }
+
+func TestLoadFS(t *testing.T) {
+	fsys := vfs.FromFS(fstest.MapFS{
+		"idl-ref.yaml": {Data: []byte("ref:\n  a.thrift: example.com/a\n")},
+	})
+	c, err := Load(fsys, ".")
+	test.Assert(t, err == nil, err)
+	ref := c.GetRef("a.thrift")
+	test.Assert(t, ref != nil && ref.Path == "example.com/a", ref)
+	test.Assert(t, GetRef("a.thrift") == nil)
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	fsys := vfs.FromFS(fstest.MapFS{})
+	c, err := Load(fsys, dir)
+	test.Assert(t, err == nil && c == nil, err)
+	test.Assert(t, c.GetRef("a.thrift") == nil)
+
+	content := "ref:\n  idl/a.thrift: example.com/a\n"
+	test.Assert(t, os.WriteFile(filepath.Join(dir, "idl-ref.yaml"), []byte(content), 0o644) == nil)
+	c, err = Load(vfs.OS, dir)
+	test.Assert(t, err == nil, err)
+	ref := c.GetRef(filepath.Join(dir, "idl", "a.thrift"))
+	test.Assert(t, ref != nil && ref.Path == "example.com/a", ref)
+	test.Assert(t, c.GetRef("idl/a.thrift") == nil)
+}
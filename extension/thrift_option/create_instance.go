@@ -17,6 +17,7 @@ package thrift_option
 import (
 	"encoding/hex"
 	"errors"
+	"github.com/cloudwego/thriftgo/parser"
 	"github.com/cloudwego/thriftgo/thrift_reflection"
 	"github.com/cloudwego/thriftgo/utils"
 	"reflect"
@@ -206,6 +207,8 @@ func createBasic(name, value string) (interface{}, error) {
 		return i, nil
 	case "string":
 		return value, nil
+	case "uuid":
+		return parser.ParseUUID(value)
 	default:
 		return nil, errors.New("unsupported basic type: " + name)
 	}
@@ -64,3 +64,48 @@ type Backend interface {
 type PostProcessor interface {
 	PostProcess(path string, content []byte) ([]byte, error)
 }
+
+// Options is implemented by the typed options of a backend. The generator
+// validates them and hands the packed form to the backend and plugins.
+type Options interface {
+	Validate() error
+	Pack() []plugin.Option
+}
+
+// OptionInfo describes an option of a backend for tools.
+type OptionInfo struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // "bool" or "string"
+	Default     string `json:"default"`
+	Description string `json:"description"`
+	Repeated    bool   `json:"repeated,omitempty"`
+	// Conflicts are the options that can not be enabled together with this one.
+	Conflicts []string `json:"conflicts,omitempty"`
+	// Requires are the options that must be enabled together with this one.
+	Requires []string `json:"requires,omitempty"`
+	// EffectiveWith are the options without which this one has no effect.
+	EffectiveWith []string `json:"effective_with,omitempty"`
+}
+
+// AnnotationInfo describes an IDL annotation recognized by a backend.
+type AnnotationInfo struct {
+	Name string `json:"name"`
+	// On lists the kinds of definitions the annotation applies to, like "field".
+	On          []string `json:"on"`
+	Values      []string `json:"values,omitempty"`
+	Description string   `json:"description"`
+}
+
+// Describer is an optional extension for the Backend interface to describe
+// its options and annotations in detail.
+type Describer interface {
+	DescribeOptions() []OptionInfo
+	Annotations() []AnnotationInfo
+}
+
+// ScopeReporter is an optional extension for the Backend interface to report
+// the identifiers it generates for the IDLs in the last call of Generate.
+// The generator passes them to the plugins that ask for them.
+type ScopeReporter interface {
+	Scopes() []*plugin.Scope
+}
@@ -51,11 +51,12 @@ const ( // wiretypes
 	tSET    = 14
 	tLIST   = 15
 	tUTF8   = 16
+	tUUID   = 16
 	tUTF16  = 17
 )
 
-var category2ThriftWireType = [16]int{
-	// 0-15, panic if Category_Typedef or Category_Service
+var category2ThriftWireType = [19]int{
+	// 0-18, panic if Category_Typedef or Category_Service
 	parser.Category_Bool:      tBOOL,
 	parser.Category_Byte:      tI08,
 	parser.Category_I16:       tI16,
@@ -71,10 +72,11 @@ var category2ThriftWireType = [16]int{
 	parser.Category_Struct:    tSTRUCT,
 	parser.Category_Union:     tSTRUCT,
 	parser.Category_Exception: tSTRUCT,
+	parser.Category_UUID:      tUUID,
 }
 
-var category2GopkgConsts = [16]string{
-	// 0-15, panic if Category_Typedef or Category_Service
+var category2GopkgConsts = [19]string{
+	// 0-18, panic if Category_Typedef or Category_Service
 	parser.Category_Bool:      "thrift.BOOL",
 	parser.Category_Byte:      "thrift.I08",
 	parser.Category_I16:       "thrift.I16",
@@ -90,9 +92,10 @@ var category2GopkgConsts = [16]string{
 	parser.Category_Struct:    "thrift.STRUCT",
 	parser.Category_Union:     "thrift.STRUCT",
 	parser.Category_Exception: "thrift.STRUCT",
+	parser.Category_UUID:      "thrift.TType(16)", // named UTF8 in older versions
 }
 
-var category2WireSize = [16]int{
+var category2WireSize = [19]int{
 	parser.Category_Bool:   1,
 	parser.Category_Byte:   1,
 	parser.Category_I16:    2,
@@ -100,4 +103,5 @@ var category2WireSize = [16]int{
 	parser.Category_Enum:   4,
 	parser.Category_I64:    8,
 	parser.Category_Double: 8,
+	parser.Category_UUID:   16,
 }
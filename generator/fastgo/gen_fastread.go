@@ -163,6 +163,8 @@ func genFastReadAny(w *codewriter, rwctx *golang.ReadWriteContext, varname strin
 		genFastReadString(w, pointer, varname)
 	case parser.Category_Binary:
 		genFastReadBinary(w, pointer, varname)
+	case parser.Category_UUID:
+		genFastReadUUID(w, rwctx, varname)
 	case parser.Category_Map:
 		genFastReadMap(w, rwctx, varname, depth)
 	case parser.Category_List:
@@ -253,6 +255,18 @@ func genFastReadBinary(w *codewriter, pointer bool, varname string) {
 	w.f("if err != nil { goto ReadFieldError }")
 }
 
+func genFastReadUUID(w *codewriter, rwctx *golang.ReadWriteContext, varname string) {
+	if rwctx.IsPointer {
+		w.f("if %s == nil { %s = new(%s) } ", varname, varname, rwctx.TypeName.Deref())
+	}
+	w.f("if len(b[off:]) < 16 {")
+	w.f(`	err = thrift.NewProtocolException(thrift.INVALID_DATA, "ReadUUID: len(buf) < 16")`)
+	w.f("	goto ReadFieldError")
+	w.f("}")
+	w.f("copy(%s[:], b[off:])", varname) // slicing works for both arrays and pointers to arrays
+	w.f("off += 16")
+}
+
 func genFastReadString(w *codewriter, pointer bool, varname string) {
 	if pointer {
 		w.f("if %s == nil { %s = new(string) } ", varname, varname)
@@ -119,6 +119,8 @@ func genFastAppendAny(w *codewriter, rwctx *golang.ReadWriteContext, varname str
 		genFastAppendString(w, pointer, varname)
 	case parser.Category_Binary:
 		genFastAppendBinary(w, pointer, varname)
+	case parser.Category_UUID:
+		genFastAppendUUID(w, varname)
 	case parser.Category_Map:
 		genFastAppendMap(w, rwctx, varname, depth)
 	case parser.Category_List, parser.Category_Set:
@@ -162,6 +164,10 @@ func genFastAppendBinary(w *codewriter, pointer bool, varname string) {
 	w.f("b = append(b, %s...)", varname)
 }
 
+func genFastAppendUUID(w *codewriter, varname string) {
+	w.f("b = append(b, %s[:]...)", varname) // slicing works for both arrays and pointers to arrays
+}
+
 func genFastAppendString(w *codewriter, pointer bool, varname string) {
 	genFastAppendBinary(w, pointer, varname)
 }
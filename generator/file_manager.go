@@ -136,6 +136,31 @@ func (p *insertionPointReplacer) Replace(content string) string {
 	return strings.NewReplacer(oldnews...).Replace(content)
 }
 
+// Replace replaces the files of the same names with the given ones and
+// drops the contents inserted into them. Other files are fed as usual.
+func (fm *FileManager) Replace(src string, files []*plugin.Generated) error {
+	var rest []*plugin.Generated
+	for _, f := range files {
+		idx, ok := fm.index[f.GetName()]
+		if !ok || !f.IsSetName() || f.IsSetInsertionPoint() {
+			rest = append(rest, f)
+			continue
+		}
+		fm.log.Info(fmt.Sprintf("[%s] replace generated file '%s'", src, f.GetName()))
+		fm.files[idx] = f
+		delete(fm.patch, f.GetName())
+	}
+	return fm.Feed(src, rest)
+}
+
+// Names returns the names of the files fed so far in order.
+func (fm *FileManager) Names() (names []string) {
+	for _, f := range fm.files {
+		names = append(names, f.GetName())
+	}
+	return
+}
+
 // BuildResponse creates a plugin.Response containing all files that the
 // FileManager manages.  All insertion points will be removed after the response
 // is built.
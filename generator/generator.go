@@ -15,16 +15,24 @@
 package generator
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/cloudwego/gopkg/unsafex"
 	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/cache"
+	"github.com/cloudwego/thriftgo/pkg/diff"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
 	"github.com/cloudwego/thriftgo/plugin"
 	"github.com/cloudwego/thriftgo/utils/dir_utils"
 )
@@ -36,6 +44,8 @@ type LangSpec struct {
 	Options     []plugin.Option
 	UsedPlugins []*plugin.Desc
 	SDKPlugins  []plugin.SDKPlugin
+	// TypedOptions are validated and packed ahead of Options.
+	TypedOptions backend.Options
 }
 
 // Arguments contains arguments for generator's Generate method.
@@ -43,16 +53,35 @@ type Arguments struct {
 	Out *LangSpec
 	Req *plugin.Request
 	Log backend.LogFunc
+
+	// Context cancels the generation between plugins, and stops the running
+	// plugins that implement plugin.ContextPlugin. Without it, plugins are
+	// executed with plugin.MaxExecutionTime.
+	Context context.Context
+	// PluginTimeLimit limits the execution time of each plugin when Context
+	// is not nil.
+	PluginTimeLimit time.Duration
+	// PluginServers keeps the external plugins that support the persistent
+	// mode running between generations. Nil starts plugins for each one.
+	PluginServers *plugin.ServerPool
+	// PluginSandbox grants WebAssembly plugins the access to host resources.
+	// They have none if it is nil.
+	PluginSandbox *plugin.Sandbox
+	// WorkDir is where external plugins run. It is the working directory of
+	// the process if empty.
+	WorkDir string
 }
 
 // Generator controls the code generation.
 // The zero value of Generator is ready for use.
 type Generator struct {
 	backends []backend.Backend
-	plugins  []plugin.Plugin
+	plugins  []*usedPlugin
+	scopes   []*plugin.Scope
 	files    *FileManager
 	log      backend.LogFunc
 	pp       backend.PostProcessor
+	diags    parser.Diagnostics
 }
 
 // Name returns "thriftgo".
@@ -89,125 +118,329 @@ func (g *Generator) validateRequest(req *plugin.Request) error {
 	return nil
 }
 
-func (g *Generator) preparePlugins(be backend.Backend, pds []*plugin.Desc) error {
-	for _, d := range pds {
+func (g *Generator) preparePlugins(be backend.Backend, args *Arguments) (err error) {
+	var used []*usedPlugin
+	for _, d := range args.Out.UsedPlugins {
 		// TODO(lushaojie): check d
 
 		if p := be.GetPlugin(d); p != nil {
-			g.plugins = append(g.plugins, p)
+			used = append(used, newUsedPlugin(p, d))
 			continue
 		}
-		p, err := plugin.Lookup(d.Name)
+		lookup := plugin.Lookup
+		if args.PluginServers != nil {
+			lookup = args.PluginServers.Lookup
+		}
+		p, err := lookup(d.Name)
 		if err != nil {
 			return err
 		}
 
-		g.plugins = append(g.plugins, p)
+		p = plugin.InDir(args.PluginSandbox.Grant(p), args.WorkDir)
+		used = append(used, newUsedPlugin(p, d))
 	}
-	return nil
+	g.plugins, err = orderPlugins(used)
+	return err
 }
 
 // Generate generates codes for the target language and executes plugins specified.
+// The Diagnostics of the result contains the warnings and errors reported by
+// the backend and all plugins.
 func (g *Generator) Generate(args *Arguments) (res *plugin.Response) {
 	out, req, log := args.Out, args.Req, args.Log
 
+	g.log = log
+	g.diags = nil
+	g.plugins = nil
+	g.scopes = nil
 	log.Info(fmt.Sprintf(`Generating: "%s"`, out.Language))
 	if err := g.validateRequest(req); err != nil {
-		return plugin.BuildErrorResponse(err.Error())
+		return g.fail(g.Name(), err.Error())
 	}
 
 	g.files = NewFileManager(log)
-	g.log = log
 
 	be := g.GetBackend(out.Language)
 	if be == nil {
 		err := fmt.Sprintf("No generator for language '%s'.", out.Language)
-		return plugin.BuildErrorResponse(err)
+		return g.fail(g.Name(), err)
 	}
 	if pp, ok := be.(backend.PostProcessor); ok {
 		g.pp = pp
 	}
 
-	if err := g.preparePlugins(be, out.UsedPlugins); err != nil {
-		return plugin.BuildErrorResponse(err.Error())
+	if err := g.preparePlugins(be, args); err != nil {
+		return g.fail(g.Name(), err.Error())
 	}
 
-	req.GeneratorParameters = plugin.Pack(out.Options)
+	// the AST modified by plugins is only used in this generation
+	defer func(ast *parser.Thrift) { req.AST = ast }(req.AST)
+	if res := g.runPlugins(args, be, plugin.StageTransform); res != nil {
+		return res
+	}
+
+	params := out.Options
+	if out.TypedOptions != nil {
+		if err := out.TypedOptions.Validate(); err != nil {
+			return g.fail(g.Name(), err.Error())
+		}
+		params = append(out.TypedOptions.Pack(), params...)
+	}
+	req.GeneratorParameters = plugin.Pack(params)
 	res = be.Generate(req, log)
-	log.MultiWarn(res.Warnings)
+	g.collect(be.Name(), res)
 	if res.GetError() != "" {
+		res.Diagnostics = g.diags
 		return res
 	}
 	log.Info("Got", len(res.Contents), "contents")
 
 	if err := g.files.Feed(g.Name(), res.Contents); err != nil {
-		return plugin.BuildErrorResponse(err.Error())
+		return g.fail(g.Name(), err.Error())
 	}
 
 	if len(out.SDKPlugins) > 0 {
 		for _, sdk := range out.SDKPlugins {
+			if err := args.err(); err != nil {
+				return g.fail(g.Name(), err.Error())
+			}
 			req.PluginParameters = sdk.GetPluginParameters()
 			extra := sdk.Invoke(req)
+			g.collect(sdk.GetName(), extra)
 			if err := extra.GetError(); err != "" {
-				return plugin.BuildErrorResponse(err)
+				return g.fail("", err)
 			}
 			if err := g.files.Feed(sdk.GetName(), extra.Contents); err != nil {
-				return plugin.BuildErrorResponse(err.Error())
+				return g.fail(sdk.GetName(), err.Error())
 			}
 		}
 	}
 
-	for i, p := range g.plugins {
-		log.Info(fmt.Sprintf(`Run plugin "%s"`, p.Name()))
+	if res := g.runPlugins(args, be, plugin.StageGenerate); res != nil {
+		return res
+	}
+	if res := g.runPlugins(args, be, plugin.StagePostProcess); res != nil {
+		return res
+	}
+
+	res = g.files.BuildResponse()
+	res.Diagnostics = g.diags
+	return res
+}
+
+func (args *Arguments) err() error {
+	if args.Context == nil {
+		return nil
+	}
+	return args.Context.Err()
+}
 
-		req.PluginParameters = plugin.Pack(out.UsedPlugins[i].Options)
-		extra := p.Execute(req)
-		log.MultiWarn(extra.Warnings)
+func (args *Arguments) execute(p plugin.Plugin, req *plugin.Request) *plugin.Response {
+	cp, ok := p.(plugin.ContextPlugin)
+	if args.Context == nil || !ok {
+		return p.Execute(req)
+	}
+	ctx := args.Context
+	if args.PluginTimeLimit > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, args.PluginTimeLimit)
+		defer cancel()
+	}
+	return cp.ExecuteContext(ctx, req)
+}
 
-		if err := extra.GetError(); err != "" {
-			return plugin.BuildErrorResponse(err)
-		}
-		if err := g.files.Feed(p.Name(), extra.Contents); err != nil {
-			return plugin.BuildErrorResponse(err.Error())
+// collect logs the warnings in the response and records them together with
+// the diagnostics and the error of the response as diagnostics from the source.
+func (g *Generator) collect(source string, res *plugin.Response) {
+	g.log.MultiWarn(res.Warnings)
+	for _, w := range res.Warnings {
+		g.diags = append(g.diags, &parser.Diagnostic{
+			Severity: parser.Severity_Warning,
+			Message:  w,
+			Source:   source,
+		})
+	}
+	var ds parser.Diagnostics
+	for _, d := range res.Diagnostics {
+		if d.Source == "" {
+			d.Source = source
 		}
+		ds = append(ds, d)
+	}
+	g.log.MultiWarn(ds.Filter(parser.Severity_Warning).Strings())
+	g.diags = append(g.diags, ds...)
+	if err := res.GetError(); err != "" && !ds.HasError() {
+		g.diags = append(g.diags, &parser.Diagnostic{
+			Severity: parser.Severity_Error,
+			Message:  err,
+			Source:   source,
+		})
 	}
+}
 
-	res = g.files.BuildResponse()
+// fail builds an error response carrying all diagnostics collected so far.
+// If source is not empty, the error is recorded as a diagnostic from it.
+func (g *Generator) fail(source, err string) *plugin.Response {
+	if source != "" {
+		g.diags = append(g.diags, &parser.Diagnostic{
+			Severity: parser.Severity_Error,
+			Message:  err,
+			Source:   source,
+		})
+	}
+	res := plugin.BuildErrorResponse(err)
+	res.Diagnostics = g.diags
 	return res
 }
 
 // Persist writes generated files into the disk. Each files in the Contents
-// slice must have a legal name.
+// slice must have a legal name. Files whose content is not changed are not
+// touched.
 func (g *Generator) Persist(res *plugin.Response) error {
-	if err := res.GetError(); err != "" {
-		return errors.New(err)
-	}
-	p := newAsyncPostProcess(g.pp)
+	_, err := g.PersistWithOptions(res, nil)
+	return err
+}
+
+// FilePaths returns the paths to write the generated files to.
+func FilePaths(res *plugin.Response) ([]string, error) {
+	return filePaths(res, true)
+}
+
+// filePaths returns the names of the generated files, which are joined with
+// the global working directory if joinWd is true.
+func filePaths(res *plugin.Response, joinWd bool) ([]string, error) {
+	paths := make([]string, len(res.Contents))
 	for i, c := range res.Contents {
 		full := c.GetName()
 		if full == "" {
-			return fmt.Errorf("file name not found for the %dth generated item", i)
+			return nil, fmt.Errorf("file name not found for the %dth generated item", i)
 		}
-		if !filepath.IsAbs(full) && dir_utils.HasGlobalWd() {
+		if joinWd && !filepath.IsAbs(full) && dir_utils.HasGlobalWd() {
 			wd, err := dir_utils.Getwd()
 			if err != nil {
-				return err
+				return nil, err
 			}
 			full = filepath.Join(wd, full)
 		}
+		paths[i] = full
+	}
+	return paths, nil
+}
+
+// PersistOptions controls how generated files are persisted.
+type PersistOptions struct {
+	// Prev records a previous generation. The post-processing of files that
+	// are generated from the same content and not modified since is skipped.
+	Prev *cache.Entry
+	// Next records the generated files if it is not nil.
+	Next *cache.Entry
+
+	// DryRun lists the files that would be written to Stdout.
+	DryRun bool
+	// Diff writes the unified diffs between the files on disk and the
+	// generated ones to Stdout.
+	Diff bool
+	// NoWrite leaves the files on disk untouched. It is implied by DryRun and
+	// Diff.
+	NoWrite bool
+	// Stdout defaults to os.Stdout.
+	Stdout io.Writer
+	// Output receives the generated files. It defaults to vfs.OS. Files are
+	// compared with the existing ones only if it is also a vfs.FS. The names
+	// of files are not joined with the global working directory unless it is
+	// vfs.OS.
+	Output vfs.Sink
+}
+
+// PersistWithOptions is like Persist, and returns the stale files whose
+// content on disk differs from the generated one, in lexical order.
+func (g *Generator) PersistWithOptions(res *plugin.Response, opts *PersistOptions) (stale []string, err error) {
+	if err := res.GetError(); err != "" {
+		return nil, errors.New(err)
+	}
+	if opts == nil {
+		opts = &PersistOptions{}
+	}
+	write := !opts.DryRun && !opts.Diff && !opts.NoWrite
+	output := opts.Output
+	if output == nil {
+		output = vfs.OS
+	}
+	existing, _ := output.(vfs.FS)
+	paths, err := filePaths(res, output == vfs.Sink(vfs.OS))
+	if err != nil {
+		return nil, err
+	}
+	p := newAsyncPostProcess(g.pp)
+	sources := make(map[string]string, len(res.Contents))
+	for i, c := range res.Contents {
+		full := paths[i]
+		if opts.Prev == nil && opts.Next == nil {
+			p.Add(full, c.Content)
+			continue
+		}
+		source := cache.Hash(c.Content)
+		if hash, ok := opts.Prev.Unchanged(full, source); ok {
+			g.log.Info("Unchanged", full)
+			if opts.Next != nil {
+				opts.Next.AddOutput(full, source, hash)
+			}
+			continue
+		}
+		sources[full] = source
 		p.Add(full, c.Content)
 	}
-	return p.OnFinished(func(path string, content []byte) error {
-		g.log.Info("Write", path)
-		dir := filepath.Dir(path)
-		if err := os.MkdirAll(dir, 0o755); err != nil && !os.IsExist(err) {
-			return fmt.Errorf("failed to create path '%s': %w", dir, err)
+
+	var mu sync.Mutex
+	diffs := make(map[string]string)
+	err = p.OnFinished(func(path string, content []byte) error {
+		if opts.Next != nil {
+			opts.Next.AddOutput(path, sources[path], cache.Hash(string(content)))
+		}
+		var old []byte
+		if existing != nil {
+			var err error
+			if old, err = existing.ReadFile(path); err == nil && bytes.Equal(old, content) {
+				g.log.Info("Unchanged", path)
+				return nil
+			}
+		}
+		mu.Lock()
+		stale = append(stale, path)
+		if opts.Diff {
+			diffs[path] = diff.Unified(path, string(old), string(content))
+		}
+		mu.Unlock()
+		if !write {
+			return nil
 		}
-		if err := ioutil.WriteFile(path, content, 0o644); err != nil {
+		g.log.Info("Write", path)
+		if err := output.WriteFile(path, content); err != nil {
 			return fmt.Errorf("failed to write file '%s': %w", path, err)
 		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(stale)
+	out := opts.Stdout
+	if out == nil {
+		out = os.Stdout
+	}
+	for _, path := range stale {
+		if opts.DryRun {
+			if _, err := fmt.Fprintln(out, path); err != nil {
+				return nil, err
+			}
+		}
+		if opts.Diff {
+			if _, err := io.WriteString(out, diffs[path]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stale, nil
 }
 
 type asyncPostProcessJob struct {
@@ -15,12 +15,19 @@
 package generator
 
 import (
+	"bytes"
 	"errors"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/cache"
 	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/plugin"
 )
 
 type postProcessFunc func(path string, content []byte) ([]byte, error)
@@ -91,3 +98,130 @@ func TestAsyncPostProcess(t *testing.T) {
 	test.Assert(t, err != nil)
 	test.Assert(t, err.Error() == "test error")
 }
+
+func TestPersistWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.go"), filepath.Join(dir, "sub", "b.go")
+	res := &plugin.Response{Contents: []*plugin.Generated{
+		{Name: &a, Content: "a"},
+		{Name: &b, Content: "b"},
+	}}
+	var mu sync.Mutex
+	var processed []string
+	g := &Generator{log: backend.DummyLogFunc()}
+	g.pp = postProcessFunc(func(path string, content []byte) ([]byte, error) {
+		mu.Lock()
+		processed = append(processed, path)
+		mu.Unlock()
+		return append(content, '\n'), nil
+	})
+	persist := func(opts *PersistOptions) []string {
+		stale, err := g.PersistWithOptions(res, opts)
+		test.Assert(t, err == nil, err)
+		return stale
+	}
+
+	first := cache.NewEntry()
+	test.DeepEqual(t, persist(&PersistOptions{Next: first}), []string{a, b})
+	test.Assert(t, len(processed) == 2, processed)
+	content, _ := os.ReadFile(b)
+	test.Assert(t, string(content) == "b\n", string(content))
+	test.Assert(t, len(first.Outputs) == 2 && first.Sources[a] == cache.Hash("a"), first)
+
+	// unchanged files are neither processed nor written
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	test.Assert(t, os.Chtimes(a, past, past) == nil)
+	processed = nil
+	second := cache.NewEntry()
+	test.Assert(t, len(persist(&PersistOptions{Prev: first, Next: second})) == 0)
+	test.Assert(t, len(processed) == 0, processed)
+	test.DeepEqual(t, second.Outputs, first.Outputs)
+
+	// modified files are generated again
+	test.Assert(t, os.WriteFile(b, []byte("modified"), 0o644) == nil)
+	test.DeepEqual(t, persist(&PersistOptions{Prev: second, Next: cache.NewEntry()}), []string{b})
+	test.DeepEqual(t, processed, []string{b})
+	content, _ = os.ReadFile(b)
+	test.Assert(t, string(content) == "b\n", string(content))
+
+	// files with the same bytes are not rewritten without a cache either
+	test.Assert(t, g.Persist(res) == nil)
+	fi, err := os.Stat(a)
+	test.Assert(t, err == nil && fi.ModTime().Equal(past), fi.ModTime())
+
+	// stale files are reported without being written
+	test.Assert(t, os.WriteFile(b, []byte("old\n"), 0o644) == nil)
+	var stdout bytes.Buffer
+	stale := persist(&PersistOptions{DryRun: true, Diff: true, Stdout: &stdout})
+	test.DeepEqual(t, stale, []string{b})
+	test.Assert(t, stdout.String() == b+"\n--- "+b+"\n+++ "+b+"\n@@ -1 +1 @@\n-old\n+b\n", stdout.String())
+	test.DeepEqual(t, persist(&PersistOptions{NoWrite: true}), []string{b})
+	content, _ = os.ReadFile(b)
+	test.Assert(t, string(content) == "old\n", string(content))
+}
+
+type fakeBackend struct {
+	backend.Backend
+	plugins []plugin.Plugin
+	ast     *parser.Thrift
+}
+
+func (b *fakeBackend) Name() string { return "fake" }
+func (b *fakeBackend) Lang() string { return "Fake" }
+
+func (b *fakeBackend) Generate(req *plugin.Request, log backend.LogFunc) *plugin.Response {
+	b.ast = req.AST
+	a, c := "a.go", "c.go"
+	return &plugin.Response{Contents: []*plugin.Generated{{Name: &a}, {Name: &c}}}
+}
+
+func (b *fakeBackend) GetPlugin(desc *plugin.Desc) plugin.Plugin {
+	for _, p := range b.plugins {
+		if p.Name() == desc.Name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (b *fakeBackend) Scopes() []*plugin.Scope {
+	return []*plugin.Scope{{Filename: "a.thrift", File: "a.go"}}
+}
+
+type manifestPlugin struct {
+	manifest *plugin.Manifest
+	params   []string
+	scopes   []*plugin.Scope
+}
+
+func (p *manifestPlugin) Name() string               { return "manifest" }
+func (p *manifestPlugin) Manifest() *plugin.Manifest { return p.manifest }
+
+func (p *manifestPlugin) Execute(req *plugin.Request) *plugin.Response {
+	p.params = req.PluginParameters
+	p.scopes = req.Scopes
+	return &plugin.Response{}
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	for _, declared := range []bool{false, true} {
+		p := &manifestPlugin{manifest: &plugin.Manifest{GeneratedFiles: declared, Scopes: declared}}
+		var g Generator
+		test.Assert(t, g.RegisterBackend(&fakeBackend{plugins: []plugin.Plugin{p}}) == nil)
+		req := plugin.NewRequest()
+		res := g.Generate(&Arguments{
+			Out: &LangSpec{Language: "fake", UsedPlugins: []*plugin.Desc{{
+				Name: "manifest", Options: []plugin.Option{{Name: "k", Desc: "v"}},
+			}}},
+			Req: req,
+			Log: backend.DummyLogFunc(),
+		})
+		test.Assert(t, res.GetError() == "", res.GetError())
+		want := []string{"k=v"}
+		if declared {
+			want = append(want, plugin.GeneratedParameter+"=a.go", plugin.GeneratedParameter+"=c.go")
+		}
+		test.DeepEqual(t, p.params, want)
+		test.Assert(t, (len(p.scopes) == 1) == declared, p.scopes)
+	}
+}
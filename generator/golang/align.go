@@ -34,6 +34,7 @@ var sizeof = (func() map[parser.Category]int {
 		parser.Category_Double: 8, // float64
 		parser.Category_String: pointerSize * 2,
 		parser.Category_Binary: pointerSize * 3,
+		parser.Category_UUID:   16,
 		parser.Category_Set:    pointerSize * 3,
 		parser.Category_List:   pointerSize * 3,
 		parser.Category_Map:    pointerSize,
@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"go/format"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"text/template"
 
+	"github.com/cloudwego/thriftgo/config"
 	"github.com/cloudwego/thriftgo/generator/golang/streaming"
 	"github.com/cloudwego/thriftgo/generator/golang/templates/slim"
 	"github.com/cloudwego/thriftgo/tool/trimmer/trim"
@@ -48,8 +50,15 @@ type GoBackend struct {
 	res              *plugin.Response
 	log              backend.LogFunc
 
-	utils *CodeUtils
-	funcs template.FuncMap
+	utils     *CodeUtils
+	funcs     template.FuncMap
+	refConfig *config.Config
+	asts      []*parser.Thrift // the IDLs processed by the last generation
+}
+
+// SetRefConfig sets the idl ref config to use instead of the global one.
+func (g *GoBackend) SetRefConfig(c *config.Config) {
+	g.refConfig = c
 }
 
 // Name implements the Backend interface.
@@ -88,6 +97,7 @@ func (g *GoBackend) Generate(req *plugin.Request, log backend.LogFunc) *plugin.R
 	g.req = req
 	g.res = plugin.NewResponse()
 	g.log = log
+	g.asts = nil
 	g.prepareUtilities()
 	if g.utils.Features().TrimIDL {
 		g.log.Warn("You Are Using IDL Trimmer")
@@ -126,6 +136,9 @@ func (g *GoBackend) prepareUtilities() {
 	}
 
 	g.utils = NewCodeUtils(g.log)
+	if g.refConfig != nil {
+		g.utils.SetRefConfig(g.refConfig)
+	}
 	g.err = g.utils.HandleOptions(g.req.GeneratorParameters)
 	if g.err != nil {
 		return
@@ -181,6 +194,10 @@ func (g *GoBackend) executeTemplates() {
 		close(trees)
 	}
 
+	// Scopes are built serially since they are cached and reference each
+	// other, then the files are rendered concurrently with their own copies of
+	// the utilities and templates.
+	var asts []*parser.Thrift
 	for ast := range trees {
 		if processed[ast] {
 			continue
@@ -188,34 +205,102 @@ func (g *GoBackend) executeTemplates() {
 		processed[ast] = true
 		g.log.Info("Processing", ast.Filename)
 
-		if g.err = g.renderOneFile(ast); g.err != nil {
-			break
+		if _, _, g.err = BuildRefScope(g.utils, ast); g.err != nil {
+			return
 		}
+		asts = append(asts, ast)
+	}
+	g.asts = asts
+
+	results := make([]renderResult, len(asts))
+	jobs := make(chan int, len(asts))
+	for i := range asts {
+		jobs <- i
+	}
+	close(jobs)
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(asts) {
+		workers = len(asts)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		r := g.newRenderer()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				r.contents = nil
+				err := r.renderOneFile(g.req.OutputPath, asts[i])
+				results[i] = renderResult{r.contents, err}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// keep the order of files deterministic
+	for _, res := range results {
+		if g.err = res.err; g.err != nil {
+			return
+		}
+		g.res.Contents = append(g.res.Contents, res.contents...)
+	}
+}
+
+type renderResult struct {
+	contents []*plugin.Generated
+	err      error
+}
+
+// renderer renders files with its own utilities and templates, which keep
+// the scope being rendered, so that files can be rendered concurrently.
+type renderer struct {
+	utils            *CodeUtils
+	tpl              *template.Template
+	refTpl           *template.Template
+	reflectionTpl    *template.Template
+	reflectionRefTpl *template.Template
+	contents         []*plugin.Generated
+}
+
+func (g *GoBackend) newRenderer() *renderer {
+	utils := g.utils.clone()
+	funcs := utils.BuildFuncMap()
+	funcs["Version"] = g.funcs["Version"]
+	clone := func(t *template.Template) *template.Template {
+		return template.Must(t.Clone()).Funcs(funcs)
+	}
+	return &renderer{
+		utils:            utils,
+		tpl:              clone(g.tpl),
+		refTpl:           clone(g.refTpl),
+		reflectionTpl:    clone(g.reflectionTpl),
+		reflectionRefTpl: clone(g.reflectionRefTpl),
 	}
 }
 
-func (g *GoBackend) renderOneFile(ast *parser.Thrift) error {
-	keepName := g.utils.Features().KeepCodeRefName
-	path := g.utils.CombineOutputPath(g.req.OutputPath, ast)
-	filename := filepath.Join(path, g.utils.GetFilename(ast))
-	localScope, refScope, err := BuildRefScope(g.utils, ast)
+func (r *renderer) renderOneFile(outputPath string, ast *parser.Thrift) error {
+	keepName := r.utils.Features().KeepCodeRefName
+	path := r.utils.CombineOutputPath(outputPath, ast)
+	filename := filepath.Join(path, r.utils.GetFilename(ast))
+	localScope, refScope, err := BuildRefScope(r.utils, ast)
 	if err != nil {
 		return err
 	}
-	err = g.renderByTemplate(localScope, g.tpl, filename)
+	err = r.renderByTemplate(localScope, r.tpl, filename)
 	if err != nil {
 		return err
 	}
-	err = g.renderByTemplate(refScope, g.refTpl, ToRefFilename(keepName, filename))
+	err = r.renderByTemplate(refScope, r.refTpl, ToRefFilename(keepName, filename))
 	if err != nil {
 		return err
 	}
-	if g.utils.Features().WithReflection {
-		err = g.renderByTemplate(refScope, g.reflectionRefTpl, ToReflectionRefFilename(keepName, filename))
+	if r.utils.Features().WithReflection {
+		err = r.renderByTemplate(refScope, r.reflectionRefTpl, ToReflectionRefFilename(keepName, filename))
 		if err != nil {
 			return err
 		}
-		return g.renderByTemplate(localScope, g.reflectionTpl, ToReflectionFilename(filename))
+		return r.renderByTemplate(localScope, r.reflectionTpl, ToReflectionFilename(filename))
 	}
 	return nil
 }
@@ -260,12 +345,12 @@ var poolBuffer = sync.Pool{
 	},
 }
 
-func (g *GoBackend) renderByTemplate(scope *Scope, executeTpl *template.Template, filename string) error {
+func (r *renderer) renderByTemplate(scope *Scope, executeTpl *template.Template, filename string) error {
 	if scope == nil {
 		return nil
 	}
 	// if scope has no content, just skip and don't generate this file
-	if g.utils.Features().SkipEmpty {
+	if r.utils.Features().SkipEmpty {
 		if scope.IsEmpty() {
 			return nil
 		}
@@ -276,12 +361,12 @@ func (g *GoBackend) renderByTemplate(scope *Scope, executeTpl *template.Template
 
 	w.Reset()
 
-	g.utils.SetRootScope(scope)
+	r.utils.SetRootScope(scope)
 	err := executeTpl.ExecuteTemplate(w, executeTpl.Name(), scope)
 	if err != nil {
 		return fmt.Errorf("%s: %w", filename, err)
 	}
-	g.res.Contents = append(g.res.Contents, &plugin.Generated{
+	r.contents = append(r.contents, &plugin.Generated{
 		Content: w.String(),
 		Name:    &filename,
 	})
@@ -295,7 +380,7 @@ func (g *GoBackend) renderByTemplate(scope *Scope, executeTpl *template.Template
 		return fmt.Errorf("%s: %w", filename, err)
 	}
 	point := "imports"
-	g.res.Contents = append(g.res.Contents, &plugin.Generated{
+	r.contents = append(r.contents, &plugin.Generated{
 		Content:        w.String(),
 		InsertionPoint: &point,
 	})
@@ -304,11 +389,19 @@ func (g *GoBackend) renderByTemplate(scope *Scope, executeTpl *template.Template
 
 func (g *GoBackend) buildResponse() *plugin.Response {
 	if g.err != nil {
-		return plugin.BuildErrorResponse(g.err.Error())
+		res := plugin.BuildErrorResponse(g.err.Error())
+		res.Diagnostics = g.res.Diagnostics
+		return res
 	}
 	return g.res
 }
 
+// warnf reports a warning diagnostic about a node in the AST.
+func (g *GoBackend) warnf(ast *parser.Thrift, loc *parser.Location, format string, args ...interface{}) {
+	d := parser.NewDiagnosticf(parser.Severity_Warning, g.Name(), "", ast.Filename, loc, format, args...)
+	g.res.Diagnostics = append(g.res.Diagnostics, d)
+}
+
 // PostProcess implements the backend.PostProcessor interface to do
 // source formatting before writing files out.
 func (g *GoBackend) PostProcess(path string, content []byte) ([]byte, error) {
@@ -332,12 +425,12 @@ func (g *GoBackend) removeStreamingFunctions(ast *parser.Thrift) {
 		for _, f := range svc.Functions {
 			st, err := streaming.ParseStreaming(f)
 			if err != nil {
-				g.log.Warn(fmt.Sprintf("%s.%s: failed to parse streaming, err = %v", svc.Name, f.Name, err))
+				g.warnf(ast, f.Location, "%s.%s: failed to parse streaming, err = %v", svc.Name, f.Name, err)
 				continue
 			}
 			if st.IsStreaming {
-				g.log.Warn(fmt.Sprintf("skip streaming function %s.%s: not supported by your kitex, "+
-					"please update your kitex tool to the latest version", svc.Name, f.Name))
+				g.warnf(ast, f.Location, "skip streaming function %s.%s: not supported by your kitex, "+
+					"please update your kitex tool to the latest version", svc.Name, f.Name)
 				continue
 			}
 			functions = append(functions, f)
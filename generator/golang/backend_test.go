@@ -0,0 +1,133 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/plugin"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+func TestExecuteTemplatesOrder(t *testing.T) {
+	dir := t.TempDir()
+	var main strings.Builder
+	main.WriteString("namespace go main\n")
+	for i := 0; i < 16; i++ {
+		name := fmt.Sprintf("f%d.thrift", i)
+		content := fmt.Sprintf("namespace go f%d\ninclude \"base.thrift\"\nstruct S%d { 1: base.Base b }\n", i, i)
+		test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+		fmt.Fprintf(&main, "include %q\n", name)
+	}
+	test.Assert(t, os.WriteFile(filepath.Join(dir, "base.thrift"), []byte("namespace go base\nstruct Base {}\n"), 0o644) == nil)
+	test.Assert(t, os.WriteFile(filepath.Join(dir, "main.thrift"), []byte(main.String()), 0o644) == nil)
+
+	ast, err := parser.ParseFile(filepath.Join(dir, "main.thrift"), nil, true)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, semantic.ResolveSymbols(ast) == nil)
+	var want []string
+	for ast := range ast.DepthFirstSearch() {
+		want = append(want, strings.TrimSuffix(filepath.Base(ast.Filename), ".thrift")+".go")
+	}
+
+	generate := func() (names []string, contents []string) {
+		req := &plugin.Request{Language: "go", OutputPath: "gen", Recursive: true, AST: ast}
+		res := new(GoBackend).Generate(req, backend.DummyLogFunc())
+		test.Assert(t, res.Error == nil, res.GetError())
+		for _, c := range res.Contents {
+			if c.Name != nil {
+				names = append(names, filepath.Base(c.GetName()))
+			}
+			contents = append(contents, c.Content)
+		}
+		return
+	}
+	names, contents := generate()
+	test.DeepEqual(t, names, want)
+	for i := 0; i < 5; i++ {
+		_, again := generate()
+		test.DeepEqual(t, again, contents)
+	}
+}
+
+func TestScopes(t *testing.T) {
+	dir := t.TempDir()
+	idl := `namespace go example.demo
+typedef string ID
+const i32 max_size = 10
+enum status { ok, failed }
+struct user_info { 1: optional ID user_id }
+service user_service { user_info get_user(1: ID id) }
+`
+	test.Assert(t, os.WriteFile(filepath.Join(dir, "demo.thrift"), []byte(idl), 0o644) == nil)
+	ast, err := parser.ParseFile(filepath.Join(dir, "demo.thrift"), nil, true)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, semantic.ResolveSymbols(ast) == nil)
+
+	req := &plugin.Request{
+		Language:   "go",
+		OutputPath: "gen",
+		AST:        ast,
+		GeneratorParameters: []string{
+			"package_prefix=github.com/x/y",
+			"naming_style=golint",
+			"use_package=github.com/x/y/example/demo=github.com/z/demo",
+		},
+	}
+	g := new(GoBackend)
+	res := g.Generate(req, backend.DummyLogFunc())
+	test.Assert(t, res.Error == nil, res.GetError())
+
+	scopes := g.Scopes()
+	test.Assert(t, len(scopes) == 1, scopes)
+	s := scopes[0]
+	test.Assert(t, s.Filename == ast.Filename, s.Filename)
+	test.Assert(t, s.Package == "demo", s.Package)
+	test.Assert(t, s.ImportPath == "github.com/z/demo", s.ImportPath)
+	test.Assert(t, s.File == filepath.Join("gen", "example", "demo", "demo.go"), s.File)
+
+	symbols := make(map[string]*plugin.Symbol)
+	for _, sym := range s.Symbols {
+		symbols[sym.Kind+" "+sym.Name] = sym
+	}
+	for key, id := range map[string]string{
+		"typedef ID":                     "ID",
+		"constant max_size":              "MaxSize",
+		"enum status":                    "Status",
+		"enum_value status.failed":       "Status_failed",
+		"struct user_info":               "UserInfo",
+		"field user_info.user_id":        "UserId",
+		"service user_service":           "UserService",
+		"function user_service.get_user": "GetUser",
+	} {
+		sym := symbols[key]
+		test.Assert(t, sym != nil, key)
+		test.Assert(t, sym.Identifier == id, key, sym.Identifier)
+	}
+	test.DeepEqual(t, symbols["struct user_info"].Extra, map[string]string{"constructor": "NewUserInfo"})
+	test.DeepEqual(t, symbols["field user_info.user_id"].Extra, map[string]string{
+		"getter": "GetUserId", "is_set": "IsSetUserId",
+	})
+	test.DeepEqual(t, symbols["function user_service.get_user"].Extra, map[string]string{
+		"args": "UserServiceGetUserArgs", "result": "UserServiceGetUserResult",
+	})
+}
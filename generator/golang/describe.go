@@ -0,0 +1,89 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/generator/golang/streaming"
+)
+
+// DescribeOptions implements the backend.Describer interface.
+func (g *GoBackend) DescribeOptions() (infos []backend.OptionInfo) {
+	for _, p := range allParams {
+		info := backend.OptionInfo{
+			Name:        p.name,
+			Type:        p.typ,
+			Default:     p.def,
+			Description: p.desc,
+			Repeated:    p.repeated,
+		}
+		for _, r := range featureRules {
+			if r.option != p.name && !(r.kind == conflicts && r.other == p.name) {
+				continue
+			}
+			switch other := r.other; r.kind {
+			case conflicts:
+				if other == p.name {
+					other = r.option
+				}
+				info.Conflicts = append(info.Conflicts, other)
+			case requires:
+				info.Requires = append(info.Requires, other)
+			case needs:
+				info.EffectiveWith = append(info.EffectiveWith, other)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return
+}
+
+// Annotations implements the backend.Describer interface.
+func (g *GoBackend) Annotations() []backend.AnnotationInfo {
+	return []backend.AnnotationInfo{
+		{
+			Name:        "go.tag",
+			On:          []string{"field"},
+			Description: "Struct tags of the generated field, like `json:\"id\"`. A json tag here replaces the default one.",
+		},
+		{
+			Name:        nestedAnnotation,
+			On:          []string{"field"},
+			Values:      []string{"true", "false"},
+			Description: "Embed the field anonymously. Requires enable_nested_struct.",
+		},
+		{
+			Name:        aliasAnnotation,
+			On:          []string{"struct", "union", "exception"},
+			Values:      []string{"true", "false"},
+			Description: "Mark the type as an alias. Used by no_alias_type_reflection_method.",
+		},
+		{
+			Name:        interfaceAnnotation,
+			On:          []string{"struct", "union", "exception", "typedef"},
+			Values:      []string{"true", "false"},
+			Description: "Refer to the type without a pointer. Used by enable_ref_interface.",
+		},
+		{
+			Name: streaming.StreamingModeKey,
+			On:   []string{"function"},
+			Values: []string{
+				streaming.StreamingBidirectional, streaming.StreamingClientSide,
+				streaming.StreamingServerSide, streaming.StreamingUnary,
+			},
+			Description: "The streaming mode of the function. Used by thrift_streaming.",
+		},
+	}
+}
@@ -38,8 +38,11 @@ var (
 	errMissingVersion = errors.New("ReadMessageBegin: missing version")
 	errBadVersion     = errors.New("ReadMessageBegin: bad version")
 	errTooDeep        = errors.New("depth limit exceeded")
+	errNoUUID         = errors.New("the protocol does not support uuid")
 )
 
+var _ UUIDProtocol = (*BinaryProtocol)(nil)
+
 // BinaryProtocol implements the binary protocol of thrift.
 type BinaryProtocol struct {
 	transport   RichTransport
@@ -217,6 +220,12 @@ func (p *BinaryProtocol) ReadBinary(ctx context.Context) ([]byte, error) {
 	return buf, err
 }
 
+// ReadUUID .
+func (p *BinaryProtocol) ReadUUID(ctx context.Context) (value [16]byte, err error) {
+	_, err = io.ReadFull(p.transport, value[:])
+	return
+}
+
 // ReadMapBegin .
 func (p *BinaryProtocol) ReadMapBegin(ctx context.Context) (keyType, valueType TTypeID, size int, err error) {
 	if keyType, err = p.readTypeID(ctx); err != nil {
@@ -370,6 +379,12 @@ func (p *BinaryProtocol) WriteBinary(ctx context.Context, value []byte) (err err
 	return
 }
 
+// WriteUUID .
+func (p *BinaryProtocol) WriteUUID(ctx context.Context, value [16]byte) (err error) {
+	_, err = p.transport.Write(value[:])
+	return
+}
+
 // WriteMapBegin .
 func (p *BinaryProtocol) WriteMapBegin(ctx context.Context, keyType, valueType TTypeID, size int) (err error) {
 	if err = p.WriteByte(ctx, int8(keyType)); err == nil {
@@ -465,6 +480,12 @@ func Skip(ctx context.Context, iprot Protocol, typeID TTypeID, maxDepth int) (er
 		_, err = iprot.ReadDouble(ctx)
 	case TTypeID_STRING:
 		_, err = iprot.ReadString(ctx)
+	case TTypeID_UUID:
+		if p, ok := iprot.(UUIDProtocol); ok {
+			_, err = p.ReadUUID(ctx)
+		} else {
+			err = errNoUUID
+		}
 	case TTypeID_STRUCT:
 		if _, err = iprot.ReadStructBegin(ctx); err != nil {
 			return
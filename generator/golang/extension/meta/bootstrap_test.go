@@ -96,3 +96,11 @@ func dumpDetail(fn string, obj interface{}, log string, bites []byte) {
 	f.Write([]byte{'\n'})
 	fmt.Fprintf(f, "%#v\n", bites)
 }
+
+func TestTTypeIDNames(t *testing.T) {
+	for _, name := range []string{"UUID", "UTF8"} {
+		id, err := TTypeIDFromString(name)
+		test.Assert(t, err == nil && id == TTypeID_UUID, id, err)
+	}
+	test.Assert(t, TTypeID_UTF8.String() == "UUID")
+}
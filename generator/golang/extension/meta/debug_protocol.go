@@ -127,6 +127,18 @@ func (p *DebugProtocol) ReadBinary(ctx context.Context) (value []byte, err error
 	return
 }
 
+// ReadUUID .
+func (p *DebugProtocol) ReadUUID(ctx context.Context) (value [16]byte, err error) {
+	if impl, ok := p.impl.(UUIDProtocol); ok {
+		value, err = impl.ReadUUID(ctx)
+	} else {
+		err = errNoUUID
+	}
+	indent := strings.Repeat("  ", p.indent)
+	p.logf("%sReadUUID() (value=%#v, err=%#v)", indent, value, err)
+	return
+}
+
 // ReadMapBegin .
 func (p *DebugProtocol) ReadMapBegin(ctx context.Context) (keyType, valueType TTypeID, size int, err error) {
 	keyType, valueType, size, err = p.impl.ReadMapBegin(ctx)
@@ -307,6 +319,18 @@ func (p *DebugProtocol) WriteBinary(ctx context.Context, value []byte) (err erro
 	return
 }
 
+// WriteUUID .
+func (p *DebugProtocol) WriteUUID(ctx context.Context, value [16]byte) (err error) {
+	if impl, ok := p.impl.(UUIDProtocol); ok {
+		err = impl.WriteUUID(ctx, value)
+	} else {
+		err = errNoUUID
+	}
+	indent := strings.Repeat("  ", p.indent)
+	p.logf("%sWriteUUID(value=%#v) => %#v", indent, value, err)
+	return
+}
+
 // WriteMapBegin .
 func (p *DebugProtocol) WriteMapBegin(ctx context.Context, keyType, valueType TTypeID, size int) (err error) {
 	err = p.impl.WriteMapBegin(ctx, keyType, valueType, size)
@@ -95,7 +95,7 @@ const (
 	TTypeID_MAP    TTypeID = 13
 	TTypeID_SET    TTypeID = 14
 	TTypeID_LIST   TTypeID = 15
-	TTypeID_UTF8   TTypeID = 16
+	TTypeID_UUID   TTypeID = 16
 	TTypeID_UTF16  TTypeID = 17
 )
 
@@ -127,8 +127,8 @@ func (p TTypeID) String() string {
 		return "SET"
 	case TTypeID_LIST:
 		return "LIST"
-	case TTypeID_UTF8:
-		return "UTF8"
+	case TTypeID_UUID:
+		return "UUID"
 	case TTypeID_UTF16:
 		return "UTF16"
 	}
@@ -163,8 +163,8 @@ func TTypeIDFromString(s string) (TTypeID, error) {
 		return TTypeID_SET, nil
 	case "LIST":
 		return TTypeID_LIST, nil
-	case "UTF8":
-		return TTypeID_UTF8, nil
+	case "UUID", "UTF8": // UTF8 is the former name of UUID
+		return TTypeID_UUID, nil
 	case "UTF16":
 		return TTypeID_UTF16, nil
 	}
@@ -36,7 +36,7 @@ enum TTypeID {
 	MAP    = 13
 	SET    = 14
 	LIST   = 15
-	UTF8   = 16
+	UUID   = 16
 	UTF16  = 17
 }
 
@@ -18,6 +18,12 @@ import (
 	"context"
 )
 
+// TTypeID_UTF8 is the former name of TTypeID_UUID, whose value was reserved
+// for UTF-8 strings in early versions of thrift.
+//
+// Deprecated: use TTypeID_UUID instead.
+const TTypeID_UTF8 = TTypeID_UUID
+
 // Protocol is an abstraction for input and output protocols in thrift.
 type Protocol interface {
 	ReadMessageBegin(ctx context.Context) (name string, typeID TMessageType, seqID int32, err error)
@@ -64,3 +70,11 @@ type Protocol interface {
 	WriteFieldStop(ctx context.Context) error
 	Flush(ctx context.Context) (err error)
 }
+
+// UUIDProtocol is implemented by protocols that support the uuid type. It is
+// separated from Protocol to keep the existing implementations of Protocol
+// valid, so users should check for it with a type assertion.
+type UUIDProtocol interface {
+	ReadUUID(ctx context.Context) (value [16]byte, err error)
+	WriteUUID(ctx context.Context, value [16]byte) error
+}
@@ -82,6 +82,7 @@ func (im *importManager) init(cu *CodeUtils, ast *parser.Thrift) {
 		"sql":               "database/sql",
 		"strings":           "strings",
 		"bytes":             "bytes",
+		"io":                "io",
 		"reflect":           "reflect",
 		"thrift":            DefaultThriftLib,
 		"unknown":           DefaultUnknownLib,
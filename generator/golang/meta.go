@@ -49,6 +49,8 @@ func type2typeInfo(ast *parser.Thrift, typ *parser.Type, index int) *meta.TypeMe
 		return &meta.TypeMeta{TypeID: meta.TTypeID_DOUBLE}
 	case parser.Category_String, parser.Category_Binary:
 		return &meta.TypeMeta{TypeID: meta.TTypeID_STRING}
+	case parser.Category_UUID:
+		return &meta.TypeMeta{TypeID: meta.TTypeID_UUID}
 	case parser.Category_Struct, parser.Category_Union, parser.Category_Exception:
 		return &meta.TypeMeta{TypeID: meta.TTypeID_STRUCT}
 	case parser.Category_Map:
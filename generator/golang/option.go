@@ -17,6 +17,7 @@ package golang
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/cloudwego/thriftgo/generator/golang/styles"
@@ -115,9 +116,12 @@ var defaultFeatures = Features{
 }
 
 type param struct {
-	name   string
-	desc   string
-	action func(value string, cu *CodeUtils) error
+	name     string
+	desc     string
+	typ      string // "bool" or "string"
+	def      string // the default value
+	repeated bool   // whether the option can be given more than once
+	action   func(value string, cu *CodeUtils) error
 }
 
 func (p *param) match(value string) bool {
@@ -128,14 +132,18 @@ var codeUtilsParams = []*param{
 	{
 		name: "thrift_import_path",
 		desc: "Override thrift package import path (default:github.com/apache/thrift/lib/go/thrift)",
+		typ:  "string",
+		def:  DefaultThriftLib,
 		action: func(value string, cu *CodeUtils) error {
 			cu.UsePackage(DefaultThriftLib, value)
 			return nil
 		},
 	},
 	{
-		name: "use_package",
-		desc: "Specify an import path replacement. Form: 'path=repl', (e.g. 'database/sql/driver=example.com/my/dirver')",
+		name:     "use_package",
+		desc:     "Specify an import path replacement. Form: 'path=repl', (e.g. 'database/sql/driver=example.com/my/dirver')",
+		typ:      "string",
+		repeated: true,
 		action: func(value string, cu *CodeUtils) error {
 			parts := strings.SplitN(value, "=", 2)
 			if len(parts) < 2 {
@@ -150,6 +158,8 @@ var codeUtilsParams = []*param{
 		desc: fmt.Sprintf(
 			"Set the naming style for identifiers: %s. Default is 'thriftgo'.",
 			strings.Join(styles.NamingStyles(), ", ")),
+		typ: "string",
+		def: "thriftgo",
 		action: func(value string, cu *CodeUtils) error {
 			style := styles.NewNamingStyle(value)
 			if style == nil {
@@ -162,6 +172,8 @@ var codeUtilsParams = []*param{
 	{
 		name: "ignore_initialisms",
 		desc: "Disable spelling correction of initialisms (e.g. 'URL')",
+		typ:  "bool",
+		def:  "false",
 		action: func(value string, cu *CodeUtils) error {
 			ignore, err := checkBool("ignore_initialisms", value)
 			if err != nil {
@@ -174,6 +186,7 @@ var codeUtilsParams = []*param{
 	{
 		name: "package_prefix",
 		desc: "Specify a package prefix for all generated codes.",
+		typ:  "string",
 		action: func(value string, cu *CodeUtils) error {
 			cu.SetPackagePrefix(value)
 			return nil
@@ -182,6 +195,8 @@ var codeUtilsParams = []*param{
 	{
 		name: "template",
 		desc: "Specify a different template to generate codes. (current available templates: 'slim', 'raw_struct')",
+		typ:  "string",
+		def:  defaultTemplate,
 		action: func(value string, cu *CodeUtils) error {
 			return cu.UseTemplate(value)
 		},
@@ -204,6 +219,8 @@ func (fs Features) params() (ps []*param) {
 		p := &param{
 			name: n,
 			desc: v,
+			typ:  "bool",
+			def:  strconv.FormatBool(x.Field(i).Bool()),
 			action: func(value string, cu *CodeUtils) error {
 				val, err := checkBool(name, value)
 				if err != nil {
@@ -276,39 +293,68 @@ next:
 }
 
 func (cu *CodeUtils) validateOptions() error {
-	f := cu.features
-
-	if f.ApacheWarning && f.ApacheAdaptor {
-		return fmt.Errorf("apache_warning and apache_adaptor are mutually exclusive")
+	errs, warns := cu.features.check()
+	if len(errs) > 0 {
+		return errs[0]
 	}
-
-	if f.WithFieldMask && !f.WithReflection {
-		return fmt.Errorf("with_field_mask requires with_reflection")
+	for _, w := range warns {
+		cu.Warn(w)
 	}
+	return nil
+}
 
-	if f.SnakeTyleJSONTag && f.LowerCamelCaseJSONTag {
-		return fmt.Errorf("snake_style_json_tag and lower_camel_style_json_tag are mutually exclusive")
-	}
+// The kinds of relations between features.
+const (
+	conflicts = "conflicts" // both enabled is an error
+	requires  = "requires"  // enabled without the other is an error
+	needs     = "needs"     // enabled without the other has no effect
+)
 
-	if !f.GenerateJSONTag && f.AlwaysGenerateJSONTag {
-		return fmt.Errorf("always_gen_json_tag requires gen_json_tag")
-	}
+// featureRule is a relation between two features.
+type featureRule struct {
+	option, kind, other string
+}
 
-	if f.AlwaysGenerateJSONTag {
-		cu.Warn("always_gen_json_tag is deprecated: gen_json_tag now keeps the default json tag when go.tag has no json key")
-	}
+// featureRules are checked by Features.check and reported by DescribeOptions.
+var featureRules = []featureRule{
+	{"apache_warning", conflicts, "apache_adaptor"},
+	{"with_field_mask", requires, "with_reflection"},
+	{"snake_style_json_tag", conflicts, "lower_camel_style_json_tag"},
+	{"always_gen_json_tag", requires, "gen_json_tag"},
+	{"streamx", needs, "thrift_streaming"},
+	{"field_mask_halfway", needs, "with_field_mask"},
+	{"snake_style_json_tag", needs, "gen_json_tag"},
+	{"lower_camel_style_json_tag", needs, "gen_json_tag"},
+}
 
-	if f.StreamX && !f.ThriftStreaming {
-		cu.Warn("streamx has no effect without thrift_streaming")
+// check reports the conflicting features as errors and the features that
+// have no effect as warnings.
+func (f *Features) check() (errs []error, warns []string) {
+	for _, r := range featureRules {
+		if !f.enabled(r.option) {
+			continue
+		}
+		switch other := f.enabled(r.other); {
+		case r.kind == conflicts && other:
+			errs = append(errs, fmt.Errorf("%s and %s are mutually exclusive", r.option, r.other))
+		case r.kind == requires && !other:
+			errs = append(errs, fmt.Errorf("%s requires %s", r.option, r.other))
+		case r.kind == needs && !other:
+			warns = append(warns, fmt.Sprintf("%s has no effect without %s", r.option, r.other))
+		}
 	}
 
-	if f.FieldMaskHalfway && !f.WithFieldMask {
-		cu.Warn("field_mask_halfway has no effect without with_field_mask")
+	if f.AlwaysGenerateJSONTag {
+		warns = append(warns, "always_gen_json_tag is deprecated: gen_json_tag now keeps the default json tag when go.tag has no json key")
 	}
+	return
+}
 
-	if !f.GenerateJSONTag && (f.SnakeTyleJSONTag || f.LowerCamelCaseJSONTag) {
-		cu.Warn("json tag style options have no effect when gen_json_tag is disabled")
+func (f *Features) enabled(name string) bool {
+	for i, n := range featureNames {
+		if n == name {
+			return reflect.ValueOf(f).Elem().Field(i).Bool()
+		}
 	}
-
-	return nil
+	return false
 }
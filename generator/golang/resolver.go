@@ -212,6 +212,9 @@ func (r *Resolver) resolveConst(g *Scope, name string, t *parser.Type, v *parser
 	case parser.Category_String, parser.Category_Binary:
 		return r.onStrBin(g, name, t, v)
 
+	case parser.Category_UUID:
+		return r.onUUID(g, name, t, v)
+
 	case parser.Category_Enum:
 		return r.onEnum(g, name, t, v)
 
@@ -321,6 +324,28 @@ func (r *Resolver) onStrBin(g *Scope, name string, t *parser.Type, v *parser.Con
 	return "", errTypeMissMatch(name, t, v)
 }
 
+func (r *Resolver) onUUID(g *Scope, name string, t *parser.Type, v *parser.ConstValue) (string, error) {
+	switch v.Type {
+	case parser.ConstType_ConstLiteral:
+		uuid, err := parser.ParseUUID(v.TypedValue.GetLiteral())
+		if err != nil {
+			return "", fmt.Errorf("type error: '%s': %w", name, err)
+		}
+		bs := make([]string, len(uuid))
+		for i, b := range uuid {
+			bs[i] = fmt.Sprintf("0x%02x", b)
+		}
+		return "[16]byte{" + strings.Join(bs, ", ") + "}", nil
+	case parser.ConstType_ConstIdentifier:
+		s := v.TypedValue.GetIdentifier()
+		if val, ok := r.getIDValue(g, v.Extra); ok {
+			return val, nil
+		}
+		return "", fmt.Errorf("undefined value: %q", s)
+	}
+	return "", errTypeMissMatch(name, t, v)
+}
+
 func (r *Resolver) onEnum(g *Scope, name string, t *parser.Type, v *parser.ConstValue) (string, error) {
 	switch v.Type {
 	case parser.ConstType_ConstInt:
@@ -18,7 +18,6 @@ import (
 	"errors"
 	"strings"
 
-	"github.com/cloudwego/thriftgo/config"
 	"github.com/cloudwego/thriftgo/parser"
 )
 
@@ -48,7 +47,7 @@ func (s *Scope) GetFirstDescriptor() string {
 }
 
 func BuildRefScope(cu *CodeUtils, ast *parser.Thrift) (*Scope, *Scope, error) {
-	thriftRef := config.GetRef(ast.Filename)
+	thriftRef := cu.getRef(ast.Filename)
 	enableCodeRef := cu.Features().CodeRef || cu.Features().CodeRefSlim || cu.Features().ExpCodeRef
 	scope, err := BuildScope(cu, ast)
 	if err != nil {
@@ -0,0 +1,128 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"path/filepath"
+
+	"github.com/cloudwego/thriftgo/plugin"
+)
+
+// The kinds of symbols reported to plugins.
+const (
+	symbolTypedef   = "typedef"
+	symbolConstant  = "constant"
+	symbolEnum      = "enum"
+	symbolEnumValue = "enum_value"
+	symbolField     = "field"
+	symbolService   = "service"
+	symbolFunction  = "function"
+)
+
+// Scopes implements the backend.ScopeReporter interface. It reports the
+// identifiers generated for the IDLs processed by the last call of Generate.
+func (g *GoBackend) Scopes() (ss []*plugin.Scope) {
+	if g.err != nil {
+		return nil
+	}
+	for _, ast := range g.asts {
+		local, ref, err := BuildRefScope(g.utils, ast)
+		if err != nil {
+			return nil
+		}
+		filename := filepath.Join(g.utils.CombineOutputPath(g.req.OutputPath, ast), g.utils.GetFilename(ast))
+		scope := local
+		if scope == nil {
+			scope = ref
+			filename = ToRefFilename(g.utils.Features().KeepCodeRefName, filename)
+		}
+		ss = append(ss, g.utils.exportScope(scope, filename))
+	}
+	return ss
+}
+
+// exportScope converts a scope to its form in the plugin protocol.
+func (cu *CodeUtils) exportScope(scope *Scope, filename string) *plugin.Scope {
+	importPath := scope.importPath
+	if repl, ok := cu.importReplace[importPath]; ok {
+		importPath = repl
+	}
+	s := &plugin.Scope{
+		Filename:   scope.ast.Filename,
+		Package:    scope.importPackage,
+		ImportPath: importPath,
+		File:       filename,
+	}
+	add := func(kind, name string, id Name, extra map[string]string) {
+		s.Symbols = append(s.Symbols, &plugin.Symbol{
+			Kind:       kind,
+			Name:       name,
+			Identifier: id.String(),
+			Extra:      extra,
+		})
+	}
+	for _, t := range scope.typedefs {
+		add(symbolTypedef, t.Alias, t.GoName(), nil)
+	}
+	for _, c := range scope.constants {
+		add(symbolConstant, c.Name, c.GoName(), nil)
+	}
+	for _, e := range scope.enums {
+		add(symbolEnum, e.Name, e.GoName(), nil)
+		for _, v := range e.Values() {
+			add(symbolEnumValue, e.Name+"."+v.Name, v.GoName(), nil)
+		}
+	}
+	for _, st := range scope.StructLikes() {
+		add(st.Category, st.Name, st.GoName(), map[string]string{
+			"constructor": TypeName(st.GoName()).NewFunc().String(),
+		})
+		for _, f := range st.Fields() {
+			add(symbolField, st.Name+"."+f.Name, f.GoName(), names(map[string]Name{
+				"getter": f.Getter(),
+				"setter": f.Setter(),
+				"is_set": f.IsSetter(),
+			}))
+		}
+	}
+	for _, svc := range scope.services {
+		add(symbolService, svc.Name, svc.GoName(), nil)
+		for _, f := range svc.Functions() {
+			extra := map[string]Name{}
+			if f.ArgType() != nil {
+				extra["args"] = f.ArgType().GoName()
+			}
+			if f.ResType() != nil {
+				extra["result"] = f.ResType().GoName()
+			}
+			add(symbolFunction, svc.Name+"."+f.Name, f.GoName(), names(extra))
+		}
+	}
+	return s
+}
+
+// names converts the non-empty names to strings.
+func names(m map[string]Name) map[string]string {
+	res := make(map[string]string, len(m))
+	for k, v := range m {
+		if v != "" {
+			res[k] = v.String()
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
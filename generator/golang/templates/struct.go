@@ -587,13 +587,26 @@ var FieldReadStructLike = `
 // FieldReadBaseType .
 var FieldReadBaseType = `
 {{define "FieldReadBaseType"}}
-	{{- $DiffType := or .Type.Category.IsEnum .Type.Category.IsBinary}}
+	{{- $DiffType := or .Type.Category.IsEnum .Type.Category.IsBinary .Type.Category.IsUUID}}
 	{{- if .NeedDecl}}
 	var {{.Target}} {{.TypeName}}
 	{{- end}}
+	{{- if .Type.Category.IsUUID}}
+	{{- UseStdLibrary "io" "fmt"}}
+	{{- $uuid := .GenID "_uuid"}}
+	{{- $err := .GenID "_err"}}
+	var {{$uuid}} [16]byte
+	var {{$err}} error
+	{{UUIDReader "iprot" $uuid $err}}
+	if err := {{$err}}; err != nil {
+		return err
+	} else {
+		v := {{$uuid}}
+	{{- else}}
 	if v, err := iprot.Read{{.TypeID}}(); err != nil {
 		return err
 	} else {
+	{{- end}}
 	{{- if .IsPointer}}
 		{{- if $DiffType}}
 		tmp := {{.TypeName.Deref}}(v)
@@ -830,9 +843,20 @@ var FieldWriteBaseType = `
 {{- if .IsPointer}}{{$Value = printf "*%s" $Value}}{{end}}
 {{- if .Type.Category.IsEnum}}{{$Value = printf "int32(%s)" $Value}}{{end}}
 {{- if .Type.Category.IsBinary}}{{$Value = printf "[]byte(%s)" $Value}}{{end}}
+	{{- if .Type.Category.IsUUID}}
+	{{- UseStdLibrary "fmt"}}
+	{
+		var err error
+		{{UUIDWriter "oprot" $Value "err"}}
+		if err != nil {
+			return err
+		}
+	}
+	{{- else}}
 	if err := oprot.Write{{.TypeID}}({{$Value}}); err != nil {
 		return err
 	}
+	{{- end}}
 {{- end}}{{/* define "FieldWriteBaseType" */}}
 `
 
@@ -26,8 +26,12 @@ import (
 func GetTypeIDConstant(t *parser.Type) string {
 	tid := GetTypeID(t)
 	tid = strings.ToUpper(tid)
-	if tid == "BINARY" {
+	switch tid {
+	case "BINARY":
 		tid = "STRING"
+	case "UUID":
+		// older thrift libraries name the type ID UTF8, so the value is used
+		tid = "TType(16)"
 	}
 	return tid
 }
@@ -36,7 +40,7 @@ func GetTypeIDConstant(t *parser.Type) string {
 // to concate with "Read" or "Write" to produce a valid method name in the TProtocol
 // interface. Note that enum types results in I32.
 func GetTypeID(t *parser.Type) string {
-	// Bool|Byte|I16|I32|I64|Double|String|Binary|Set|List|Map|Struct
+	// Bool|Byte|I16|I32|I64|Double|String|Binary|UUID|Set|List|Map|Struct
 	return category2TypeID[t.Category]
 }
 
@@ -74,6 +78,8 @@ func ZeroWriter(t *parser.Type, oprot string, err string) string {
 		return checkErrorTPL(oprot+".WriteString(\"\")", err)
 	case parser.Category_Binary:
 		return checkErrorTPL(oprot+".WriteBinary([]byte{})", err)
+	case parser.Category_UUID:
+		return UUIDWriter(oprot, "[16]byte{}", "err") + "if err != nil {\n goto " + err + "\n}\n"
 	case parser.Category_Map:
 		return checkErrorTPL(oprot+".WriteMapBegin(thrift."+GetTypeIDConstant(t.GetKeyType())+
 			",thrift."+GetTypeIDConstant(t.GetValueType())+",0)", err) + checkErrorTPL(oprot+".WriteMapEnd()", err)
@@ -91,6 +97,42 @@ func ZeroWriter(t *parser.Type, oprot string, err string) string {
 	}
 }
 
+// UUIDReader returns the statements that read a uuid from iprot into the
+// variable value and set the variable errVar. With the versions of thrift
+// that lack ReadUUID, the 16 bytes are read from the transport of the binary
+// protocol, and other protocols fail.
+func UUIDReader(iprot, value, errVar string) string {
+	return fmt.Sprintf(`if r, ok := %[1]s.(interface{ ReadUUID() ([16]byte, error) }); ok {
+	%[2]s, %[3]s = r.ReadUUID()
+} else {
+	switch %[1]s.(type) {
+	case *thrift.TBinaryProtocol:
+		_, %[3]s = io.ReadFull(%[1]s.Transport(), %[2]s[:])
+	default:
+		%[3]s = thrift.NewTProtocolExceptionWithType(thrift.NOT_IMPLEMENTED, fmt.Errorf("%%T does not support uuid", %[1]s))
+	}
+}
+`, iprot, value, errVar)
+}
+
+// UUIDWriter returns the statements that write the uuid value with oprot and
+// set the variable errVar. The versions of thrift without WriteUUID are
+// supported like UUIDReader does.
+func UUIDWriter(oprot, value, errVar string) string {
+	return fmt.Sprintf(`if w, ok := %[1]s.(interface{ WriteUUID([16]byte) error }); ok {
+	%[3]s = w.WriteUUID(%[2]s)
+} else {
+	switch %[1]s.(type) {
+	case *thrift.TBinaryProtocol:
+		v := %[2]s
+		_, %[3]s = %[1]s.Transport().Write(v[:])
+	default:
+		%[3]s = thrift.NewTProtocolExceptionWithType(thrift.NOT_IMPLEMENTED, fmt.Errorf("%%T does not support uuid", %[1]s))
+	}
+}
+`, oprot, value, errVar)
+}
+
 // IsIntType determines whether the given type is a Int type.
 func IsIntType(t *parser.Type) bool {
 	switch t.Category {
@@ -132,7 +174,7 @@ func NeedRedirect(f *parser.Field) bool {
 // IsConstantInGo tells whether a constant in thrift IDL results in a constant in go.
 func IsConstantInGo(v *parser.Constant) bool {
 	c := v.Type.Category
-	if c.IsBaseType() && c != parser.Category_Binary {
+	if c.IsBaseType() && c != parser.Category_Binary && c != parser.Category_UUID {
 		return true
 	}
 	return c == parser.Category_Enum
@@ -0,0 +1,342 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/generator/golang/styles"
+	"github.com/cloudwego/thriftgo/generator/golang/templates"
+	"github.com/cloudwego/thriftgo/plugin"
+)
+
+// Options is the typed form of the options of the Go backend, which are given
+// as "-g go:k=v,..." on the command line. The zero value keeps the default
+// behavior.
+type Options struct {
+	// Features maps the names of boolean options, like "with_reflection", to
+	// their values. The features not in it keep their defaults.
+	Features map[string]bool
+
+	// ThriftImportPath overrides the import path of the thrift library.
+	ThriftImportPath string
+	// UsePackages maps import paths to their replacements.
+	UsePackages map[string]string
+	// NamingStyle is one of styles.NamingStyles().
+	NamingStyle       string
+	IgnoreInitialisms bool
+	PackagePrefix     string
+	// Template is "slim", "raw_struct" or the default one. It defaults to
+	// "slim" if enable_nested_struct is on.
+	Template string
+}
+
+// DefaultOptions returns the options used when none is given.
+func DefaultOptions() *Options {
+	return &Options{}
+}
+
+// FeatureSet returns the default features updated by Features.
+func (o *Options) FeatureSet() Features {
+	fs := defaultFeatures
+	v := reflect.ValueOf(&fs).Elem()
+	for i, name := range featureNames {
+		if val, ok := o.Features[name]; ok {
+			v.Field(i).SetBool(val)
+		}
+	}
+	return fs
+}
+
+// ParseOptions converts options in the "-g go:k=v" form into Options and
+// validates them. Unlike HandleOptions, names must match exactly and unknown
+// options are errors.
+func ParseOptions(opts []plugin.Option) (*Options, error) {
+	o := DefaultOptions()
+	for _, opt := range opts {
+		if err := o.set(opt.Name, opt.Desc); err != nil {
+			return nil, err
+		}
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Validate reports invalid values and conflicting options.
+func (o *Options) Validate() error {
+	var errs []error
+	for _, name := range o.featureNames() {
+		if _, ok := featureIndex[name]; !ok {
+			errs = append(errs, fmt.Errorf("unsupported option: '%s'", name))
+		}
+	}
+	if o.NamingStyle != "" && styles.NewNamingStyle(o.NamingStyle) == nil {
+		errs = append(errs, fmt.Errorf("unsupported naming style: '%s'", o.NamingStyle))
+	}
+	if o.Template != "" && o.Template != defaultTemplate && templates.Alternative()[o.Template] == nil {
+		errs = append(errs, fmt.Errorf("unknown template name: %q", o.Template))
+	}
+	for _, path := range o.packages() {
+		if path == "" || strings.Contains(path, "=") || o.UsePackages[path] == "" {
+			errs = append(errs, fmt.Errorf("invalid argument for use_package: '%s=%s'", path, o.UsePackages[path]))
+		}
+	}
+	features := o.FeatureSet()
+	fs, _ := features.check()
+	errs = append(errs, fs...)
+	if o.Features["enable_nested_struct"] && !NestableTemplate(o.template()) {
+		errs = append(errs, fmt.Errorf("enable_nested_struct requires the 'slim' or 'raw_struct' template"))
+	}
+	return errors.Join(errs...)
+}
+
+// NestableTemplate tells whether enable_nested_struct works with the template.
+func NestableTemplate(name string) bool {
+	return name == "slim" || name == "raw_struct"
+}
+
+// template returns the template to use. Like on the command line, a missing
+// template defaults to "slim" if enable_nested_struct is on.
+func (o *Options) template() string {
+	if o.Template == "" && o.Features["enable_nested_struct"] {
+		return "slim"
+	}
+	return o.Template
+}
+
+// Pack converts the options into the "-g go:k=v" form. Only the values that
+// differ from the defaults are included, in a stable order.
+func (o *Options) Pack() (opts []plugin.Option) {
+	add := func(name, value string) {
+		opts = append(opts, plugin.Option{Name: name, Desc: value})
+	}
+	if o.ThriftImportPath != "" {
+		add("thrift_import_path", o.ThriftImportPath)
+	}
+	for _, path := range o.packages() {
+		add("use_package", path+"="+o.UsePackages[path])
+	}
+	if o.NamingStyle != "" {
+		add("naming_style", o.NamingStyle)
+	}
+	if o.IgnoreInitialisms {
+		add("ignore_initialisms", "true")
+	}
+	if o.PackagePrefix != "" {
+		add("package_prefix", o.PackagePrefix)
+	}
+	if t := o.template(); t != "" && t != defaultTemplate {
+		add("template", t)
+	}
+
+	def := reflect.ValueOf(defaultFeatures)
+	for i, name := range featureNames {
+		if v, ok := o.Features[name]; ok && v != def.Field(i).Bool() {
+			add(name, strconv.FormatBool(v))
+		}
+	}
+	return
+}
+
+func (o *Options) featureNames() (names []string) {
+	for name := range o.Features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+func (o *Options) packages() (paths []string) {
+	for path := range o.UsePackages {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return
+}
+
+func (o *Options) set(name, value string) error {
+	switch name {
+	case "thrift_import_path":
+		o.ThriftImportPath = value
+	case "use_package":
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) < 2 {
+			return fmt.Errorf("invalid argument for use_package: '%s'", value)
+		}
+		o.usePackage(parts[0], parts[1])
+	case "naming_style":
+		o.NamingStyle = value
+	case "ignore_initialisms":
+		ignore, err := checkBool(name, value)
+		if err != nil {
+			return err
+		}
+		o.IgnoreInitialisms = ignore
+	case "package_prefix":
+		o.PackagePrefix = value
+	case "template":
+		o.Template = value
+	default:
+		val, err := checkBool(name, value)
+		if err != nil {
+			return err
+		}
+		return o.setFeature(name, val)
+	}
+	return nil
+}
+
+func (o *Options) usePackage(path, repl string) {
+	if o.UsePackages == nil {
+		o.UsePackages = make(map[string]string)
+	}
+	o.UsePackages[path] = repl
+}
+
+func (o *Options) setFeature(name string, value bool) error {
+	if _, ok := featureIndex[name]; !ok {
+		return fmt.Errorf("unsupported option: '%s'", name)
+	}
+	if o.Features == nil {
+		o.Features = make(map[string]bool)
+	}
+	o.Features[name] = value
+	return nil
+}
+
+// featureNames are the option names of the fields of Features.
+var featureNames = func() (ns []string) {
+	t := reflect.TypeOf(Features{})
+	for i := 0; i < t.NumField(); i++ {
+		ns = append(ns, strings.SplitN(string(t.Field(i).Tag), ":", 2)[0])
+	}
+	return
+}()
+
+// featureIndex maps the option names of Features to the indexes of fields.
+var featureIndex = func() map[string]int {
+	m := make(map[string]int, len(featureNames))
+	for i, name := range featureNames {
+		m[name] = i
+	}
+	return m
+}()
+
+// OptionsBuilder builds Options on top of the defaults. The first error met
+// is reported by Build.
+type OptionsBuilder struct {
+	opts Options
+	err  error
+}
+
+// NewOptionsBuilder creates an OptionsBuilder.
+func NewOptionsBuilder() *OptionsBuilder {
+	return &OptionsBuilder{opts: *DefaultOptions()}
+}
+
+// ThriftImportPath overrides the import path of the thrift library.
+func (b *OptionsBuilder) ThriftImportPath(path string) *OptionsBuilder {
+	b.opts.ThriftImportPath = path
+	return b
+}
+
+// UsePackage replaces the import path with repl.
+func (b *OptionsBuilder) UsePackage(path, repl string) *OptionsBuilder {
+	b.opts.usePackage(path, repl)
+	return b
+}
+
+// NamingStyle sets the naming style for identifiers.
+func (b *OptionsBuilder) NamingStyle(name string) *OptionsBuilder {
+	b.opts.NamingStyle = name
+	return b
+}
+
+// IgnoreInitialisms disables the spelling correction of initialisms.
+func (b *OptionsBuilder) IgnoreInitialisms(ignore bool) *OptionsBuilder {
+	b.opts.IgnoreInitialisms = ignore
+	return b
+}
+
+// PackagePrefix sets the package prefix for all generated codes.
+func (b *OptionsBuilder) PackagePrefix(prefix string) *OptionsBuilder {
+	b.opts.PackagePrefix = prefix
+	return b
+}
+
+// Template selects the template to generate codes.
+func (b *OptionsBuilder) Template(name string) *OptionsBuilder {
+	b.opts.Template = name
+	return b
+}
+
+// Enable turns on the features with the given option names, like "with_reflection".
+func (b *OptionsBuilder) Enable(names ...string) *OptionsBuilder {
+	return b.toggle(true, names)
+}
+
+// Disable turns off the features with the given option names.
+func (b *OptionsBuilder) Disable(names ...string) *OptionsBuilder {
+	return b.toggle(false, names)
+}
+
+func (b *OptionsBuilder) toggle(value bool, names []string) *OptionsBuilder {
+	for _, n := range names {
+		if err := b.opts.setFeature(n, value); err != nil && b.err == nil {
+			b.err = err
+		}
+	}
+	return b
+}
+
+// Features updates the features directly.
+func (b *OptionsBuilder) Features(update func(f *Features)) *OptionsBuilder {
+	old := b.opts.FeatureSet()
+	fs := old
+	update(&fs)
+	prev, cur := reflect.ValueOf(old), reflect.ValueOf(fs)
+	for i, name := range featureNames {
+		if v := cur.Field(i).Bool(); v != prev.Field(i).Bool() {
+			b.opts.setFeature(name, v)
+		}
+	}
+	return b
+}
+
+// Build validates and returns the options.
+func (b *OptionsBuilder) Build() (*Options, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	o := b.opts
+	o.UsePackages, o.Features = nil, nil
+	for path, repl := range b.opts.UsePackages {
+		o.usePackage(path, repl)
+	}
+	for name, value := range b.opts.Features {
+		o.setFeature(name, value)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
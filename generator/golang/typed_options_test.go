@@ -0,0 +1,99 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golang
+
+import (
+	"testing"
+
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/plugin"
+)
+
+func TestOptions(t *testing.T) {
+	o, err := NewOptionsBuilder().
+		PackagePrefix("example.com/gen").
+		UsePackage("b.com/x", "c.com/x").
+		UsePackage("a.com/x", "d.com/x").
+		Template("slim").
+		Enable("with_reflection", "with_field_mask", "enable_nested_struct").
+		Disable("gen_json_tag").
+		Build()
+	test.Assert(t, err == nil, err)
+	test.Assert(t, o.FeatureSet().WithFieldMask && !o.FeatureSet().GenerateJSONTag)
+
+	packed := o.Pack()
+	test.DeepEqual(t, plugin.Pack(packed), []string{
+		"use_package=a.com/x=d.com/x",
+		"use_package=b.com/x=c.com/x",
+		"package_prefix=example.com/gen",
+		"template=slim",
+		"gen_json_tag=false",
+		"with_reflection=true",
+		"enable_nested_struct=true",
+		"with_field_mask=true",
+	})
+
+	parsed, err := ParseOptions(packed)
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, parsed, o)
+
+	cu := NewCodeUtils(backend.DummyLogFunc())
+	test.Assert(t, cu.HandleOptions(plugin.Pack(packed)) == nil)
+	test.DeepEqual(t, cu.Features(), o.FeatureSet())
+	test.Assert(t, len(DefaultOptions().Pack()) == 0)
+}
+
+func TestOptionsValidate(t *testing.T) {
+	for _, b := range []*OptionsBuilder{
+		NewOptionsBuilder().Enable("with_field_mask"),
+		NewOptionsBuilder().Enable("apache_warning", "apache_adaptor"),
+		NewOptionsBuilder().Enable("enable_nested_struct").Template(defaultTemplate),
+		NewOptionsBuilder().Enable("no_such_option"),
+		NewOptionsBuilder().NamingStyle("kebab"),
+		NewOptionsBuilder().Template("fancy"),
+		NewOptionsBuilder().UsePackage("a.com/x", ""),
+	} {
+		_, err := b.Build()
+		test.Assert(t, err != nil)
+	}
+
+	// features missing in composite literals keep their defaults
+	o := &Options{Features: map[string]bool{"with_reflection": true}}
+	test.Assert(t, o.Validate() == nil)
+	test.Assert(t, o.FeatureSet().WithReflection && o.FeatureSet().GenerateJSONTag)
+	test.DeepEqual(t, o.Pack(), []plugin.Option{{Name: "with_reflection", Desc: "true"}})
+	o = &Options{Features: map[string]bool{"no_such_option": true}}
+	test.Assert(t, o.Validate() != nil)
+
+	// like on the command line, the template defaults to slim in nested mode
+	for _, b := range []*OptionsBuilder{
+		NewOptionsBuilder().Enable("enable_nested_struct"),
+		NewOptionsBuilder().Features(func(f *Features) { f.EnableNestedStruct = true }),
+	} {
+		o, err := b.Build()
+		test.Assert(t, err == nil, err)
+		test.DeepEqual(t, o.Features, map[string]bool{"enable_nested_struct": true})
+		test.DeepEqual(t, plugin.Pack(o.Pack()), []string{"template=slim", "enable_nested_struct=true"})
+	}
+	o, err := ParseOptions([]plugin.Option{{Name: "enable_nested_struct", Desc: "true"}})
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, plugin.Pack(o.Pack()), []string{"template=slim", "enable_nested_struct=true"})
+
+	_, err = ParseOptions([]plugin.Option{{Name: "gen_json", Desc: "true"}})
+	test.Assert(t, err != nil)
+	_, err = ParseOptions([]plugin.Option{{Name: "use_package", Desc: "a.com/x"}})
+	test.Assert(t, err != nil)
+}
@@ -28,6 +28,7 @@ var typeids = struct {
 	Double string
 	String string
 	Binary string
+	UUID   string
 	Set    string
 	List   string
 	Map    string
@@ -42,6 +43,7 @@ var typeids = struct {
 	Double: "Double",
 	String: "String",
 	Binary: "Binary",
+	UUID:   "UUID",
 	Set:    "Set",
 	List:   "List",
 	Map:    "Map",
@@ -57,6 +59,7 @@ var category2TypeID = map[parser.Category]string{
 	parser.Category_Double:    "Double",
 	parser.Category_String:    "String",
 	parser.Category_Binary:    "Binary",
+	parser.Category_UUID:      "UUID",
 	parser.Category_Map:       "Map",
 	parser.Category_List:      "List",
 	parser.Category_Set:       "Set",
@@ -76,6 +79,7 @@ var baseTypes = map[string]string{
 	"double": "float64",
 	"string": "string",
 	"binary": "[]byte",
+	"uuid":   "[16]byte",
 }
 
 var isContainerTypes = map[string]bool{"map": true, "set": true, "list": true}
@@ -27,6 +27,7 @@ import (
 
 	"golang.org/x/text/language"
 
+	"github.com/cloudwego/thriftgo/config"
 	"github.com/cloudwego/thriftgo/generator/backend"
 	"github.com/cloudwego/thriftgo/generator/golang/common"
 	"github.com/cloudwego/thriftgo/generator/golang/extension/meta"
@@ -67,6 +68,7 @@ type CodeUtils struct {
 
 	rootScope   *Scope
 	scopeCache  map[*parser.Thrift]*Scope
+	refConfig   *config.Config // the global idl ref config is used if nil
 	useTemplate string
 	alternative map[string][]string
 }
@@ -96,6 +98,25 @@ func (cu *CodeUtils) SetWithFieldMask(enable bool) bool {
 	return ret
 }
 
+// SetRefConfig sets the idl ref config to use instead of the global one.
+func (cu *CodeUtils) SetRefConfig(c *config.Config) {
+	cu.refConfig = c
+}
+
+func (cu *CodeUtils) getRef(filename string) *config.RefConfig {
+	if cu.refConfig != nil {
+		return cu.refConfig.GetRef(filename)
+	}
+	return config.GetRef(filename)
+}
+
+// clone returns a copy of the CodeUtils that shares the built scopes but keeps
+// its own root scope and features.
+func (cu *CodeUtils) clone() *CodeUtils {
+	c := *cu
+	return &c
+}
+
 // Features returns the current settings of generator features.
 func (cu *CodeUtils) Features() Features {
 	return cu.features
@@ -446,6 +467,8 @@ func (cu *CodeUtils) BuildFuncMap() template.FuncMap {
 
 		"IsBaseType":        IsBaseType,
 		"ZeroWriter":        ZeroWriter,
+		"UUIDReader":        UUIDReader,
+		"UUIDWriter":        UUIDWriter,
 		"NeedRedirect":      NeedRedirect,
 		"IsFixedLengthType": IsFixedLengthType,
 		"SupportIsSet":      SupportIsSet,
@@ -0,0 +1,201 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/plugin"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+// usedPlugin is a plugin to run with its options from the command line.
+type usedPlugin struct {
+	plugin.Plugin
+	options []plugin.Option
+	stage   string
+	after   []string
+}
+
+func newUsedPlugin(p plugin.Plugin, d *plugin.Desc) *usedPlugin {
+	u := &usedPlugin{Plugin: p, options: d.Options, stage: plugin.StageOf(p)}
+	if m := plugin.ManifestOf(p); m != nil {
+		u.after = m.After
+	}
+	return u
+}
+
+// orderPlugins sorts the plugins by their stages and the plugins they run
+// after. Otherwise, they keep the order in which they are given.
+func orderPlugins(ps []*usedPlugin) ([]*usedPlugin, error) {
+	deps := make(map[*usedPlugin][]*usedPlugin)
+	for _, p := range ps {
+		for _, name := range p.after {
+			for _, q := range ps {
+				if q == p || q.Name() != name {
+					continue
+				}
+				if plugin.StageIndex(q.stage) > plugin.StageIndex(p.stage) {
+					return nil, fmt.Errorf("plugin '%s' at the %s stage cannot run after '%s' at the %s stage",
+						p.Name(), p.stage, q.Name(), q.stage)
+				}
+				deps[p] = append(deps[p], q)
+			}
+		}
+	}
+
+	var res []*usedPlugin
+	done := make(map[*usedPlugin]bool)
+	ready := func(p *usedPlugin) bool {
+		for _, q := range deps[p] {
+			if !done[q] {
+				return false
+			}
+		}
+		return true
+	}
+	for len(res) < len(ps) {
+		var next *usedPlugin
+		for _, p := range ps {
+			if done[p] || !ready(p) {
+				continue
+			}
+			if next == nil || plugin.StageIndex(p.stage) < plugin.StageIndex(next.stage) {
+				next = p
+			}
+		}
+		if next == nil {
+			var names []string
+			for _, p := range ps {
+				if !done[p] {
+					names = append(names, p.Name())
+				}
+			}
+			return nil, fmt.Errorf("plugins run after each other: %s", strings.Join(names, ", "))
+		}
+		done[next] = true
+		res = append(res, next)
+	}
+	return res, nil
+}
+
+// runPlugins runs the plugins at the stage. It returns a failed response if
+// any of them fails.
+func (g *Generator) runPlugins(args *Arguments, be backend.Backend, stage string) *plugin.Response {
+	req := args.Req
+	defer func() { req.Scopes, req.Contents = nil, nil }()
+	for _, p := range g.plugins {
+		if p.stage != stage {
+			continue
+		}
+		g.log.Info(fmt.Sprintf(`Run plugin "%s"`, p.Name()))
+
+		if err := args.err(); err != nil {
+			return g.fail(g.Name(), err.Error())
+		}
+		req.PluginParameters = plugin.Pack(p.options)
+		req.Scopes, req.Contents = nil, nil
+		if m := plugin.ManifestOf(p.Plugin); m != nil && stage != plugin.StageTransform {
+			if m.GeneratedFiles {
+				for _, name := range g.files.Names() {
+					req.PluginParameters = append(req.PluginParameters, plugin.GeneratedParameter+"="+name)
+				}
+			}
+			if sr, ok := be.(backend.ScopeReporter); ok && m.Scopes {
+				if g.scopes == nil {
+					g.scopes = sr.Scopes()
+				}
+				req.Scopes = g.scopes
+			}
+		}
+		if stage == plugin.StagePostProcess {
+			req.Contents = g.files.BuildResponse().Contents
+		}
+		extra := args.execute(p.Plugin, req)
+		g.collect(p.Name(), extra)
+
+		if err := extra.GetError(); err != "" {
+			return g.fail("", err)
+		}
+		if extra.AST != nil {
+			var ds parser.Diagnostics
+			err := errors.New("an AST is only accepted from plugins at the transform stage")
+			if stage == plugin.StageTransform {
+				ds, err = transform(req, extra.AST)
+			}
+			if err != nil {
+				source := p.Name()
+				if len(ds) > 0 {
+					// the diagnostics of the checker describe the error
+					g.diags, source = append(g.diags, ds...), ""
+				}
+				return g.fail(source, fmt.Sprintf("plugin '%s': %s", p.Name(), err))
+			}
+		}
+		var err error
+		if stage == plugin.StagePostProcess {
+			err = g.files.Replace(p.Name(), extra.Contents)
+		} else {
+			err = g.files.Feed(p.Name(), extra.Contents)
+		}
+		if err != nil {
+			return g.fail(p.Name(), err.Error())
+		}
+	}
+	return nil
+}
+
+// transform replaces the AST of the request with one returned by a plugin.
+// The symbols are resolved and the semantics are checked again since the
+// plugin may add or change them. The errors found by the checker are returned
+// as diagnostics.
+func transform(req *plugin.Request, ast *parser.Thrift) (parser.Diagnostics, error) {
+	if ast.Filename != req.AST.Filename {
+		return nil, fmt.Errorf("the AST of '%s' is returned instead of '%s'", ast.Filename, req.AST.Filename)
+	}
+	shareIncludes(ast, make(map[string]*parser.Thrift))
+	for t := range ast.DepthFirstSearch() {
+		t.Name2Category = nil
+	}
+	if err := semantic.ResolveSymbols(ast); err != nil {
+		return nil, errors.New("invalid AST: " + err.Error())
+	}
+	checker := semantic.NewDiagnosticChecker(semantic.Options{FixWarnings: true})
+	if ds := checker.Diagnose(ast).Filter(parser.Severity_Error); len(ds) > 0 {
+		return ds, errors.New("invalid AST: " + ds.Error())
+	}
+	req.AST = ast
+	return nil, nil
+}
+
+// shareIncludes makes the includes of the same file share one AST, which
+// are decoded as copies.
+func shareIncludes(ast *parser.Thrift, seen map[string]*parser.Thrift) {
+	seen[ast.Filename] = ast
+	for _, inc := range ast.Includes {
+		if inc.Reference == nil {
+			continue
+		}
+		if t, ok := seen[inc.Reference.Filename]; ok {
+			inc.Reference = t
+		} else {
+			shareIncludes(inc.Reference, seen)
+		}
+	}
+}
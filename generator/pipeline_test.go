@@ -0,0 +1,207 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/plugin"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+type stagePlugin struct {
+	name     string
+	manifest *plugin.Manifest
+	run      func(req *plugin.Request) *plugin.Response
+	order    *[]string
+}
+
+func (p *stagePlugin) Name() string               { return p.name }
+func (p *stagePlugin) Manifest() *plugin.Manifest { return p.manifest }
+
+func (p *stagePlugin) Execute(req *plugin.Request) *plugin.Response {
+	*p.order = append(*p.order, p.name)
+	if p.run == nil {
+		name := p.name + ".go"
+		return &plugin.Response{Contents: []*plugin.Generated{{Name: &name, Content: p.name}}}
+	}
+	return p.run(req)
+}
+
+func TestOrderPlugins(t *testing.T) {
+	used := func(name, stage string, after ...string) *usedPlugin {
+		return &usedPlugin{Plugin: &stagePlugin{name: name}, stage: stage, after: after}
+	}
+	names := func(ps []*usedPlugin) (res []string) {
+		for _, p := range ps {
+			res = append(res, p.Name())
+		}
+		return
+	}
+
+	ps, err := orderPlugins([]*usedPlugin{
+		used("post", plugin.StagePostProcess, "a"),
+		used("a", plugin.StageGenerate, "b", "missing"),
+		used("c", plugin.StageGenerate),
+		used("b", plugin.StageGenerate, "transform"),
+		used("transform", plugin.StageTransform),
+	})
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, names(ps), []string{"transform", "c", "b", "a", "post"})
+
+	_, err = orderPlugins([]*usedPlugin{
+		used("a", plugin.StageGenerate, "post"),
+		used("post", plugin.StagePostProcess),
+	})
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "cannot run after"), err)
+
+	_, err = orderPlugins([]*usedPlugin{
+		used("a", plugin.StageGenerate, "b"),
+		used("b", plugin.StageGenerate, "a"),
+		used("c", plugin.StageGenerate),
+	})
+	test.Assert(t, err != nil && strings.HasSuffix(err.Error(), ": a, b"), err)
+}
+
+func TestPipeline(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"base.thrift": "struct Base {}",
+		"a.thrift":    `include "base.thrift" struct A { 1: base.Base base }`,
+		"b.thrift":    `include "base.thrift" struct B { 1: base.Base base }`,
+		"main.thrift": `include "a.thrift" include "b.thrift" struct Main { 1: a.A a }`,
+	} {
+		test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+	}
+	ast, err := parser.ParseFile(filepath.Join(dir, "main.thrift"), nil, true)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, semantic.ResolveSymbols(ast) == nil)
+
+	var order []string
+	var contents []string
+	inject := &stagePlugin{
+		name:     "inject",
+		manifest: &plugin.Manifest{Stage: plugin.StageTransform},
+		run: func(req *plugin.Request) *plugin.Response {
+			// like an external plugin, the AST is decoded as a copy
+			clone := plugin.NewRequest()
+			_, err := clone.FastRead(req.FastAppend(nil))
+			test.Assert(t, err == nil, err)
+			main := clone.AST.Structs[0]
+			main.Fields = append(main.Fields, &parser.Field{
+				ID:   2,
+				Name: "b",
+				Type: &parser.Type{Name: "b.B"},
+			})
+			return &plugin.Response{AST: clone.AST}
+		},
+	}
+	post := &stagePlugin{
+		name:     "post",
+		manifest: &plugin.Manifest{Stage: plugin.StagePostProcess},
+		run: func(req *plugin.Request) *plugin.Response {
+			for _, c := range req.Contents {
+				contents = append(contents, c.GetName()+":"+c.Content)
+			}
+			a, d := "a.go", "d.go"
+			return &plugin.Response{Contents: []*plugin.Generated{
+				{Name: &a, Content: "post"},
+				{Name: &d, Content: "d"},
+			}}
+		},
+	}
+	first := &stagePlugin{name: "first", manifest: &plugin.Manifest{After: []string{"second"}}}
+	second := &stagePlugin{name: "second"}
+	for _, p := range []*stagePlugin{inject, post, first, second} {
+		p.order = &order
+	}
+
+	be := &fakeBackend{plugins: []plugin.Plugin{post, first, inject, second}}
+	var g Generator
+	test.Assert(t, g.RegisterBackend(be) == nil)
+	req := plugin.NewRequest()
+	req.AST = ast
+	var pds []*plugin.Desc
+	for _, name := range []string{"post", "first", "inject", "second"} {
+		pds = append(pds, &plugin.Desc{Name: name})
+	}
+	res := g.Generate(&Arguments{
+		Out: &LangSpec{Language: "fake", UsedPlugins: pds},
+		Req: req,
+		Log: backend.DummyLogFunc(),
+	})
+	test.Assert(t, res.GetError() == "", res.GetError())
+	test.DeepEqual(t, order, []string{"inject", "second", "first", "post"})
+
+	// the backend sees the modified AST with its symbols resolved
+	test.Assert(t, be.ast != ast && req.AST == ast)
+	fields := be.ast.Structs[0].Fields
+	test.Assert(t, len(fields) == 2 && fields[1].Type.Category == parser.Category_Struct, fields)
+	test.Assert(t, be.ast.Includes[0].Reference.Includes[0].Reference == be.ast.Includes[1].Reference.Includes[0].Reference)
+	test.Assert(t, len(ast.Structs[0].Fields) == 1)
+
+	test.DeepEqual(t, contents, []string{"a.go:", "c.go:", "second.go:second", "first.go:first"})
+	var got []string
+	for _, c := range res.Contents {
+		got = append(got, c.GetName()+":"+c.Content)
+	}
+	test.DeepEqual(t, got, []string{"a.go:post", "c.go:", "second.go:second", "first.go:first", "d.go:d"})
+}
+
+func TestPipelineInvalidAST(t *testing.T) {
+	ast, err := parser.ParseString("main.thrift", "struct Main { 1: i32 a }")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, semantic.ResolveSymbols(ast) == nil)
+
+	dup := &stagePlugin{
+		name:     "dup",
+		manifest: &plugin.Manifest{Stage: plugin.StageTransform},
+		run: func(req *plugin.Request) *plugin.Response {
+			clone := plugin.NewRequest()
+			_, err := clone.FastRead(req.FastAppend(nil))
+			test.Assert(t, err == nil, err)
+			main := clone.AST.Structs[0]
+			main.Fields = append(main.Fields, &parser.Field{
+				ID:   1,
+				Name: "b",
+				Type: &parser.Type{Name: "i32"},
+			})
+			return &plugin.Response{AST: clone.AST}
+		},
+	}
+	var order []string
+	dup.order = &order
+
+	be := &fakeBackend{plugins: []plugin.Plugin{dup}}
+	var g Generator
+	test.Assert(t, g.RegisterBackend(be) == nil)
+	req := plugin.NewRequest()
+	req.AST = ast
+	res := g.Generate(&Arguments{
+		Out: &LangSpec{Language: "fake", UsedPlugins: []*plugin.Desc{{Name: "dup"}}},
+		Req: req,
+		Log: backend.DummyLogFunc(),
+	})
+	test.Assert(t, strings.Contains(res.GetError(), "plugin 'dup': invalid AST"), res.GetError())
+	test.Assert(t, be.ast == nil && req.AST == ast)
+	ds := parser.Diagnostics(res.Diagnostics)
+	test.Assert(t, len(ds) == 1 && ds[0].Source == "semantic" && ds[0].Filename == "main.thrift", ds)
+}
@@ -5,6 +5,7 @@ go 1.20
 require (
 	github.com/cloudwego/gopkg v0.2.0
 	github.com/dlclark/regexp2 v1.11.0
+	github.com/tetratelabs/wazero v1.7.3
 	golang.org/x/text v0.14.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -23,9 +23,25 @@ import (
 	"runtime/pprof"
 	"time"
 
+	"github.com/cloudwego/thriftgo/pkg/build"
+	"github.com/cloudwego/thriftgo/pkg/compat"
+	"github.com/cloudwego/thriftgo/pkg/describe"
+	"github.com/cloudwego/thriftgo/pkg/format"
+	"github.com/cloudwego/thriftgo/pkg/lint"
+	"github.com/cloudwego/thriftgo/pkg/lsp"
 	"github.com/cloudwego/thriftgo/sdk"
 )
 
+// subcommands are invoked with the arguments after their names, like "thriftgo lsp".
+var subcommands = map[string]func(args []string) error{
+	"build":    build.Run,
+	"compat":   compat.Run,
+	"describe": describe.Run,
+	"fmt":      format.Run,
+	"lint":     lint.Run,
+	"lsp":      lsp.Run,
+}
+
 var debugMode bool
 
 func init() {
@@ -62,6 +78,18 @@ func main() {
 
 	defer handlePanic()
 
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				if !errors.Is(err, flag.ErrHelp) {
+					println(err.Error())
+				}
+				os.Exit(2)
+			}
+			return
+		}
+	}
+
 	if err := sdk.InvokeThriftgo(nil, os.Args...); err != nil {
 		if !errors.Is(err, flag.ErrHelp) {
 			println(err.Error())
@@ -59,6 +59,11 @@ func (p Category) IsBinary() bool {
 	return p == Category_Binary
 }
 
+// IsUUID tells if the category is uuid.
+func (p Category) IsUUID() bool {
+	return p == Category_UUID
+}
+
 // IsMap tells if the category is map.
 func (p Category) IsMap() bool {
 	return p == Category_Map
@@ -106,7 +111,7 @@ func (p Category) IsService() bool {
 
 // IsBaseType tells if the category is one of the basetypes.
 func (p Category) IsBaseType() bool {
-	return int64(Category_Bool) <= int64(p) && int64(p) <= int64(Category_Binary)
+	return int64(Category_Bool) <= int64(p) && int64(p) <= int64(Category_Binary) || p == Category_UUID
 }
 
 // IsContainerType tells if the category is one of the container types.
@@ -1,4 +1,4 @@
-// Code generated by thriftgo (0.4.1). DO NOT EDIT.
+// Code generated by thriftgo (0.4.5). DO NOT EDIT.
 
 package parser
 
@@ -30,6 +30,7 @@ const (
 	Category_Exception Category = 15
 	Category_Typedef   Category = 16
 	Category_Service   Category = 17
+	Category_UUID      Category = 18
 )
 
 func (p Category) String() string {
@@ -70,6 +71,8 @@ func (p Category) String() string {
 		return "Typedef"
 	case Category_Service:
 		return "Service"
+	case Category_UUID:
+		return "UUID"
 	}
 	return "<UNSET>"
 }
@@ -112,6 +115,8 @@ func CategoryFromString(s string) (Category, error) {
 		return Category_Typedef, nil
 	case "Service":
 		return Category_Service, nil
+	case "UUID":
+		return Category_UUID, nil
 	}
 	return Category(0), fmt.Errorf("not a valid Category string")
 }
@@ -240,8 +245,122 @@ func (p *FieldType) Value() (driver.Value, error) {
 	return int64(*p), nil
 }
 
+type Severity int64
+
+const (
+	Severity_Error   Severity = 0
+	Severity_Warning Severity = 1
+	Severity_Info    Severity = 2
+	Severity_Hint    Severity = 3
+)
+
+func (p Severity) String() string {
+	switch p {
+	case Severity_Error:
+		return "Error"
+	case Severity_Warning:
+		return "Warning"
+	case Severity_Info:
+		return "Info"
+	case Severity_Hint:
+		return "Hint"
+	}
+	return "<UNSET>"
+}
+
+func SeverityFromString(s string) (Severity, error) {
+	switch s {
+	case "Error":
+		return Severity_Error, nil
+	case "Warning":
+		return Severity_Warning, nil
+	case "Info":
+		return Severity_Info, nil
+	case "Hint":
+		return Severity_Hint, nil
+	}
+	return Severity(0), fmt.Errorf("not a valid Severity string")
+}
+
+func SeverityPtr(v Severity) *Severity { return &v }
+func (p *Severity) Scan(value interface{}) (err error) {
+	var result sql.NullInt64
+	err = result.Scan(value)
+	*p = Severity(result.Int64)
+	return
+}
+
+func (p *Severity) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return int64(*p), nil
+}
+
 type Annotations []*Annotation
 
+type Location struct {
+	StartLine   int32 `thrift:"StartLine,1" frugal:"1,default,i32" json:"StartLine"`
+	StartColumn int32 `thrift:"StartColumn,2" frugal:"2,default,i32" json:"StartColumn"`
+	EndLine     int32 `thrift:"EndLine,3" frugal:"3,default,i32" json:"EndLine"`
+	EndColumn   int32 `thrift:"EndColumn,4" frugal:"4,default,i32" json:"EndColumn"`
+}
+
+func init() {
+	meta.RegisterStruct(NewLocation, []byte{
+		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0xb,
+		0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc,
+		0x0, 0x0, 0x0, 0x4, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x9,
+		0x53, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x69, 0x6e, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0,
+		0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0,
+		0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0xb, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6c,
+		0x75, 0x6d, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1,
+		0x0, 0x0, 0x0, 0x8, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x3, 0xb, 0x0, 0x2, 0x0, 0x0,
+		0x0, 0x7, 0x45, 0x6e, 0x64, 0x4c, 0x69, 0x6e, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0,
+		0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0,
+		0x4, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x9, 0x45, 0x6e, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d,
+		0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0,
+		0x0, 0x8, 0x0, 0x0, 0x0,
+	})
+}
+
+func NewLocation() *Location {
+	return &Location{}
+}
+
+func (p *Location) InitDefault() {
+}
+
+func (p *Location) GetStartLine() (v int32) {
+	return p.StartLine
+}
+
+func (p *Location) GetStartColumn() (v int32) {
+	return p.StartColumn
+}
+
+func (p *Location) GetEndLine() (v int32) {
+	return p.EndLine
+}
+
+func (p *Location) GetEndColumn() (v int32) {
+	return p.EndColumn
+}
+
+func (p *Location) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Location(%+v)", *p)
+}
+
+var fieldIDToName_Location = map[int16]string{
+	1: "StartLine",
+	2: "StartColumn",
+	3: "EndLine",
+	4: "EndColumn",
+}
+
 type Reference struct {
 	Name  string `thrift:"Name,1" frugal:"1,default,string" json:"Name"`
 	Index int32  `thrift:"Index,2" frugal:"2,default,i32" json:"Index"`
@@ -287,20 +406,23 @@ var fieldIDToName_Reference = map[int16]string{
 }
 
 type Annotation struct {
-	Key    string   `thrift:"Key,1" frugal:"1,default,string" json:"Key"`
-	Values []string `thrift:"Values,2" frugal:"2,default,list<string>" json:"Values"`
+	Key      string    `thrift:"Key,1" frugal:"1,default,string" json:"Key"`
+	Values   []string  `thrift:"Values,2" frugal:"2,default,list<string>" json:"Values"`
+	Location *Location `thrift:"Location,3,optional" frugal:"3,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewAnnotation, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0xa, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
 		0x6e, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0,
-		0x3, 0xc, 0x0, 0x0, 0x0, 0x2, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0,
+		0x3, 0xc, 0x0, 0x0, 0x0, 0x3, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0,
 		0x0, 0x3, 0x4b, 0x65, 0x79, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8,
 		0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2,
 		0x0, 0x0, 0x0, 0x6, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0,
 		0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0xc, 0x0, 0x3, 0x8, 0x0,
-		0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0, 0x0,
+		0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x3, 0xb, 0x0, 0x2,
+		0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0,
+		0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -319,6 +441,19 @@ func (p *Annotation) GetValues() (v []string) {
 	return p.Values
 }
 
+var Annotation_Location_DEFAULT *Location
+
+func (p *Annotation) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Annotation_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *Annotation) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Annotation) String() string {
 	if p == nil {
 		return "<nil>"
@@ -329,6 +464,7 @@ func (p *Annotation) String() string {
 var fieldIDToName_Annotation = map[int16]string{
 	1: "Key",
 	2: "Values",
+	3: "Location",
 }
 
 type Type struct {
@@ -340,12 +476,13 @@ type Type struct {
 	Category    Category    `thrift:"Category,6" frugal:"6,default,Category" json:"Category"`
 	Reference   *Reference  `thrift:"Reference,7,optional" frugal:"7,optional,Reference" json:"Reference,omitempty"`
 	IsTypedef   *bool       `thrift:"IsTypedef,8,optional" frugal:"8,optional,bool" json:"IsTypedef,omitempty"`
+	Location    *Location   `thrift:"Location,9,optional" frugal:"9,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewType, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x4, 0x54, 0x79, 0x70, 0x65, 0xb, 0x0, 0x2, 0x0, 0x0,
-		0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0, 0x8,
+		0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0, 0x9,
 		0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4e, 0x61, 0x6d, 0x65,
 		0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0,
 		0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x7, 0x4b,
@@ -366,7 +503,9 @@ func init() {
 		0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x8,
 		0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x9, 0x49, 0x73, 0x54, 0x79, 0x70, 0x65, 0x64, 0x65, 0x66,
 		0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0,
-		0x2, 0x0, 0x0, 0x0,
+		0x2, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x9, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c,
+		0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0,
+		0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -429,6 +568,15 @@ func (p *Type) GetIsTypedef() (v bool) {
 	return *p.IsTypedef
 }
 
+var Type_Location_DEFAULT *Location
+
+func (p *Type) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Type_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *Type) IsSetKeyType() bool {
 	return p.KeyType != nil
 }
@@ -445,6 +593,10 @@ func (p *Type) IsSetIsTypedef() bool {
 	return p.IsTypedef != nil
 }
 
+func (p *Type) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 var fieldIDToName_Type = map[int16]string{
 	1: "Name",
 	2: "KeyType",
@@ -454,19 +606,21 @@ var fieldIDToName_Type = map[int16]string{
 	6: "Category",
 	7: "Reference",
 	8: "IsTypedef",
+	9: "Location",
 }
 
 type Namespace struct {
 	Language    string      `thrift:"Language,1" frugal:"1,default,string" json:"Language"`
 	Name        string      `thrift:"Name,2" frugal:"2,default,string" json:"Name"`
 	Annotations Annotations `thrift:"Annotations,3" frugal:"3,default,list<Annotation>" json:"Annotations"`
+	Location    *Location   `thrift:"Location,4,optional" frugal:"4,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewNamespace, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x9, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
 		0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3,
-		0xc, 0x0, 0x0, 0x0, 0x3, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0,
+		0xc, 0x0, 0x0, 0x0, 0x4, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0,
 		0x8, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0,
 		0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0,
 		0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0,
@@ -474,7 +628,9 @@ func init() {
 		0x0, 0x1, 0x0, 0x3, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0xb, 0x41, 0x6e, 0x6e, 0x6f, 0x74,
 		0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4,
 		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0xc, 0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0,
-		0xc, 0x0, 0x0, 0x0, 0x0,
+		0xc, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8,
+		0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc,
+		0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -497,6 +653,19 @@ func (p *Namespace) GetAnnotations() (v Annotations) {
 	return p.Annotations
 }
 
+var Namespace_Location_DEFAULT *Location
+
+func (p *Namespace) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Namespace_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *Namespace) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Namespace) String() string {
 	if p == nil {
 		return "<nil>"
@@ -508,6 +677,7 @@ var fieldIDToName_Namespace = map[int16]string{
 	1: "Language",
 	2: "Name",
 	3: "Annotations",
+	4: "Location",
 }
 
 type Typedef struct {
@@ -515,13 +685,14 @@ type Typedef struct {
 	Alias            string      `thrift:"Alias,2" frugal:"2,default,string" json:"Alias"`
 	Annotations      Annotations `thrift:"Annotations,3" frugal:"3,default,list<Annotation>" json:"Annotations"`
 	ReservedComments string      `thrift:"ReservedComments,4" frugal:"4,default,string" json:"ReservedComments"`
+	Location         *Location   `thrift:"Location,5,optional" frugal:"5,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewTypedef, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x7, 0x54, 0x79, 0x70, 0x65, 0x64, 0x65, 0x66, 0xb, 0x0,
 		0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0,
-		0x0, 0x0, 0x4, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x54,
+		0x0, 0x0, 0x5, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x54,
 		0x79, 0x70, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1,
 		0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0,
 		0x0, 0x5, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0,
@@ -531,7 +702,10 @@ func init() {
 		0x0, 0xf, 0xc, 0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x6,
 		0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x10, 0x52, 0x65, 0x73, 0x65, 0x72,
 		0x76, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0,
-		0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0,
+		0x1, 0x0, 0x5, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+		0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0,
+		0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -563,10 +737,23 @@ func (p *Typedef) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var Typedef_Location_DEFAULT *Location
+
+func (p *Typedef) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Typedef_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *Typedef) IsSetType() bool {
 	return p.Type != nil
 }
 
+func (p *Typedef) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Typedef) String() string {
 	if p == nil {
 		return "<nil>"
@@ -579,6 +766,7 @@ var fieldIDToName_Typedef = map[int16]string{
 	2: "Alias",
 	3: "Annotations",
 	4: "ReservedComments",
+	5: "Location",
 }
 
 type EnumValue struct {
@@ -586,13 +774,14 @@ type EnumValue struct {
 	Value            int64       `thrift:"Value,2" frugal:"2,default,i64" json:"Value"`
 	Annotations      Annotations `thrift:"Annotations,3" frugal:"3,default,list<Annotation>" json:"Annotations"`
 	ReservedComments string      `thrift:"ReservedComments,4" frugal:"4,default,string" json:"ReservedComments"`
+	Location         *Location   `thrift:"Location,5,optional" frugal:"5,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewEnumValue, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x9, 0x45, 0x6e, 0x75, 0x6d, 0x56, 0x61, 0x6c, 0x75, 0x65,
 		0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3,
-		0xc, 0x0, 0x0, 0x0, 0x4, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0,
+		0xc, 0x0, 0x0, 0x0, 0x5, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0,
 		0x4, 0x4e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8,
 		0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2,
 		0x0, 0x0, 0x0, 0x5, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0,
@@ -603,7 +792,9 @@ func init() {
 		0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x10, 0x52, 0x65, 0x73,
 		0x65, 0x72, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x8, 0x0, 0x3,
 		0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0,
-		0x0,
+		0x6, 0x0, 0x1, 0x0, 0x5, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61,
+		0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0,
+		0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -630,6 +821,19 @@ func (p *EnumValue) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var EnumValue_Location_DEFAULT *Location
+
+func (p *EnumValue) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return EnumValue_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *EnumValue) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *EnumValue) String() string {
 	if p == nil {
 		return "<nil>"
@@ -642,6 +846,7 @@ var fieldIDToName_EnumValue = map[int16]string{
 	2: "Value",
 	3: "Annotations",
 	4: "ReservedComments",
+	5: "Location",
 }
 
 type Enum struct {
@@ -649,12 +854,13 @@ type Enum struct {
 	Values           []*EnumValue `thrift:"Values,2" frugal:"2,default,list<EnumValue>" json:"Values"`
 	Annotations      Annotations  `thrift:"Annotations,3" frugal:"3,default,list<Annotation>" json:"Annotations"`
 	ReservedComments string       `thrift:"ReservedComments,4" frugal:"4,default,string" json:"ReservedComments"`
+	Location         *Location    `thrift:"Location,5,optional" frugal:"5,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewEnum, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x4, 0x45, 0x6e, 0x75, 0x6d, 0xb, 0x0, 0x2, 0x0, 0x0,
-		0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0, 0x4,
+		0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0, 0x5,
 		0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4e, 0x61, 0x6d, 0x65,
 		0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0,
 		0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x56,
@@ -666,7 +872,9 @@ func init() {
 		0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2,
 		0x0, 0x0, 0x0, 0x10, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d,
 		0x65, 0x6e, 0x74, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0,
-		0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0,
+		0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x5, 0xb, 0x0, 0x2, 0x0,
+		0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0,
+		0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -693,6 +901,19 @@ func (p *Enum) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var Enum_Location_DEFAULT *Location
+
+func (p *Enum) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Enum_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *Enum) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Enum) String() string {
 	if p == nil {
 		return "<nil>"
@@ -705,6 +926,7 @@ var fieldIDToName_Enum = map[int16]string{
 	2: "Values",
 	3: "Annotations",
 	4: "ReservedComments",
+	5: "Location",
 }
 
 type ConstValueExtra struct {
@@ -733,7 +955,6 @@ func init() {
 
 func NewConstValueExtra() *ConstValueExtra {
 	return &ConstValueExtra{
-
 		Index: -1,
 	}
 }
@@ -776,20 +997,23 @@ type ConstValue struct {
 	Type       ConstType        `thrift:"Type,1" frugal:"1,default,ConstType" json:"Type"`
 	TypedValue *ConstTypedValue `thrift:"TypedValue,2,optional" frugal:"2,optional,ConstTypedValue" json:"TypedValue,omitempty"`
 	Extra      *ConstValueExtra `thrift:"Extra,3,optional" frugal:"3,optional,ConstValueExtra" json:"Extra,omitempty"`
+	Location   *Location        `thrift:"Location,4,optional" frugal:"4,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewConstValue, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0xa, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75,
 		0x65, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0,
-		0x3, 0xc, 0x0, 0x0, 0x0, 0x3, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0,
+		0x3, 0xc, 0x0, 0x0, 0x0, 0x4, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0,
 		0x0, 0x4, 0x54, 0x79, 0x70, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4,
 		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0,
 		0x2, 0x0, 0x0, 0x0, 0xa, 0x54, 0x79, 0x70, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x8,
 		0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc,
 		0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x3, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x5, 0x45, 0x78,
 		0x74, 0x72, 0x61, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1,
-		0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
+		0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0, 0x0,
+		0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0,
+		0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -822,6 +1046,15 @@ func (p *ConstValue) GetExtra() (v *ConstValueExtra) {
 	return p.Extra
 }
 
+var ConstValue_Location_DEFAULT *Location
+
+func (p *ConstValue) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return ConstValue_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *ConstValue) IsSetTypedValue() bool {
 	return p.TypedValue != nil
 }
@@ -830,10 +1063,15 @@ func (p *ConstValue) IsSetExtra() bool {
 	return p.Extra != nil
 }
 
+func (p *ConstValue) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 var fieldIDToName_ConstValue = map[int16]string{
 	1: "Type",
 	2: "TypedValue",
 	3: "Extra",
+	4: "Location",
 }
 
 type MapConstValue struct {
@@ -904,13 +1142,14 @@ type Constant struct {
 	Value            *ConstValue `thrift:"Value,3,optional" frugal:"3,optional,ConstValue" json:"Value,omitempty"`
 	Annotations      Annotations `thrift:"Annotations,4" frugal:"4,default,list<Annotation>" json:"Annotations"`
 	ReservedComments string      `thrift:"ReservedComments,5" frugal:"5,default,string" json:"ReservedComments"`
+	Location         *Location   `thrift:"Location,6,optional" frugal:"6,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewConstant, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x74, 0xb,
 		0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc,
-		0x0, 0x0, 0x0, 0x5, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4,
+		0x0, 0x0, 0x0, 0x6, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4,
 		0x4e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0,
 		0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0,
 		0x0, 0x0, 0x4, 0x54, 0x79, 0x70, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0,
@@ -923,7 +1162,9 @@ func init() {
 		0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x5, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x10, 0x52,
 		0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x8,
 		0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb,
-		0x0, 0x0, 0x0,
+		0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x6, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f,
+		0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4,
+		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -964,6 +1205,15 @@ func (p *Constant) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var Constant_Location_DEFAULT *Location
+
+func (p *Constant) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Constant_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *Constant) IsSetType() bool {
 	return p.Type != nil
 }
@@ -972,6 +1222,10 @@ func (p *Constant) IsSetValue() bool {
 	return p.Value != nil
 }
 
+func (p *Constant) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Constant) String() string {
 	if p == nil {
 		return "<nil>"
@@ -985,6 +1239,7 @@ var fieldIDToName_Constant = map[int16]string{
 	3: "Value",
 	4: "Annotations",
 	5: "ReservedComments",
+	6: "Location",
 }
 
 type Field struct {
@@ -995,13 +1250,14 @@ type Field struct {
 	Default          *ConstValue `thrift:"Default,5,optional" frugal:"5,optional,ConstValue" json:"Default,omitempty"`
 	Annotations      Annotations `thrift:"Annotations,6" frugal:"6,default,list<Annotation>" json:"Annotations"`
 	ReservedComments string      `thrift:"ReservedComments,7" frugal:"7,default,string" json:"ReservedComments"`
+	Location         *Location   `thrift:"Location,8,optional" frugal:"8,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewField, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x5, 0x46, 0x69, 0x65, 0x6c, 0x64, 0xb, 0x0, 0x2, 0x0,
 		0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0,
-		0x7, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x2, 0x49, 0x44, 0x8,
+		0x8, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x2, 0x49, 0x44, 0x8,
 		0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8,
 		0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4e, 0x61,
 		0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0,
@@ -1018,7 +1274,10 @@ func init() {
 		0xf, 0xc, 0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x6, 0x0,
 		0x1, 0x0, 0x7, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x10, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
 		0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0,
-		0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0,
+		0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1,
+		0x0, 0x8, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+		0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0,
+		0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -1067,6 +1326,15 @@ func (p *Field) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var Field_Location_DEFAULT *Location
+
+func (p *Field) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Field_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *Field) IsSetType() bool {
 	return p.Type != nil
 }
@@ -1075,6 +1343,10 @@ func (p *Field) IsSetDefault() bool {
 	return p.Default != nil
 }
 
+func (p *Field) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Field) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1090,6 +1362,7 @@ var fieldIDToName_Field = map[int16]string{
 	5: "Default",
 	6: "Annotations",
 	7: "ReservedComments",
+	8: "Location",
 }
 
 type StructLike struct {
@@ -1098,13 +1371,14 @@ type StructLike struct {
 	Fields           []*Field    `thrift:"Fields,3" frugal:"3,default,list<Field>" json:"Fields"`
 	Annotations      Annotations `thrift:"Annotations,4" frugal:"4,default,list<Annotation>" json:"Annotations"`
 	ReservedComments string      `thrift:"ReservedComments,5" frugal:"5,default,string" json:"ReservedComments"`
+	Location         *Location   `thrift:"Location,6,optional" frugal:"6,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewStructLike, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0xa, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x4c, 0x69, 0x6b,
 		0x65, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0,
-		0x3, 0xc, 0x0, 0x0, 0x0, 0x5, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0,
+		0x3, 0xc, 0x0, 0x0, 0x0, 0x6, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0,
 		0x0, 0x8, 0x43, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x79, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0,
 		0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1,
 		0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3,
@@ -1118,7 +1392,9 @@ func init() {
 		0x0, 0xc, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x5, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0,
 		0x10, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
 		0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0,
-		0x0, 0xb, 0x0, 0x0, 0x0,
+		0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x6, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8,
+		0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc,
+		0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -1149,6 +1425,19 @@ func (p *StructLike) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var StructLike_Location_DEFAULT *Location
+
+func (p *StructLike) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return StructLike_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *StructLike) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *StructLike) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1162,6 +1451,7 @@ var fieldIDToName_StructLike = map[int16]string{
 	3: "Fields",
 	4: "Annotations",
 	5: "ReservedComments",
+	6: "Location",
 }
 
 type Function struct {
@@ -1173,13 +1463,14 @@ type Function struct {
 	Throws           []*Field    `thrift:"Throws,6" frugal:"6,default,list<Field>" json:"Throws"`
 	Annotations      Annotations `thrift:"Annotations,7" frugal:"7,default,list<Annotation>" json:"Annotations"`
 	ReservedComments string      `thrift:"ReservedComments,8" frugal:"8,default,string" json:"ReservedComments"`
+	Location         *Location   `thrift:"Location,9,optional" frugal:"9,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewFunction, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0xb,
 		0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc,
-		0x0, 0x0, 0x0, 0x8, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4,
+		0x0, 0x0, 0x0, 0x9, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4,
 		0x4e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0,
 		0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0,
 		0x0, 0x0, 0x6, 0x4f, 0x6e, 0x65, 0x77, 0x61, 0x79, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0,
@@ -1201,7 +1492,9 @@ func init() {
 		0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x8, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x10, 0x52, 0x65,
 		0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x8, 0x0,
 		0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0,
-		0x0, 0x0,
+		0x0, 0x6, 0x0, 0x1, 0x0, 0x9, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63,
+		0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8,
+		0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -1249,10 +1542,23 @@ func (p *Function) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var Function_Location_DEFAULT *Location
+
+func (p *Function) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Function_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *Function) IsSetFunctionType() bool {
 	return p.FunctionType != nil
 }
 
+func (p *Function) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Function) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1269,6 +1575,7 @@ var fieldIDToName_Function = map[int16]string{
 	6: "Throws",
 	7: "Annotations",
 	8: "ReservedComments",
+	9: "Location",
 }
 
 type Service struct {
@@ -1278,13 +1585,14 @@ type Service struct {
 	Annotations      Annotations `thrift:"Annotations,4" frugal:"4,default,list<Annotation>" json:"Annotations"`
 	Reference        *Reference  `thrift:"Reference,5,optional" frugal:"5,optional,Reference" json:"Reference,omitempty"`
 	ReservedComments string      `thrift:"ReservedComments,6" frugal:"6,default,string" json:"ReservedComments"`
+	Location         *Location   `thrift:"Location,7,optional" frugal:"7,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewService, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x7, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0xb, 0x0,
 		0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0,
-		0x0, 0x0, 0x6, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4e,
+		0x0, 0x0, 0x7, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4e,
 		0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1,
 		0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0,
 		0x0, 0x7, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0,
@@ -1300,7 +1608,10 @@ func init() {
 		0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0,
 		0x6, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x10, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64,
 		0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc,
-		0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0,
+		0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x7,
+		0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8,
+		0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc,
+		0x0, 0x0, 0x0,
 	})
 }
 
@@ -1340,10 +1651,23 @@ func (p *Service) GetReservedComments() (v string) {
 	return p.ReservedComments
 }
 
+var Service_Location_DEFAULT *Location
+
+func (p *Service) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Service_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *Service) IsSetReference() bool {
 	return p.Reference != nil
 }
 
+func (p *Service) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Service) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1358,26 +1682,30 @@ var fieldIDToName_Service = map[int16]string{
 	4: "Annotations",
 	5: "Reference",
 	6: "ReservedComments",
+	7: "Location",
 }
 
 type Include struct {
-	Path      string  `thrift:"Path,1" frugal:"1,default,string" json:"Path"`
-	Reference *Thrift `thrift:"Reference,2,optional" frugal:"2,optional,Thrift" json:"Reference,omitempty"`
-	Used      *bool   `thrift:"Used,3,optional" frugal:"3,optional,bool" json:"Used,omitempty"`
+	Path      string    `thrift:"Path,1" frugal:"1,default,string" json:"Path"`
+	Reference *Thrift   `thrift:"Reference,2,optional" frugal:"2,optional,Thrift" json:"Reference,omitempty"`
+	Used      *bool     `thrift:"Used,3,optional" frugal:"3,optional,bool" json:"Used,omitempty"`
+	Location  *Location `thrift:"Location,4,optional" frugal:"4,optional,Location" json:"Location,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewInclude, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x7, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0xb, 0x0,
 		0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0,
-		0x0, 0x0, 0x3, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x50,
+		0x0, 0x0, 0x4, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x50,
 		0x61, 0x74, 0x68, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1,
 		0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0,
 		0x0, 0x9, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0,
 		0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0,
 		0x1, 0x0, 0x3, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x55, 0x73, 0x65, 0x64, 0x8, 0x0,
 		0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0x2, 0x0,
-		0x0, 0x0,
+		0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63,
+		0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8,
+		0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -1410,6 +1738,15 @@ func (p *Include) GetUsed() (v bool) {
 	return *p.Used
 }
 
+var Include_Location_DEFAULT *Location
+
+func (p *Include) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Include_Location_DEFAULT
+	}
+	return p.Location
+}
+
 func (p *Include) IsSetReference() bool {
 	return p.Reference != nil
 }
@@ -1418,6 +1755,10 @@ func (p *Include) IsSetUsed() bool {
 	return p.Used != nil
 }
 
+func (p *Include) IsSetLocation() bool {
+	return p.Location != nil
+}
+
 func (p *Include) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1429,6 +1770,7 @@ var fieldIDToName_Include = map[int16]string{
 	1: "Path",
 	2: "Reference",
 	3: "Used",
+	4: "Location",
 }
 
 type Thrift struct {
@@ -1569,6 +1911,288 @@ var fieldIDToName_Thrift = map[int16]string{
 	12: "Name2Category",
 }
 
+type RelatedLocation struct {
+	Filename string    `thrift:"Filename,1" frugal:"1,default,string" json:"Filename"`
+	Location *Location `thrift:"Location,2,optional" frugal:"2,optional,Location" json:"Location,omitempty"`
+	Message  string    `thrift:"Message,3" frugal:"3,default,string" json:"Message"`
+}
+
+func init() {
+	meta.RegisterStruct(NewRelatedLocation, []byte{
+		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x4c, 0x6f,
+		0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72,
+		0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0, 0x3, 0x6, 0x0, 0x1, 0x0, 0x1,
+		0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x8,
+		0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb,
+		0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f,
+		0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4,
+		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x3, 0xb, 0x0,
+		0x2, 0x0, 0x0, 0x0, 0x7, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x8, 0x0, 0x3, 0x0,
+		0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0,
+	})
+}
+
+func NewRelatedLocation() *RelatedLocation {
+	return &RelatedLocation{}
+}
+
+func (p *RelatedLocation) InitDefault() {
+}
+
+func (p *RelatedLocation) GetFilename() (v string) {
+	return p.Filename
+}
+
+var RelatedLocation_Location_DEFAULT *Location
+
+func (p *RelatedLocation) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return RelatedLocation_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *RelatedLocation) GetMessage() (v string) {
+	return p.Message
+}
+
+func (p *RelatedLocation) IsSetLocation() bool {
+	return p.Location != nil
+}
+
+func (p *RelatedLocation) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("RelatedLocation(%+v)", *p)
+}
+
+var fieldIDToName_RelatedLocation = map[int16]string{
+	1: "Filename",
+	2: "Location",
+	3: "Message",
+}
+
+type TextEdit struct {
+	Filename string    `thrift:"Filename,1" frugal:"1,default,string" json:"Filename"`
+	Location *Location `thrift:"Location,2,optional" frugal:"2,optional,Location" json:"Location,omitempty"`
+	NewText  string    `thrift:"NewText,3" frugal:"3,default,string" json:"NewText"`
+}
+
+func init() {
+	meta.RegisterStruct(NewTextEdit, []byte{
+		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x54, 0x65, 0x78, 0x74, 0x45, 0x64, 0x69, 0x74, 0xb,
+		0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc,
+		0x0, 0x0, 0x0, 0x3, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8,
+		0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc,
+		0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2,
+		0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8,
+		0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc,
+		0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x3, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x7, 0x4e, 0x65,
+		0x77, 0x54, 0x65, 0x78, 0x74, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8,
+		0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0,
+	})
+}
+
+func NewTextEdit() *TextEdit {
+	return &TextEdit{}
+}
+
+func (p *TextEdit) InitDefault() {
+}
+
+func (p *TextEdit) GetFilename() (v string) {
+	return p.Filename
+}
+
+var TextEdit_Location_DEFAULT *Location
+
+func (p *TextEdit) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return TextEdit_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *TextEdit) GetNewText() (v string) {
+	return p.NewText
+}
+
+func (p *TextEdit) IsSetLocation() bool {
+	return p.Location != nil
+}
+
+func (p *TextEdit) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TextEdit(%+v)", *p)
+}
+
+var fieldIDToName_TextEdit = map[int16]string{
+	1: "Filename",
+	2: "Location",
+	3: "NewText",
+}
+
+type SuggestedFix struct {
+	Message string      `thrift:"Message,1" frugal:"1,default,string" json:"Message"`
+	Edits   []*TextEdit `thrift:"Edits,2" frugal:"2,default,list<TextEdit>" json:"Edits"`
+}
+
+func init() {
+	meta.RegisterStruct(NewSuggestedFix, []byte{
+		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64,
+		0x46, 0x69, 0x78, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+		0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0, 0x2, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2,
+		0x0, 0x0, 0x0, 0x7, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0,
+		0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0,
+		0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x5, 0x45, 0x64, 0x69, 0x74, 0x73, 0x8,
+		0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf,
+		0xc, 0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x0,
+	})
+}
+
+func NewSuggestedFix() *SuggestedFix {
+	return &SuggestedFix{}
+}
+
+func (p *SuggestedFix) InitDefault() {
+}
+
+func (p *SuggestedFix) GetMessage() (v string) {
+	return p.Message
+}
+
+func (p *SuggestedFix) GetEdits() (v []*TextEdit) {
+	return p.Edits
+}
+
+func (p *SuggestedFix) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("SuggestedFix(%+v)", *p)
+}
+
+var fieldIDToName_SuggestedFix = map[int16]string{
+	1: "Message",
+	2: "Edits",
+}
+
+type Diagnostic struct {
+	Severity Severity           `thrift:"Severity,1" frugal:"1,default,Severity" json:"Severity"`
+	Code     string             `thrift:"Code,2" frugal:"2,default,string" json:"Code"`
+	Message  string             `thrift:"Message,3" frugal:"3,default,string" json:"Message"`
+	Source   string             `thrift:"Source,4" frugal:"4,default,string" json:"Source"`
+	Filename string             `thrift:"Filename,5" frugal:"5,default,string" json:"Filename"`
+	Location *Location          `thrift:"Location,6,optional" frugal:"6,optional,Location" json:"Location,omitempty"`
+	Related  []*RelatedLocation `thrift:"Related,7" frugal:"7,default,list<RelatedLocation>" json:"Related"`
+	Fix      *SuggestedFix      `thrift:"Fix,8,optional" frugal:"8,optional,SuggestedFix" json:"Fix,omitempty"`
+}
+
+func init() {
+	meta.RegisterStruct(NewDiagnostic, []byte{
+		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0xa, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+		0x63, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0,
+		0x3, 0xc, 0x0, 0x0, 0x0, 0x8, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0,
+		0x0, 0x8, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0,
+		0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x0, 0x0, 0x6, 0x0, 0x1,
+		0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x43, 0x6f, 0x64, 0x65, 0x8, 0x0, 0x3,
+		0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0,
+		0x6, 0x0, 0x1, 0x0, 0x3, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x7, 0x4d, 0x65, 0x73, 0x73,
+		0x61, 0x67, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1,
+		0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0, 0x0,
+		0x0, 0x6, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc,
+		0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x5,
+		0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x8,
+		0x0, 0x3, 0x0, 0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb,
+		0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x6, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x4c, 0x6f,
+		0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4,
+		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x7, 0xb, 0x0,
+		0x2, 0x0, 0x0, 0x0, 0x7, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x8, 0x0, 0x3, 0x0,
+		0x0, 0x0, 0x0, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0xc, 0x0, 0x3,
+		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x8, 0xb,
+		0x0, 0x2, 0x0, 0x0, 0x0, 0x3, 0x46, 0x69, 0x78, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2,
+		0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
+	})
+}
+
+func NewDiagnostic() *Diagnostic {
+	return &Diagnostic{}
+}
+
+func (p *Diagnostic) InitDefault() {
+}
+
+func (p *Diagnostic) GetSeverity() (v Severity) {
+	return p.Severity
+}
+
+func (p *Diagnostic) GetCode() (v string) {
+	return p.Code
+}
+
+func (p *Diagnostic) GetMessage() (v string) {
+	return p.Message
+}
+
+func (p *Diagnostic) GetSource() (v string) {
+	return p.Source
+}
+
+func (p *Diagnostic) GetFilename() (v string) {
+	return p.Filename
+}
+
+var Diagnostic_Location_DEFAULT *Location
+
+func (p *Diagnostic) GetLocation() (v *Location) {
+	if !p.IsSetLocation() {
+		return Diagnostic_Location_DEFAULT
+	}
+	return p.Location
+}
+
+func (p *Diagnostic) GetRelated() (v []*RelatedLocation) {
+	return p.Related
+}
+
+var Diagnostic_Fix_DEFAULT *SuggestedFix
+
+func (p *Diagnostic) GetFix() (v *SuggestedFix) {
+	if !p.IsSetFix() {
+		return Diagnostic_Fix_DEFAULT
+	}
+	return p.Fix
+}
+
+func (p *Diagnostic) IsSetLocation() bool {
+	return p.Location != nil
+}
+
+func (p *Diagnostic) IsSetFix() bool {
+	return p.Fix != nil
+}
+
+func (p *Diagnostic) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Diagnostic(%+v)", *p)
+}
+
+var fieldIDToName_Diagnostic = map[int16]string{
+	1: "Severity",
+	2: "Code",
+	3: "Message",
+	4: "Source",
+	5: "Filename",
+	6: "Location",
+	7: "Related",
+	8: "Fix",
+}
+
 type ConstTypedValue struct {
 	Double     *float64         `thrift:"Double,1,optional" frugal:"1,optional,double" json:"Double,omitempty"`
 	Int        *int64           `thrift:"Int,2,optional" frugal:"2,optional,i64" json:"Int,omitempty"`
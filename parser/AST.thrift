@@ -35,6 +35,16 @@ enum Category {
     Exception
     Typedef
     Service
+    UUID // appended to keep the values of other categories stable
+}
+
+// Location records the range of an AST node in its IDL file. Lines and columns
+// are 1-based, columns count unicode characters and the end is exclusive.
+struct Location {
+    1: i32 StartLine
+    2: i32 StartColumn
+    3: i32 EndLine
+    4: i32 EndColumn
 }
 
 struct Reference {
@@ -45,6 +55,7 @@ struct Reference {
 struct Annotation {
     1: string Key
     2: list<string> Values
+    3: optional Location Location
 }
 
 struct Type {
@@ -56,12 +67,14 @@ struct Type {
     6: Category Category               // the **final** category resolved
     7: optional Reference Reference    // when Name is an identifier referring to an external type
     8: optional bool IsTypedef         // whether this type is a typedef
+    9: optional Location Location
 }
 
 struct Namespace {
     1: string Language
     2: string Name
     3: Annotations Annotations
+    4: optional Location Location
 }
 
 struct Typedef {
@@ -69,6 +82,7 @@ struct Typedef {
     2: string Alias
     3: Annotations Annotations
     4: string ReservedComments
+    5: optional Location Location
 }
 
 struct EnumValue {
@@ -76,6 +90,7 @@ struct EnumValue {
     2: i64 Value
     3: Annotations Annotations
     4: string ReservedComments
+    5: optional Location Location
 }
 
 struct Enum {
@@ -83,6 +98,7 @@ struct Enum {
     2: list<EnumValue> Values
     3: Annotations Annotations
     4: string ReservedComments
+    5: optional Location Location
 }
 
 enum ConstType {
@@ -106,6 +122,7 @@ struct ConstValue {
     1: ConstType Type
     2: optional ConstTypedValue TypedValue
     3: optional ConstValueExtra Extra
+    4: optional Location Location
 }
 
 union ConstTypedValue {
@@ -128,6 +145,7 @@ struct Constant {
     3: optional ConstValue Value
     4: Annotations Annotations
     5: string ReservedComments
+    6: optional Location Location
 }
 
 enum FieldType {
@@ -144,6 +162,7 @@ struct Field {
     5: optional ConstValue Default // ConstValue
     6: Annotations Annotations
     7: string ReservedComments
+    8: optional Location Location
 }
 
 struct StructLike {
@@ -152,6 +171,7 @@ struct StructLike {
     3: list<Field> Fields
     4: Annotations Annotations
     5: string ReservedComments
+    6: optional Location Location
 }
 
 struct Function {
@@ -163,6 +183,7 @@ struct Function {
     6: list<Field> Throws
     7: Annotations Annotations
     8: string ReservedComments
+    9: optional Location Location
 }
 
 struct Service {
@@ -176,12 +197,14 @@ struct Service {
     5: optional Reference Reference
 
     6: string ReservedComments
+    7: optional Location Location
 }
 
 struct Include {
     1: string Path               // The path literal in the include statement.
     2: optional Thrift Reference // The parsed AST of the included IDL.
     3: optional bool Used        // If this include is used in the IDL
+    4: optional Location Location
 }
 
 // Thrift is the AST of the current IDL with symbols sorted.
@@ -201,3 +224,43 @@ struct Thrift {
     // Name2Category keeps a mapping for all global names with their **direct** category.
     12: map<string, Category> Name2Category
 }
+
+enum Severity {
+    Error
+    Warning
+    Info
+    Hint
+}
+
+// RelatedLocation points to another place in the IDLs that relates to a diagnostic.
+struct RelatedLocation {
+    1: string Filename
+    2: optional Location Location
+    3: string Message
+}
+
+// TextEdit replaces the text in Location with NewText.
+struct TextEdit {
+    1: string Filename
+    2: optional Location Location
+    3: string NewText
+}
+
+// SuggestedFix describes a possible way to solve the problem of a diagnostic.
+struct SuggestedFix {
+    1: string Message
+    2: list<TextEdit> Edits
+}
+
+// Diagnostic describes a problem found by the parser, the semantic checker,
+// a generator backend or a plugin.
+struct Diagnostic {
+    1: Severity Severity
+    2: string Code                   // A short identifier of the kind of the problem, e.g. "duplicated-field-id".
+    3: string Message
+    4: string Source                 // The component that produces the diagnostic, e.g. "parser", "semantic" or a plugin name.
+    5: string Filename
+    6: optional Location Location
+    7: list<RelatedLocation> Related
+    8: optional SuggestedFix Fix
+}
@@ -14,6 +14,12 @@
 
 package parser
 
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
 // TType constants in the Thrift protocol.
 const (
 	STOP   = 0
@@ -32,6 +38,7 @@ const (
 	SET    = 14
 	LIST   = 15
 	UTF8   = 16
+	UUID   = 16
 	UTF16  = 17
 	BINARY = 18
 
@@ -48,6 +55,7 @@ var typename2TypeID = map[string]uint8{
 	"double": DOUBLE,
 	"string": STRING,
 	"binary": BINARY,
+	"uuid":   UUID,
 	"map":    MAP,
 	"set":    SET,
 	"list":   LIST,
@@ -60,3 +68,20 @@ func Typename2TypeID(name string) uint8 {
 	}
 	return UNKNOWN
 }
+
+// ParseUUID parses the value of a uuid constant, which is in the canonical
+// form of "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx" and may be wrapped in braces.
+func ParseUUID(s string) (uuid [16]byte, err error) {
+	str := s
+	if strings.HasPrefix(str, "{") && strings.HasSuffix(str, "}") {
+		str = str[1 : len(str)-1]
+	}
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return uuid, fmt.Errorf("invalid uuid %q", s)
+	}
+	str = strings.ReplaceAll(str, "-", "")
+	if _, err = hex.Decode(uuid[:], []byte(str)); err != nil {
+		return uuid, fmt.Errorf("invalid uuid %q", s)
+	}
+	return uuid, nil
+}
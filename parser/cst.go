@@ -0,0 +1,212 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"io"
+	"strings"
+)
+
+// Kinds of the leaves of a concrete syntax tree. Inner nodes are named after
+// the grammar rules in thrift.peg, such as "Struct", "Field" and "Identifier".
+const (
+	CSTToken      = "Token"      // keywords, names, literals and punctuations
+	CSTWhitespace = "Whitespace" // spaces, tabs and line breaks
+	CSTComment    = "Comment"    // a comment in any of the three styles
+)
+
+// CSTNode is a node of a concrete syntax tree. A leaf holds a piece of the
+// source and an inner node holds its children. The source is reproduced by
+// concatenating the text of all leaves in order, so a tree that is edited in
+// place can be printed with all the comments and blank lines untouched.
+type CSTNode struct {
+	Kind     string
+	Text     string // the text of a leaf
+	Children []*CSTNode
+	// Location is the range of the node in the source without the leading
+	// and trailing trivia. It is nil for nodes created by users and is not
+	// updated on edits.
+	Location *Location
+}
+
+// IsLeaf tells whether the node is a leaf.
+func (n *CSTNode) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// IsTrivia tells whether the node is a whitespace or a comment.
+func (n *CSTNode) IsTrivia() bool {
+	return n.Kind == CSTWhitespace || n.Kind == CSTComment
+}
+
+// Walk visits the node and its descendants in depth-first order. The
+// children of a node are skipped if visit returns false.
+func (n *CSTNode) Walk(visit func(n *CSTNode) bool) {
+	if !visit(n) {
+		return
+	}
+	for _, c := range n.Children {
+		c.Walk(visit)
+	}
+}
+
+// FindAll returns the descendants of the given kind in depth-first order.
+func (n *CSTNode) FindAll(kind string) (res []*CSTNode) {
+	n.Walk(func(c *CSTNode) bool {
+		if c != n && c.Kind == kind {
+			res = append(res, c)
+		}
+		return true
+	})
+	return
+}
+
+// Tokens returns the leaves that are not trivia.
+func (n *CSTNode) Tokens() (res []*CSTNode) {
+	n.Walk(func(c *CSTNode) bool {
+		if c.IsLeaf() && !c.IsTrivia() {
+			res = append(res, c)
+		}
+		return true
+	})
+	return
+}
+
+// WriteTo writes the source of the node to w.
+func (n *CSTNode) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	n.print(&sb)
+	m, err := io.WriteString(w, sb.String())
+	return int64(m), err
+}
+
+// Source returns the source of the node.
+func (n *CSTNode) Source() string {
+	var sb strings.Builder
+	n.print(&sb)
+	return sb.String()
+}
+
+func (n *CSTNode) print(sb *strings.Builder) {
+	if n.IsLeaf() {
+		sb.WriteString(n.Text)
+		return
+	}
+	for _, c := range n.Children {
+		c.print(sb)
+	}
+}
+
+// ParseCST parses the content into a lossless concrete syntax tree whose root
+// is a "Document" node. The tree keeps every byte of the content, so
+// ParseCST(filename, content).Source() == content. Syntax errors are reported
+// as diagnostics.
+func ParseCST(filename, content string) (*CSTNode, error) {
+	p, err := parseTree(filename, content)
+	if err != nil {
+		return nil, err
+	}
+	b := &cstBuilder{p: p, content: content, offsets: make([]int, 0, len(p.buffer)+1)}
+	// map rune offsets in the buffer to byte offsets in the content to keep
+	// invalid UTF-8 sequences as they are
+	for i := range content {
+		b.offsets = append(b.offsets, i)
+	}
+	b.offsets = append(b.offsets, len(content))
+
+	// the buffer ends with an extra end symbol
+	end := uint32(len(b.offsets) - 1)
+	root := p.AST()
+	doc := &CSTNode{Kind: rul3s[root.pegRule], Location: b.location(0, end)}
+	b.fill(doc, 0, end, root.up)
+	return doc, nil
+}
+
+type cstBuilder struct {
+	p       *parser
+	content string
+	offsets []int
+}
+
+func (b *cstBuilder) text(begin, end uint32) string {
+	return b.content[b.offsets[begin]:b.offsets[end]]
+}
+
+func (b *cstBuilder) location(begin, end uint32) *Location {
+	var loc Location
+	loc.StartLine, loc.StartColumn = b.p.position(begin)
+	loc.EndLine, loc.EndColumn = b.p.position(end)
+	return &loc
+}
+
+// leaf appends a leaf to the parent or extends the last leaf of the same kind.
+func (b *cstBuilder) leaf(parent *CSTNode, kind string, begin, end uint32) {
+	if k := len(parent.Children); k > 0 && kind == CSTWhitespace {
+		if last := parent.Children[k-1]; last.Kind == CSTWhitespace {
+			last.Text += b.text(begin, end)
+			last.Location.EndLine, last.Location.EndColumn = b.p.position(end)
+			return
+		}
+	}
+	parent.Children = append(parent.Children, &CSTNode{
+		Kind: kind, Text: b.text(begin, end), Location: b.location(begin, end),
+	})
+}
+
+// gap appends the text between the children of a node as leaves.
+func (b *cstBuilder) gap(parent *CSTNode, begin, end uint32) {
+	for begin < end {
+		i := begin
+		space := isSpace(b.p.buffer[i])
+		for i < end && isSpace(b.p.buffer[i]) == space {
+			i++
+		}
+		if space {
+			b.leaf(parent, CSTWhitespace, begin, i)
+		} else {
+			b.leaf(parent, CSTToken, begin, i)
+		}
+		begin = i
+	}
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\r' || r == '\n'
+}
+
+// fill appends the children of a peg node in the range [begin, end) to parent.
+func (b *cstBuilder) fill(parent *CSTNode, begin, end uint32, first *node32) {
+	pos := begin
+	for n := first; n != nil; n = n.next {
+		b.gap(parent, pos, n.begin)
+		pos = n.end
+		switch n.pegRule {
+		case ruleSkip, ruleSkipLine, ruleReservedComments, ruleReservedEndLineComments:
+			// containers of trivia are flattened into the parent
+			b.fill(parent, n.begin, n.end, n.up)
+		case ruleSpace, ruleIndent, ruleCarriageReturnLineFeed:
+			b.leaf(parent, CSTWhitespace, n.begin, n.end)
+		case ruleComment:
+			b.leaf(parent, CSTComment, n.begin, n.end)
+		case rulePegText:
+			b.leaf(parent, CSTToken, n.begin, n.end)
+		default:
+			node := &CSTNode{Kind: rul3s[n.pegRule], Location: b.location(nodeBegin(n), nodeEnd(n))}
+			b.fill(node, n.begin, n.end, n.up)
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	b.gap(parent, pos, end)
+}
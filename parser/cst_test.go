@@ -0,0 +1,85 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser_test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestCSTRoundTrip(t *testing.T) {
+	sources := []string{testUnformatted, testFormatted, "", "\n\n", "# only a comment", "struct A {}\r\n\t\n", "struct A {} // \xff\xfe\n"}
+	_ = filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".thrift") {
+			content, err := os.ReadFile(path)
+			test.Assert(t, err == nil, err)
+			sources = append(sources, string(content))
+		}
+		return nil
+	})
+	for _, src := range sources {
+		if _, err := parser.ParseString("a.thrift", src); err != nil {
+			continue
+		}
+		cst, err := parser.ParseCST("a.thrift", src)
+		test.Assert(t, err == nil, err)
+		test.Assert(t, cst.Source() == src, cst.Source())
+		var buf bytes.Buffer
+		_, err = cst.WriteTo(&buf)
+		test.Assert(t, err == nil && buf.String() == src, err)
+	}
+}
+
+func TestCST(t *testing.T) {
+	src := "include \"a.thrift\" // a\n\n/* S */\nstruct S {\n  1: i32 a, # a\n\n  2: string b;\n}\n"
+	cst, err := parser.ParseCST("a.thrift", src)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, cst.Kind == "Document")
+
+	var comments []string
+	for _, c := range cst.FindAll(parser.CSTComment) {
+		comments = append(comments, c.Text)
+	}
+	test.DeepEqual(t, comments, []string{"// a", "/* S */", "# a"})
+
+	structs := cst.FindAll("Struct")
+	test.Assert(t, len(structs) == 1)
+	var tokens []string
+	for _, tok := range structs[0].Tokens() {
+		tokens = append(tokens, tok.Text)
+	}
+	test.DeepEqual(t, tokens, []string{"struct", "S", "{", "1", ":", "i32", "a", ",", "2", ":", "string", "b", ";", "}"})
+	loc := structs[0].FindAll("Identifier")[0].Location
+	test.Assert(t, loc.StartLine == 4 && loc.StartColumn == 8 && loc.EndColumn == 9, loc)
+
+	// rename the struct and drop the separators without touching the comments
+	structs[0].Tokens()[1].Text = "T"
+	for _, sep := range cst.FindAll("ListSeparator") {
+		for _, tok := range sep.Tokens() {
+			tok.Text = ""
+		}
+	}
+	test.Assert(t, cst.Source() == "include \"a.thrift\" // a\n\n/* S */\nstruct T {\n  1: i32 a # a\n\n  2: string b\n}\n", cst.Source())
+
+	_, err = parser.ParseCST("a.thrift", "struct {")
+	test.Assert(t, len(parser.DiagnosticsOf(err, "")) > 0, err)
+}
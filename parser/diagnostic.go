@@ -0,0 +1,122 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CodeSyntaxError is the code of diagnostics reported for malformed IDLs.
+const CodeSyntaxError = "syntax-error"
+
+// NewDiagnosticf creates a diagnostic with a formatted message.
+func NewDiagnosticf(severity Severity, source, code, filename string, loc *Location, format string, args ...interface{}) *Diagnostic {
+	return &Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Source:   source,
+		Filename: filename,
+		Location: loc,
+	}
+}
+
+// AddRelated attaches a related location to the diagnostic and returns the diagnostic itself.
+func (d *Diagnostic) AddRelated(filename string, loc *Location, message string) *Diagnostic {
+	d.Related = append(d.Related, &RelatedLocation{Filename: filename, Location: loc, Message: message})
+	return d
+}
+
+// WithFix sets a suggested fix for the diagnostic and returns the diagnostic itself.
+func (d *Diagnostic) WithFix(message string, edits ...*TextEdit) *Diagnostic {
+	d.Fix = &SuggestedFix{Message: message, Edits: edits}
+	return d
+}
+
+// Error implements the error interface. The result has the form of
+// "filename:line:column: message".
+func (d *Diagnostic) Error() string {
+	if d.Filename == "" {
+		return d.Message
+	}
+	return Position(d.Filename, d.Location) + ": " + d.Message
+}
+
+// Diagnostics is a list of diagnostics. A non-empty Diagnostics can be used as an error.
+type Diagnostics []*Diagnostic
+
+// Error implements the error interface.
+func (ds Diagnostics) Error() string {
+	var ss []string
+	for _, d := range ds {
+		ss = append(ss, d.Error())
+	}
+	return strings.Join(ss, "\n")
+}
+
+// Filter returns the diagnostics that has the given severity.
+func (ds Diagnostics) Filter(severity Severity) (res Diagnostics) {
+	for _, d := range ds {
+		if d.Severity == severity {
+			res = append(res, d)
+		}
+	}
+	return
+}
+
+// HasError reports whether any diagnostic is an error.
+func (ds Diagnostics) HasError() bool {
+	for _, d := range ds {
+		if d.Severity == Severity_Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the errors in the list as an error or nil if there is not any.
+func (ds Diagnostics) Err() error {
+	if errs := ds.Filter(Severity_Error); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Strings formats each diagnostic into a string.
+func (ds Diagnostics) Strings() (ss []string) {
+	for _, d := range ds {
+		ss = append(ss, d.Error())
+	}
+	return
+}
+
+// DiagnosticsOf extracts diagnostics from an error. If the error carries no
+// diagnostic, it is converted into a single error diagnostic from the source.
+func DiagnosticsOf(err error, source string) Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var ds Diagnostics
+	if errors.As(err, &ds) {
+		return ds
+	}
+	var d *Diagnostic
+	if errors.As(err, &d) {
+		return Diagnostics{d}
+	}
+	return Diagnostics{{Severity: Severity_Error, Message: err.Error(), Source: source}}
+}
@@ -0,0 +1,727 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const formatIndent = "    "
+
+// Format rewrites the IDL in the canonical style:
+//   - includes are sorted and placed before other headers;
+//   - definitions and their members are indented with 4 spaces;
+//   - field IDs in a block are right-aligned;
+//   - separators after fields, enum values and functions are removed and
+//     items in lists are separated by ", " or put one per line;
+//   - annotations are written as (key = "value", ...);
+//   - consecutive blank lines are collapsed into one.
+//
+// All comments are preserved. Comments inside a single-line construct are
+// moved above the line containing it. Lists that span multiple lines in the
+// input are kept one item per line.
+func Format(filename, content string) (string, error) {
+	p, err := parseTree(filename, content)
+	if err != nil {
+		return "", err
+	}
+	f := &formatter{buf: p.buffer, done: make(map[*node32]bool), blanks: true}
+	f.document(p.AST())
+	for len(f.lines) > 0 && f.lines[len(f.lines)-1] == "" {
+		f.lines = f.lines[:len(f.lines)-1]
+	}
+	if len(f.lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(f.lines, "\n") + "\n", nil
+}
+
+// parseTree parses the content into a syntax tree. Syntax errors are reported
+// as diagnostics.
+func parseTree(filename, content string) (*parser, error) {
+	p := &parser{}
+	p.Filename = filename
+	p.Buffer = content
+	p.Init()
+	if err := p.ThriftIDL.Parse(); err != nil {
+		if _, diags, err := parseWithRecovery(filename, content, nil); err != nil {
+			return nil, err
+		} else if len(diags) > 0 {
+			return nil, diags
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+type formatter struct {
+	buf     []rune
+	lines   []string
+	indent  int
+	fresh   bool     // whether the current block has no line yet
+	blanks  bool     // whether blank lines in the input are kept
+	pending []string // comments moved out of single-line constructs
+	done    map[*node32]bool
+}
+
+// sub creates a formatter for the items of a multi-line list.
+func (f *formatter) sub() *formatter {
+	return &formatter{buf: f.buf, done: f.done, indent: f.indent + 1, fresh: true, blanks: true}
+}
+
+func (f *formatter) indentation() string {
+	return strings.Repeat(formatIndent, f.indent)
+}
+
+func (f *formatter) line(s string) {
+	f.lines = append(f.lines, f.indentation()+s)
+	f.fresh = false
+}
+
+func (f *formatter) blank() {
+	if f.fresh || len(f.lines) == 0 || f.lines[len(f.lines)-1] == "" {
+		return
+	}
+	f.lines = append(f.lines, "")
+}
+
+func (f *formatter) text(n *node32) string {
+	return string(f.buf[nodeBegin(n):nodeEnd(n)])
+}
+
+// multiline tells whether there is a line break between the two tokens.
+func (f *formatter) multiline(open, close *node32) bool {
+	for _, r := range f.buf[nodeBegin(open):nodeBegin(close)] {
+		if r == '\n' {
+			return true
+		}
+	}
+	return false
+}
+
+// newlinesBefore counts line breaks in the spaces right before the offset.
+func (f *formatter) newlinesBefore(offset uint32) (n int) {
+	for i := int(offset) - 1; i >= 0 && unicode.IsSpace(f.buf[i]); i-- {
+		if f.buf[i] == '\n' {
+			n++
+		}
+	}
+	return
+}
+
+func children(n *node32) (res []*node32) {
+	for c := n.up; c != nil; c = c.next {
+		res = append(res, c)
+	}
+	return
+}
+
+func child(n *node32, rule pegRule) *node32 {
+	for c := n.up; c != nil; c = c.next {
+		if c.pegRule == rule {
+			return c
+		}
+	}
+	return nil
+}
+
+func childrenOf(n *node32, rule pegRule) (res []*node32) {
+	for c := n.up; c != nil; c = c.next {
+		if c.pegRule == rule {
+			res = append(res, c)
+		}
+	}
+	return
+}
+
+// comments returns the comments in the nodes in order.
+func comments(nodes ...*node32) (res []*node32) {
+	var walk func(n *node32)
+	walk = func(n *node32) {
+		for ; n != nil; n = n.next {
+			if n.pegRule == ruleComment {
+				res = append(res, n)
+			} else {
+				walk(n.up)
+			}
+		}
+	}
+	for _, n := range nodes {
+		if n.pegRule == ruleComment {
+			res = append(res, n)
+		} else {
+			walk(n.up)
+		}
+	}
+	return
+}
+
+// span returns the offsets of the first and the last significant runes of the nodes.
+func span(nodes []*node32) (first, last uint32) {
+	first, last = nodes[0].begin, nodes[0].begin
+	for _, n := range nodes {
+		if !isTrivia(n.pegRule) {
+			first = nodeBegin(n)
+			break
+		}
+	}
+	for i := len(nodes) - 1; i >= 0; i-- {
+		if !isTrivia(nodes[i].pegRule) {
+			last = nodeEnd(nodes[i])
+			break
+		}
+	}
+	return
+}
+
+func (f *formatter) commentText(c *node32) string {
+	f.done[c] = true
+	return strings.TrimRight(string(f.buf[c.begin:c.end]), " \t\r")
+}
+
+// comment emits a comment on its own line, or at the end of the last line if
+// there is no line break before it in the input.
+func (f *formatter) comment(c *node32) {
+	nl := f.newlinesBefore(c.begin)
+	text := f.commentText(c)
+	if nl == 0 && len(f.lines) > 0 {
+		f.lines[len(f.lines)-1] += " " + text
+		return
+	}
+	if nl >= 2 && f.blanks {
+		f.blank()
+	}
+	f.line(text)
+}
+
+// leading emits the comments before the offset and keeps a blank line
+// before the token at the offset.
+func (f *formatter) leading(nodes []*node32, offset uint32) {
+	for _, c := range comments(nodes...) {
+		if c.end <= offset && !f.done[c] {
+			f.comment(c)
+		}
+	}
+	if f.newlinesBefore(offset) >= 2 && f.blanks {
+		f.blank()
+	}
+}
+
+// hoist moves the comments that have not been emitted into pending.
+func (f *formatter) hoist(nodes ...*node32) {
+	for _, c := range comments(nodes...) {
+		if !f.done[c] {
+			f.pending = append(f.pending, f.commentText(c))
+		}
+	}
+}
+
+func (f *formatter) flush() {
+	for _, s := range f.pending {
+		f.line(s)
+	}
+	f.pending = nil
+}
+
+// trailing appends the comments that have not been emitted to the last line.
+func (f *formatter) trailing(nodes ...*node32) {
+	for _, c := range comments(nodes...) {
+		if !f.done[c] {
+			f.lines[len(f.lines)-1] += " " + f.commentText(c)
+		}
+	}
+}
+
+// item emits a line rendered from the nodes with the comments around them.
+func (f *formatter) item(nodes []*node32, render func() string) {
+	first, last := span(nodes)
+	f.leading(nodes, first)
+	s := render()
+	var inner, after []*node32
+	for _, c := range comments(nodes...) {
+		if c.begin >= last {
+			after = append(after, c)
+		} else {
+			inner = append(inner, c)
+		}
+	}
+	f.hoist(inner...)
+	f.flush()
+	f.line(s)
+	f.trailing(after...)
+}
+
+// closing emits the comments before a closing token of a block.
+func (f *formatter) closing(n *node32) {
+	for _, c := range comments(n) {
+		if !f.done[c] {
+			f.comment(c)
+		}
+	}
+}
+
+func (f *formatter) document(root *node32) {
+	var includes, headers, defs []*node32
+	var tail *node32
+	for n := root.up; n != nil; n = n.next {
+		switch n.pegRule {
+		case ruleHeader:
+			if child(n, ruleInclude) != nil {
+				includes = append(includes, n)
+			} else {
+				headers = append(headers, n)
+			}
+		case ruleDefinition:
+			defs = append(defs, n)
+		case ruleSkip:
+			tail = n
+		}
+	}
+	if root.up != nil && root.up.pegRule != ruleSkip {
+		f.fileComments(root.up)
+	}
+	sort.SliceStable(includes, func(i, j int) bool {
+		return f.includePath(includes[i]) < f.includePath(includes[j])
+	})
+
+	f.blanks = false
+	for _, group := range [][]*node32{includes, headers} {
+		if len(group) > 0 {
+			f.blank()
+		}
+		for _, h := range group {
+			f.item(children(h), func() string { return f.header(h) })
+		}
+	}
+	f.blanks = true
+	for i, d := range defs {
+		if i == 0 {
+			f.blank()
+		}
+		f.definition(d)
+	}
+	if tail != nil {
+		f.closing(tail)
+	}
+}
+
+// fileComments emits the comments at the beginning of the file which are
+// separated from the first header or definition by a blank line, so that
+// they stay at the top when includes are sorted.
+func (f *formatter) fileComments(first *node32) {
+	begin, _ := span(children(first))
+	cs := comments(first)
+	detached := -1
+	for i, c := range cs {
+		if c.end > begin {
+			break
+		}
+		next := begin
+		if i+1 < len(cs) && cs[i+1].end <= begin {
+			next = cs[i+1].begin
+		}
+		if f.newlinesBefore(next) >= 2 {
+			detached = i
+		}
+	}
+	for _, c := range cs[:detached+1] {
+		f.comment(c)
+	}
+}
+
+func (f *formatter) includePath(h *node32) string {
+	s := f.text(child(child(h, ruleInclude), ruleLiteral))
+	return s[1 : len(s)-1] // the quotes
+}
+
+func (f *formatter) header(h *node32) string {
+	for n := h.up; n != nil; n = n.next {
+		switch n.pegRule {
+		case ruleInclude:
+			return "include " + f.text(child(n, ruleLiteral))
+		case ruleCppInclude:
+			return "cpp_include " + f.text(child(n, ruleLiteral))
+		case ruleNamespace:
+			s := "namespace " + f.text(child(n, ruleNamespaceScope)) + " " + f.text(child(n, ruleIdentifier))
+			if a := child(n, ruleAnnotations); a != nil {
+				s += " " + f.annotations(a)
+			}
+			return s
+		}
+	}
+	return ""
+}
+
+func (f *formatter) definition(d *node32) {
+	var def, annos *node32
+	for n := d.up; n != nil; n = n.next {
+		switch n.pegRule {
+		case ruleAnnotations:
+			annos = n
+		case ruleConst, ruleTypedef, ruleEnum, ruleService, ruleStruct, ruleUnion, ruleException:
+			def = n
+		}
+	}
+	withAnnotations := func(s string) string {
+		if annos != nil {
+			s += " " + f.annotations(annos)
+		}
+		return s
+	}
+	switch def.pegRule {
+	case ruleConst:
+		f.item(children(d), func() string {
+			s := "const " + f.fieldType(child(def, ruleFieldType)) + " " + f.text(child(def, ruleIdentifier)) +
+				" = " + f.constValue(child(def, ruleConstValue))
+			return withAnnotations(s)
+		})
+		return
+	case ruleTypedef:
+		f.item(children(d), func() string {
+			return withAnnotations("typedef " + f.fieldType(child(def, ruleFieldType)) + " " + f.text(child(def, ruleIdentifier)))
+		})
+		return
+	}
+
+	// a block: the header line, the members and the closing line
+	var head []*node32
+	for n := d.up; n != def; n = n.next {
+		head = append(head, n)
+	}
+	var lwing, rwing *node32
+	for n := def.up; n != nil; n = n.next {
+		switch n.pegRule {
+		case ruleLWING:
+			lwing = n
+		case ruleRWING:
+			rwing = n
+		}
+		if lwing == nil || n == lwing {
+			head = append(head, n)
+		}
+	}
+	first, _ := span(head)
+	f.leading(head, first)
+	f.hoist(head...)
+	f.flush()
+
+	idents := childrenOf(def, ruleIdentifier)
+	var s string
+	switch def.pegRule {
+	case ruleStruct:
+		s = "struct " + f.text(idents[0])
+	case ruleUnion:
+		s = "union " + f.text(idents[0])
+	case ruleException:
+		s = "exception " + f.text(idents[0])
+	case ruleEnum:
+		s = "enum " + f.text(idents[0])
+	case ruleService:
+		s = "service " + f.text(idents[0])
+		if len(idents) > 1 {
+			s += " extends " + f.text(idents[1])
+		}
+	}
+	f.line(s + " {")
+
+	f.indent++
+	f.fresh = true
+	switch def.pegRule {
+	case ruleEnum:
+		f.enumValues(def, lwing, rwing)
+	case ruleService:
+		for _, fn := range childrenOf(def, ruleFunction) {
+			fn := fn
+			f.item(children(fn), func() string { return f.function(fn) })
+		}
+	default:
+		f.fields(childrenOf(def, ruleField))
+	}
+	f.closing(rwing)
+	f.indent--
+
+	s = withAnnotations("}")
+	if annos != nil {
+		f.hoist(annos)
+	}
+	f.flush()
+	f.line(s)
+	f.trailing(d)
+}
+
+func (f *formatter) enumValues(def, lwing, rwing *node32) {
+	// (ReservedComments Identifier (EQUAL IntConstant)? Annotations? ListSeparator? ReservedEndLineComments SkipLine)*
+	// Empty nodes are omitted in the tree, so a value starts at its comments or its name.
+	var groups [][]*node32
+	named := true
+	for n := lwing.next; n != nil && n != rwing; n = n.next {
+		if n.pegRule == ruleReservedComments || n.pegRule == ruleIdentifier && named {
+			groups = append(groups, nil)
+			named = false
+		}
+		if n.pegRule == ruleIdentifier {
+			named = true
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], n)
+	}
+	for _, g := range groups {
+		g := g
+		f.item(g, func() string {
+			var s string
+			for _, n := range g {
+				switch n.pegRule {
+				case ruleIdentifier:
+					s = f.text(n)
+				case ruleIntConstant:
+					s += " = " + f.text(n)
+				case ruleAnnotations:
+					s += " " + f.annotations(n)
+				}
+			}
+			return s
+		})
+	}
+}
+
+// idWidth returns the width of the longest field ID.
+func (f *formatter) idWidth(fields []*node32) (width int) {
+	for _, fd := range fields {
+		if id := child(fd, ruleFieldId); id != nil {
+			if w := len(f.text(child(id, ruleIntConstant))); w > width {
+				width = w
+			}
+		}
+	}
+	return
+}
+
+func (f *formatter) fields(fields []*node32) {
+	width := f.idWidth(fields)
+	for _, fd := range fields {
+		fd := fd
+		f.item(children(fd), func() string { return f.field(fd, width) })
+	}
+}
+
+// field formats a field with its ID right-aligned to the width.
+func (f *formatter) field(fd *node32, width int) string {
+	var sb strings.Builder
+	for n := fd.up; n != nil; n = n.next {
+		switch n.pegRule {
+		case ruleFieldId:
+			id := f.text(child(n, ruleIntConstant))
+			if pad := width - len(id); pad > 0 {
+				sb.WriteString(strings.Repeat(" ", pad))
+			}
+			sb.WriteString(id + ": ")
+		case ruleFieldReq:
+			sb.WriteString(f.text(n) + " ")
+		case ruleFieldType:
+			sb.WriteString(f.fieldType(n) + " ")
+		case ruleIdentifier:
+			sb.WriteString(f.text(n))
+		case ruleConstValue:
+			sb.WriteString(" = " + f.constValue(n))
+		case ruleAnnotations:
+			sb.WriteString(" " + f.annotations(n))
+		}
+	}
+	return sb.String()
+}
+
+func (f *formatter) function(fn *node32) string {
+	var sb strings.Builder
+	var args []*node32
+	var lpar *node32
+	for n := fn.up; n != nil; n = n.next {
+		switch n.pegRule {
+		case ruleONEWAY:
+			sb.WriteString("oneway ")
+		case ruleFunctionType:
+			if t := child(n, ruleFieldType); t != nil {
+				sb.WriteString(f.fieldType(t))
+			} else {
+				sb.WriteString("void")
+			}
+		case ruleIdentifier:
+			sb.WriteString(" " + f.text(n))
+		case ruleLPAR:
+			lpar = n
+		case ruleField:
+			args = append(args, n)
+		case ruleRPAR:
+			sb.WriteString(f.fieldList(lpar, args, n))
+		case ruleThrows:
+			sb.WriteString(" throws " + f.fieldList(child(n, ruleLPAR), childrenOf(n, ruleField), child(n, ruleRPAR)))
+		case ruleAnnotations:
+			sb.WriteString(" " + f.annotations(n))
+		}
+	}
+	return sb.String()
+}
+
+// fieldList formats the arguments or the exceptions of a function.
+func (f *formatter) fieldList(lpar *node32, fields []*node32, rpar *node32) string {
+	if len(fields) == 0 && len(comments(rpar)) == 0 {
+		return "()"
+	}
+	if !f.multiline(lpar, rpar) {
+		ss := make([]string, 0, len(fields))
+		for _, fd := range fields {
+			ss = append(ss, f.field(fd, 0))
+		}
+		return "(" + strings.Join(ss, ", ") + ")"
+	}
+	sub := f.sub()
+	sub.fields(fields)
+	sub.closing(rpar)
+	return "(\n" + strings.Join(sub.lines, "\n") + "\n" + f.indentation() + ")"
+}
+
+func (f *formatter) fieldType(n *node32) string {
+	var s string
+	for c := n.up; c != nil; c = c.next {
+		switch c.pegRule {
+		case ruleContainerType:
+			s = f.containerType(c.up)
+		case ruleBaseType, ruleIdentifier:
+			s = f.text(c)
+		case ruleAnnotations:
+			s += " " + f.annotations(c)
+		}
+	}
+	return s
+}
+
+func (f *formatter) containerType(n *node32) string {
+	var cpp string
+	var types []string
+	for c := n.up; c != nil; c = c.next {
+		switch c.pegRule {
+		case ruleCppType:
+			cpp = "cpp_type " + f.text(child(c, ruleLiteral))
+		case ruleFieldType:
+			types = append(types, f.fieldType(c))
+		}
+	}
+	switch n.pegRule {
+	case ruleMapType:
+		if cpp != "" {
+			cpp = " " + cpp
+		}
+		return "map" + cpp + "<" + types[0] + ", " + types[1] + ">"
+	case ruleSetType:
+		if cpp != "" {
+			cpp = " " + cpp
+		}
+		return "set" + cpp + "<" + types[0] + ">"
+	default:
+		if cpp != "" {
+			cpp = " " + cpp
+		}
+		return "list<" + types[0] + ">" + cpp
+	}
+}
+
+func (f *formatter) constValue(n *node32) string {
+	c := n.up
+	switch c.pegRule {
+	case ruleConstList:
+		return f.constList(c)
+	case ruleConstMap:
+		return f.constMap(c)
+	}
+	return f.text(c)
+}
+
+// list formats the groups of nodes as the items of a list enclosed by the
+// open and the close tokens.
+func (f *formatter) list(open, close *node32, groups [][]*node32, render func(g []*node32) string) string {
+	o, c := f.text(open), f.text(close)
+	if len(groups) == 0 && len(comments(close)) == 0 {
+		return o + c
+	}
+	if !f.multiline(open, close) {
+		ss := make([]string, 0, len(groups))
+		for _, g := range groups {
+			ss = append(ss, render(g))
+		}
+		return o + strings.Join(ss, ", ") + c
+	}
+	sub := f.sub()
+	for _, g := range groups {
+		g := g
+		sub.item(g, func() string { return render(g) + "," })
+	}
+	sub.closing(close)
+	return o + "\n" + strings.Join(sub.lines, "\n") + "\n" + f.indentation() + c
+}
+
+func (f *formatter) constList(n *node32) string {
+	// LBRK (ConstValue ListSeparator?)* RBRK
+	var groups [][]*node32
+	for c := n.up; c != nil; c = c.next {
+		switch c.pegRule {
+		case ruleConstValue:
+			groups = append(groups, []*node32{c})
+		case ruleListSeparator:
+			groups[len(groups)-1] = append(groups[len(groups)-1], c)
+		}
+	}
+	return f.list(child(n, ruleLBRK), child(n, ruleRBRK), groups, func(g []*node32) string {
+		return f.constValue(g[0])
+	})
+}
+
+func (f *formatter) constMap(n *node32) string {
+	// LWING (ConstValue COLON ConstValue ListSeparator?)* RWING
+	var groups [][]*node32
+	values := 0
+	for c := n.up; c != nil; c = c.next {
+		switch c.pegRule {
+		case ruleConstValue:
+			if values%2 == 0 {
+				groups = append(groups, nil)
+			}
+			values++
+			groups[len(groups)-1] = append(groups[len(groups)-1], c)
+		case ruleCOLON, ruleListSeparator:
+			groups[len(groups)-1] = append(groups[len(groups)-1], c)
+		}
+	}
+	return f.list(child(n, ruleLWING), child(n, ruleRWING), groups, func(g []*node32) string {
+		var kv []string
+		for _, c := range g {
+			if c.pegRule == ruleConstValue {
+				kv = append(kv, f.constValue(c))
+			}
+		}
+		return kv[0] + ": " + kv[1]
+	})
+}
+
+func (f *formatter) annotations(n *node32) string {
+	// LPAR Annotation* RPAR
+	var groups [][]*node32
+	for _, a := range childrenOf(n, ruleAnnotation) {
+		groups = append(groups, []*node32{a})
+	}
+	return f.list(child(n, ruleLPAR), child(n, ruleRPAR), groups, func(g []*node32) string {
+		a := g[0]
+		return f.text(child(a, ruleIdentifier)) + " = " + f.text(child(a, ruleLiteral))
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+const testUnformatted = `// Copyright header
+
+namespace go example // ns comment
+include "z.thrift"
+include 'a.thrift'
+
+
+/* doc for Const */
+const   i32 X=0x10;
+const list<string> L = ["a",'b' , "c"]
+const map<string,i32> M = {
+  "a":1, // one
+  "b" : 2
+}
+
+typedef map<string , list<i32 (k="v")>> (a = "b") T (x = 'y')
+enum E { A=1, B, // b comment
+ // c leading
+ C = 3 (z = "1");
+}
+
+struct S { // s trailing
+  1: required i32 a = 1, // a comment
+  // b leading
+  10 : optional string b (go.tag = 'json:"b"', x="y")
+
+  /* free */
+  -1: list < S > c
+  // tail
+} (
+  a = "1",
+  b = "2"
+)
+
+service Svc extends base.B {
+  // doc f
+  oneway void f(1:i32 a, 2: string b) (k = "v"),
+  Resp g(
+     1: Req req // req
+     2: i32 x
+  ) throws (1: Err e);
+}
+// end
+`
+
+const testFormatted = `// Copyright header
+
+include 'a.thrift'
+include "z.thrift"
+
+namespace go example // ns comment
+
+/* doc for Const */
+const i32 X = 0x10
+const list<string> L = ["a", 'b', "c"]
+const map<string, i32> M = {
+    "a": 1, // one
+    "b": 2,
+}
+
+typedef map<string, list<i32 (k = "v")>> (a = "b") T (x = 'y')
+enum E {
+    A = 1
+    B // b comment
+    // c leading
+    C = 3 (z = "1")
+}
+
+struct S { // s trailing
+     1: required i32 a = 1 // a comment
+    // b leading
+    10: optional string b (go.tag = 'json:"b"', x = "y")
+
+    /* free */
+    -1: list<S> c
+    // tail
+} (
+    a = "1",
+    b = "2",
+)
+
+service Svc extends base.B {
+    // doc f
+    oneway void f(1: i32 a, 2: string b) (k = "v")
+    Resp g(
+        1: Req req // req
+        2: i32 x
+    ) throws (1: Err e)
+}
+// end
+`
+
+func TestFormat(t *testing.T) {
+	res, err := parser.Format("a.thrift", testUnformatted)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, res == testFormatted, res)
+
+	res, err = parser.Format("a.thrift", testFormatted)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, res == testFormatted, res)
+
+	_, err = parser.Format("a.thrift", "struct A {\n  1: i32\n}\n")
+	diags := parser.DiagnosticsOf(err, "")
+	test.Assert(t, len(diags) == 1 && diags[0].Location.StartLine == 3, err)
+}
+
+// normalize clears the information that formatting does not keep.
+func normalize(ast *parser.Thrift) {
+	sort.SliceStable(ast.Includes, func(i, j int) bool {
+		return ast.Includes[i].Path < ast.Includes[j].Path
+	})
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if !v.IsNil() {
+				walk(v.Elem())
+			}
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i))
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				switch name := v.Type().Field(i).Name; name {
+				case "Location", "ReservedComments":
+					v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+				default:
+					walk(v.Field(i))
+				}
+			}
+		}
+	}
+	walk(reflect.ValueOf(ast))
+}
+
+func TestFormatIDLs(t *testing.T) {
+	var paths []string
+	_ = filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".thrift") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	test.Assert(t, len(paths) > 0)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		test.Assert(t, err == nil, err)
+		src, err := parser.ParseString(path, string(content))
+		if err != nil {
+			continue // some IDLs are invalid on purpose
+		}
+		res, err := parser.Format(path, string(content))
+		test.Assert(t, err == nil, path, err)
+		again, err := parser.Format(path, res)
+		test.Assert(t, err == nil, path, err)
+		test.Assert(t, again == res, path, again)
+
+		dst, err := parser.ParseString(path, res)
+		test.Assert(t, err == nil, path, err)
+		normalize(src)
+		normalize(dst)
+		test.DeepEqual(t, dst, src)
+	}
+}
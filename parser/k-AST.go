@@ -1,4 +1,4 @@
-// Code generated by thriftgo (0.4.1) (fastgo). DO NOT EDIT.
+// Code generated by thriftgo (0.4.5) (fastgo). DO NOT EDIT.
 package parser
 
 import (
@@ -10,6 +10,123 @@ import (
 
 var ThriftGoUnusedProtection = struct{}{}
 
+func (p *Location) BLength() int {
+	if p == nil {
+		return 1
+	}
+	off := 0
+
+	// p.StartLine ID:1 thrift.I32
+	off += 3
+	off += 4
+
+	// p.StartColumn ID:2 thrift.I32
+	off += 3
+	off += 4
+
+	// p.EndLine ID:3 thrift.I32
+	off += 3
+	off += 4
+
+	// p.EndColumn ID:4 thrift.I32
+	off += 3
+	off += 4
+	return off + 1
+}
+
+func (p *Location) FastWrite(b []byte) int { return p.FastWriteNocopy(b, nil) }
+
+func (p *Location) FastWriteNocopy(b []byte, w thrift.NocopyWriter) (n int) {
+	if n = len(p.FastAppend(b[:0])); n > len(b) {
+		panic("buffer overflow. concurrency issue?")
+	}
+	return
+}
+
+func (p *Location) FastAppend(b []byte) []byte {
+	if p == nil {
+		return append(b, 0)
+	}
+	x := thrift.BinaryProtocol{}
+	_ = x
+
+	// p.StartLine
+	b = append(b, 8, 0, 1)
+	b = x.AppendI32(b, int32(p.StartLine))
+
+	// p.StartColumn
+	b = append(b, 8, 0, 2)
+	b = x.AppendI32(b, int32(p.StartColumn))
+
+	// p.EndLine
+	b = append(b, 8, 0, 3)
+	b = x.AppendI32(b, int32(p.EndLine))
+
+	// p.EndColumn
+	b = append(b, 8, 0, 4)
+	b = x.AppendI32(b, int32(p.EndColumn))
+
+	return append(b, 0)
+}
+
+func (p *Location) FastRead(b []byte) (off int, err error) {
+	var ftyp thrift.TType
+	var fid int16
+	var l int
+	x := thrift.BinaryProtocol{}
+	for {
+		ftyp, fid, l, err = x.ReadFieldBegin(b[off:])
+		off += l
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if ftyp == thrift.STOP {
+			break
+		}
+		switch uint32(fid)<<8 | uint32(ftyp) {
+		case 0x108: // p.StartLine ID:1 thrift.I32
+			p.StartLine, l, err = x.ReadI32(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x208: // p.StartColumn ID:2 thrift.I32
+			p.StartColumn, l, err = x.ReadI32(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x308: // p.EndLine ID:3 thrift.I32
+			p.EndLine, l, err = x.ReadI32(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x408: // p.EndColumn ID:4 thrift.I32
+			p.EndColumn, l, err = x.ReadI32(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		default:
+			l, err = x.Skip(b[off:], ftyp)
+			off += l
+			if err != nil {
+				goto SkipFieldError
+			}
+		}
+	}
+	return
+ReadFieldBeginError:
+	return off, thrift.PrependError(fmt.Sprintf("%T read field begin error: ", p), err)
+ReadFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T read field %d '%s' error: ", p, fid, fieldIDToName_Location[fid]), err)
+SkipFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
+}
+
 func (p *Reference) BLength() int {
 	if p == nil {
 		return 1
@@ -116,6 +233,12 @@ func (p *Annotation) BLength() int {
 	for _, v := range p.Values {
 		off += 4 + len(v)
 	}
+
+	// p.Location ID:3 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -148,6 +271,12 @@ func (p *Annotation) FastAppend(b []byte) []byte {
 		b = append(b, v...)
 	}
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 3)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -187,6 +316,13 @@ func (p *Annotation) FastRead(b []byte) (off int, err error) {
 					goto ReadFieldError
 				}
 			}
+		case 0x30c: // p.Location ID:3 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -254,6 +390,12 @@ func (p *Type) BLength() int {
 		off += 3
 		off += 1
 	}
+
+	// p.Location ID:9 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -318,6 +460,12 @@ func (p *Type) FastAppend(b []byte) []byte {
 		b = append(b, *(*byte)(unsafe.Pointer(p.IsTypedef)))
 	}
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 9)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -402,6 +550,13 @@ func (p *Type) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x90c: // p.Location ID:9 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -441,6 +596,12 @@ func (p *Namespace) BLength() int {
 	for _, v := range p.Annotations {
 		off += v.BLength()
 	}
+
+	// p.Location ID:4 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -477,6 +638,12 @@ func (p *Namespace) FastAppend(b []byte) []byte {
 		b = v.FastAppend(b)
 	}
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 4)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -523,6 +690,13 @@ func (p *Namespace) FastRead(b []byte) (off int, err error) {
 					goto ReadFieldError
 				}
 			}
+		case 0x40c: // p.Location ID:4 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -568,6 +742,12 @@ func (p *Typedef) BLength() int {
 	// p.ReservedComments ID:4 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:5 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -610,6 +790,12 @@ func (p *Typedef) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 5)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -663,6 +849,13 @@ func (p *Typedef) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x50c: // p.Location ID:5 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -706,6 +899,12 @@ func (p *EnumValue) BLength() int {
 	// p.ReservedComments ID:4 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:5 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -746,6 +945,12 @@ func (p *EnumValue) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 5)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -798,6 +1003,13 @@ func (p *EnumValue) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x50c: // p.Location ID:5 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -844,6 +1056,12 @@ func (p *Enum) BLength() int {
 	// p.ReservedComments ID:4 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:5 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -887,6 +1105,12 @@ func (p *Enum) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 5)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -949,6 +1173,13 @@ func (p *Enum) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x50c: // p.Location ID:5 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -1108,6 +1339,12 @@ func (p *ConstValue) BLength() int {
 		off += 3
 		off += p.Extra.BLength()
 	}
+
+	// p.Location ID:4 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -1143,6 +1380,12 @@ func (p *ConstValue) FastAppend(b []byte) []byte {
 		b = p.Extra.FastAppend(b)
 	}
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 4)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -1183,6 +1426,13 @@ func (p *ConstValue) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x40c: // p.Location ID:4 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -1333,6 +1583,12 @@ func (p *Constant) BLength() int {
 	// p.ReservedComments ID:5 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:6 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -1381,6 +1637,12 @@ func (p *Constant) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 6)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -1441,6 +1703,13 @@ func (p *Constant) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x60c: // p.Location ID:6 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -1498,6 +1767,12 @@ func (p *Field) BLength() int {
 	// p.ReservedComments ID:7 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:8 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -1552,6 +1827,12 @@ func (p *Field) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 8)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -1626,6 +1907,13 @@ func (p *Field) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x80c: // p.Location ID:8 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -1676,6 +1964,12 @@ func (p *StructLike) BLength() int {
 	// p.ReservedComments ID:5 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:6 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -1724,6 +2018,12 @@ func (p *StructLike) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 6)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -1792,6 +2092,13 @@ func (p *StructLike) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x60c: // p.Location ID:6 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -1859,6 +2166,12 @@ func (p *Function) BLength() int {
 	// p.ReservedComments ID:8 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:9 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -1923,6 +2236,12 @@ func (p *Function) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 9)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -2020,6 +2339,13 @@ func (p *Function) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x90c: // p.Location ID:9 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -2076,6 +2402,12 @@ func (p *Service) BLength() int {
 	// p.ReservedComments ID:6 thrift.STRING
 	off += 3
 	off += 4 + len(p.ReservedComments)
+
+	// p.Location ID:7 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -2130,6 +2462,12 @@ func (p *Service) FastAppend(b []byte) []byte {
 	b = x.AppendI32(b, int32(len(p.ReservedComments)))
 	b = append(b, p.ReservedComments...)
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 7)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -2205,6 +2543,13 @@ func (p *Service) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x70c: // p.Location ID:7 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -2245,6 +2590,12 @@ func (p *Include) BLength() int {
 		off += 3
 		off += 1
 	}
+
+	// p.Location ID:4 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
 	return off + 1
 }
 
@@ -2281,6 +2632,12 @@ func (p *Include) FastAppend(b []byte) []byte {
 		b = append(b, *(*byte)(unsafe.Pointer(p.Used)))
 	}
 
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 4)
+		b = p.Location.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -2321,6 +2678,13 @@ func (p *Include) FastRead(b []byte) (off int, err error) {
 			if err != nil {
 				goto ReadFieldError
 			}
+		case 0x40c: // p.Location ID:4 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -2758,6 +3122,537 @@ SkipFieldError:
 		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
 }
 
+func (p *RelatedLocation) BLength() int {
+	if p == nil {
+		return 1
+	}
+	off := 0
+
+	// p.Filename ID:1 thrift.STRING
+	off += 3
+	off += 4 + len(p.Filename)
+
+	// p.Location ID:2 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
+
+	// p.Message ID:3 thrift.STRING
+	off += 3
+	off += 4 + len(p.Message)
+	return off + 1
+}
+
+func (p *RelatedLocation) FastWrite(b []byte) int { return p.FastWriteNocopy(b, nil) }
+
+func (p *RelatedLocation) FastWriteNocopy(b []byte, w thrift.NocopyWriter) (n int) {
+	if n = len(p.FastAppend(b[:0])); n > len(b) {
+		panic("buffer overflow. concurrency issue?")
+	}
+	return
+}
+
+func (p *RelatedLocation) FastAppend(b []byte) []byte {
+	if p == nil {
+		return append(b, 0)
+	}
+	x := thrift.BinaryProtocol{}
+	_ = x
+
+	// p.Filename
+	b = append(b, 11, 0, 1)
+	b = x.AppendI32(b, int32(len(p.Filename)))
+	b = append(b, p.Filename...)
+
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 2)
+		b = p.Location.FastAppend(b)
+	}
+
+	// p.Message
+	b = append(b, 11, 0, 3)
+	b = x.AppendI32(b, int32(len(p.Message)))
+	b = append(b, p.Message...)
+
+	return append(b, 0)
+}
+
+func (p *RelatedLocation) FastRead(b []byte) (off int, err error) {
+	var ftyp thrift.TType
+	var fid int16
+	var l int
+	x := thrift.BinaryProtocol{}
+	for {
+		ftyp, fid, l, err = x.ReadFieldBegin(b[off:])
+		off += l
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if ftyp == thrift.STOP {
+			break
+		}
+		switch uint32(fid)<<8 | uint32(ftyp) {
+		case 0x10b: // p.Filename ID:1 thrift.STRING
+			p.Filename, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x20c: // p.Location ID:2 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x30b: // p.Message ID:3 thrift.STRING
+			p.Message, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		default:
+			l, err = x.Skip(b[off:], ftyp)
+			off += l
+			if err != nil {
+				goto SkipFieldError
+			}
+		}
+	}
+	return
+ReadFieldBeginError:
+	return off, thrift.PrependError(fmt.Sprintf("%T read field begin error: ", p), err)
+ReadFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T read field %d '%s' error: ", p, fid, fieldIDToName_RelatedLocation[fid]), err)
+SkipFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
+}
+
+func (p *TextEdit) BLength() int {
+	if p == nil {
+		return 1
+	}
+	off := 0
+
+	// p.Filename ID:1 thrift.STRING
+	off += 3
+	off += 4 + len(p.Filename)
+
+	// p.Location ID:2 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
+
+	// p.NewText ID:3 thrift.STRING
+	off += 3
+	off += 4 + len(p.NewText)
+	return off + 1
+}
+
+func (p *TextEdit) FastWrite(b []byte) int { return p.FastWriteNocopy(b, nil) }
+
+func (p *TextEdit) FastWriteNocopy(b []byte, w thrift.NocopyWriter) (n int) {
+	if n = len(p.FastAppend(b[:0])); n > len(b) {
+		panic("buffer overflow. concurrency issue?")
+	}
+	return
+}
+
+func (p *TextEdit) FastAppend(b []byte) []byte {
+	if p == nil {
+		return append(b, 0)
+	}
+	x := thrift.BinaryProtocol{}
+	_ = x
+
+	// p.Filename
+	b = append(b, 11, 0, 1)
+	b = x.AppendI32(b, int32(len(p.Filename)))
+	b = append(b, p.Filename...)
+
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 2)
+		b = p.Location.FastAppend(b)
+	}
+
+	// p.NewText
+	b = append(b, 11, 0, 3)
+	b = x.AppendI32(b, int32(len(p.NewText)))
+	b = append(b, p.NewText...)
+
+	return append(b, 0)
+}
+
+func (p *TextEdit) FastRead(b []byte) (off int, err error) {
+	var ftyp thrift.TType
+	var fid int16
+	var l int
+	x := thrift.BinaryProtocol{}
+	for {
+		ftyp, fid, l, err = x.ReadFieldBegin(b[off:])
+		off += l
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if ftyp == thrift.STOP {
+			break
+		}
+		switch uint32(fid)<<8 | uint32(ftyp) {
+		case 0x10b: // p.Filename ID:1 thrift.STRING
+			p.Filename, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x20c: // p.Location ID:2 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x30b: // p.NewText ID:3 thrift.STRING
+			p.NewText, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		default:
+			l, err = x.Skip(b[off:], ftyp)
+			off += l
+			if err != nil {
+				goto SkipFieldError
+			}
+		}
+	}
+	return
+ReadFieldBeginError:
+	return off, thrift.PrependError(fmt.Sprintf("%T read field begin error: ", p), err)
+ReadFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T read field %d '%s' error: ", p, fid, fieldIDToName_TextEdit[fid]), err)
+SkipFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
+}
+
+func (p *SuggestedFix) BLength() int {
+	if p == nil {
+		return 1
+	}
+	off := 0
+
+	// p.Message ID:1 thrift.STRING
+	off += 3
+	off += 4 + len(p.Message)
+
+	// p.Edits ID:2 thrift.LIST
+	off += 3
+	off += 5
+	for _, v := range p.Edits {
+		off += v.BLength()
+	}
+	return off + 1
+}
+
+func (p *SuggestedFix) FastWrite(b []byte) int { return p.FastWriteNocopy(b, nil) }
+
+func (p *SuggestedFix) FastWriteNocopy(b []byte, w thrift.NocopyWriter) (n int) {
+	if n = len(p.FastAppend(b[:0])); n > len(b) {
+		panic("buffer overflow. concurrency issue?")
+	}
+	return
+}
+
+func (p *SuggestedFix) FastAppend(b []byte) []byte {
+	if p == nil {
+		return append(b, 0)
+	}
+	x := thrift.BinaryProtocol{}
+	_ = x
+
+	// p.Message
+	b = append(b, 11, 0, 1)
+	b = x.AppendI32(b, int32(len(p.Message)))
+	b = append(b, p.Message...)
+
+	// p.Edits
+	b = append(b, 15, 0, 2)
+	b = x.AppendListBegin(b, thrift.STRUCT, len(p.Edits))
+	for _, v := range p.Edits {
+		b = v.FastAppend(b)
+	}
+
+	return append(b, 0)
+}
+
+func (p *SuggestedFix) FastRead(b []byte) (off int, err error) {
+	var ftyp thrift.TType
+	var fid int16
+	var l int
+	x := thrift.BinaryProtocol{}
+	for {
+		ftyp, fid, l, err = x.ReadFieldBegin(b[off:])
+		off += l
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if ftyp == thrift.STOP {
+			break
+		}
+		switch uint32(fid)<<8 | uint32(ftyp) {
+		case 0x10b: // p.Message ID:1 thrift.STRING
+			p.Message, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x20f: // p.Edits ID:2 thrift.LIST
+			var sz int
+			_, sz, l, err = x.ReadListBegin(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Edits = make([]*TextEdit, sz)
+			for i := 0; i < sz; i++ {
+				p.Edits[i] = NewTextEdit()
+				l, err = p.Edits[i].FastRead(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+			}
+		default:
+			l, err = x.Skip(b[off:], ftyp)
+			off += l
+			if err != nil {
+				goto SkipFieldError
+			}
+		}
+	}
+	return
+ReadFieldBeginError:
+	return off, thrift.PrependError(fmt.Sprintf("%T read field begin error: ", p), err)
+ReadFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T read field %d '%s' error: ", p, fid, fieldIDToName_SuggestedFix[fid]), err)
+SkipFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
+}
+
+func (p *Diagnostic) BLength() int {
+	if p == nil {
+		return 1
+	}
+	off := 0
+
+	// p.Severity ID:1 thrift.I32
+	off += 3
+	off += 4
+
+	// p.Code ID:2 thrift.STRING
+	off += 3
+	off += 4 + len(p.Code)
+
+	// p.Message ID:3 thrift.STRING
+	off += 3
+	off += 4 + len(p.Message)
+
+	// p.Source ID:4 thrift.STRING
+	off += 3
+	off += 4 + len(p.Source)
+
+	// p.Filename ID:5 thrift.STRING
+	off += 3
+	off += 4 + len(p.Filename)
+
+	// p.Location ID:6 thrift.STRUCT
+	if p.Location != nil {
+		off += 3
+		off += p.Location.BLength()
+	}
+
+	// p.Related ID:7 thrift.LIST
+	off += 3
+	off += 5
+	for _, v := range p.Related {
+		off += v.BLength()
+	}
+
+	// p.Fix ID:8 thrift.STRUCT
+	if p.Fix != nil {
+		off += 3
+		off += p.Fix.BLength()
+	}
+	return off + 1
+}
+
+func (p *Diagnostic) FastWrite(b []byte) int { return p.FastWriteNocopy(b, nil) }
+
+func (p *Diagnostic) FastWriteNocopy(b []byte, w thrift.NocopyWriter) (n int) {
+	if n = len(p.FastAppend(b[:0])); n > len(b) {
+		panic("buffer overflow. concurrency issue?")
+	}
+	return
+}
+
+func (p *Diagnostic) FastAppend(b []byte) []byte {
+	if p == nil {
+		return append(b, 0)
+	}
+	x := thrift.BinaryProtocol{}
+	_ = x
+
+	// p.Severity
+	b = append(b, 8, 0, 1)
+	b = x.AppendI32(b, int32(p.Severity))
+
+	// p.Code
+	b = append(b, 11, 0, 2)
+	b = x.AppendI32(b, int32(len(p.Code)))
+	b = append(b, p.Code...)
+
+	// p.Message
+	b = append(b, 11, 0, 3)
+	b = x.AppendI32(b, int32(len(p.Message)))
+	b = append(b, p.Message...)
+
+	// p.Source
+	b = append(b, 11, 0, 4)
+	b = x.AppendI32(b, int32(len(p.Source)))
+	b = append(b, p.Source...)
+
+	// p.Filename
+	b = append(b, 11, 0, 5)
+	b = x.AppendI32(b, int32(len(p.Filename)))
+	b = append(b, p.Filename...)
+
+	// p.Location
+	if p.Location != nil {
+		b = append(b, 12, 0, 6)
+		b = p.Location.FastAppend(b)
+	}
+
+	// p.Related
+	b = append(b, 15, 0, 7)
+	b = x.AppendListBegin(b, thrift.STRUCT, len(p.Related))
+	for _, v := range p.Related {
+		b = v.FastAppend(b)
+	}
+
+	// p.Fix
+	if p.Fix != nil {
+		b = append(b, 12, 0, 8)
+		b = p.Fix.FastAppend(b)
+	}
+
+	return append(b, 0)
+}
+
+func (p *Diagnostic) FastRead(b []byte) (off int, err error) {
+	var ftyp thrift.TType
+	var fid int16
+	var l int
+	var enum int32
+	x := thrift.BinaryProtocol{}
+	for {
+		ftyp, fid, l, err = x.ReadFieldBegin(b[off:])
+		off += l
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if ftyp == thrift.STOP {
+			break
+		}
+		switch uint32(fid)<<8 | uint32(ftyp) {
+		case 0x108: // p.Severity ID:1 thrift.I32
+			enum, l, err = x.ReadI32(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Severity = Severity(enum)
+		case 0x20b: // p.Code ID:2 thrift.STRING
+			p.Code, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x30b: // p.Message ID:3 thrift.STRING
+			p.Message, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x40b: // p.Source ID:4 thrift.STRING
+			p.Source, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x50b: // p.Filename ID:5 thrift.STRING
+			p.Filename, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x60c: // p.Location ID:6 thrift.STRUCT
+			p.Location = NewLocation()
+			l, err = p.Location.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		case 0x70f: // p.Related ID:7 thrift.LIST
+			var sz int
+			_, sz, l, err = x.ReadListBegin(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Related = make([]*RelatedLocation, sz)
+			for i := 0; i < sz; i++ {
+				p.Related[i] = NewRelatedLocation()
+				l, err = p.Related[i].FastRead(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+			}
+		case 0x80c: // p.Fix ID:8 thrift.STRUCT
+			p.Fix = NewSuggestedFix()
+			l, err = p.Fix.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+		default:
+			l, err = x.Skip(b[off:], ftyp)
+			off += l
+			if err != nil {
+				goto SkipFieldError
+			}
+		}
+	}
+	return
+ReadFieldBeginError:
+	return off, thrift.PrependError(fmt.Sprintf("%T read field begin error: ", p), err)
+ReadFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T read field %d '%s' error: ", p, fid, fieldIDToName_Diagnostic[fid]), err)
+SkipFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
+}
+
 func (p *ConstTypedValue) BLength() int {
 	if p == nil {
 		return 1
@@ -21,61 +21,252 @@ import (
 	"github.com/cloudwego/thriftgo/pkg/reserved"
 )
 
-// DetectKeyword detects if there is any identifier using a reserved
-// word in common programming languages.
-func DetectKeyword(t *Thrift) (warnings []string) {
+// CodeReservedKeyword is the code of diagnostics reported for identifiers
+// using reserved words.
+const CodeReservedKeyword = "reserved-keyword"
+
+// identifier is a name defined in an IDL.
+type identifier struct {
+	name  *string
+	scope []string // the enclosing definitions and the identifier itself
+	loc   *Location
+	wire  bool // the name is seen by peers, such as the names of methods and fields
+}
+
+// identifiers calls visit for each name defined in the IDL.
+func identifiers(t *Thrift, visit func(id *identifier)) {
 	p := func(kind, name string) string {
 		return fmt.Sprintf("<%s:%q>", kind, name)
 	}
-	report := func(langs []string, word string, scope ...string) {
-		msg := fmt.Sprintf("%q is a reserved word in %v (%s)",
-			word, langs, strings.Join(scope, "."))
-		warnings = append(warnings, msg)
-	}
 	for _, v := range t.Typedefs {
-		if langs := reserved.Hit(v.Alias); len(langs) > 0 {
-			report(langs, v.Alias, p("typedef", v.Alias))
-		}
+		visit(&identifier{&v.Alias, []string{p("typedef", v.Alias)}, v.Location, false})
 	}
 	for _, v := range t.Constants {
-		if langs := reserved.Hit(v.Name); len(langs) > 0 {
-			report(langs, v.Name, p("constant", v.Name))
-		}
+		visit(&identifier{&v.Name, []string{p("constant", v.Name)}, v.Location, false})
 	}
 	for _, v := range t.Enums {
-		if langs := reserved.Hit(v.Name); len(langs) > 0 {
-			report(langs, v.Name, p("enum", v.Name))
+		scope := p("enum", v.Name)
+		for _, ev := range v.Values {
+			visit(&identifier{&ev.Name, []string{scope, p("value", ev.Name)}, ev.Location, false})
 		}
+		visit(&identifier{&v.Name, []string{scope}, v.Location, false})
 	}
 	for _, v := range t.GetStructLikes() {
-		if langs := reserved.Hit(v.Name); len(langs) > 0 {
-			report(langs, v.Name, p(v.Category, v.Name))
-		}
+		scope := p(v.Category, v.Name)
 		for _, f := range v.Fields {
-			if langs := reserved.Hit(f.Name); len(langs) > 0 {
-				report(langs, f.Name, p(v.Category, v.Name), p("field", f.Name))
-			}
+			visit(&identifier{&f.Name, []string{scope, p("field", f.Name)}, f.Location, true})
 		}
+		visit(&identifier{&v.Name, []string{scope}, v.Location, false})
 	}
 	for _, v := range t.Services {
-		if langs := reserved.Hit(v.Name); len(langs) > 0 {
-			report(langs, v.Name, p("service", v.Name))
-		}
+		scope := p("service", v.Name)
 		for _, f := range v.Functions {
-			if langs := reserved.Hit(f.Name); len(langs) > 0 {
-				report(langs, f.Name, p("service", v.Name), p("function", f.Name))
-			}
+			fs := p("function", f.Name)
 			for _, a := range f.Arguments {
-				if langs := reserved.Hit(a.Name); len(langs) > 0 {
-					report(langs, a.Name, p("service", v.Name), p("function", f.Name), p("parameter", a.Name))
-				}
+				visit(&identifier{&a.Name, []string{scope, fs, p("parameter", a.Name)}, a.Location, true})
 			}
 			for _, a := range f.Throws {
-				if langs := reserved.Hit(a.Name); len(langs) > 0 {
-					report(langs, a.Name, p("service", v.Name), p("function", f.Name), p("exception", a.Name))
-				}
+				visit(&identifier{&a.Name, []string{scope, fs, p("exception", a.Name)}, a.Location, true})
 			}
+			visit(&identifier{&f.Name, []string{scope, fs}, f.Location, true})
 		}
+		visit(&identifier{&v.Name, []string{scope}, v.Location, true})
+	}
+}
+
+// DetectKeyword detects if there is any identifier using a reserved
+// word in common programming languages.
+func DetectKeyword(t *Thrift) (warnings []string) {
+	for _, d := range detectKeywords(t, nil, Severity_Warning) {
+		warnings = append(warnings, d.Message)
 	}
 	return
 }
+
+func detectKeywords(t *Thrift, langs []string, severity Severity) (diags Diagnostics) {
+	identifiers(t, func(id *identifier) {
+		if hits := reserved.HitIn(*id.name, langs); len(hits) > 0 {
+			diags = append(diags, NewDiagnosticf(severity, "parser", CodeReservedKeyword, t.Filename, id.loc,
+				"%q is a reserved word in %v (%s)", *id.name, hits, strings.Join(id.scope, ".")))
+		}
+	})
+	return
+}
+
+// DiagnoseKeywords reports identifiers in the AST and its includes that are
+// reserved words in the given languages, or in all known languages if langs
+// is empty.
+func DiagnoseKeywords(t *Thrift, langs []string, severity Severity) (diags Diagnostics) {
+	for tt := range t.DepthFirstSearch() {
+		diags = append(diags, detectKeywords(tt, langs, severity)...)
+	}
+	return
+}
+
+// escaped are the languages whose backends never use keywords as the
+// generated identifiers. The Go backend, for example, exports the names of
+// definitions and adds a prefix to the other names that are keywords.
+var escaped = map[string]bool{"Go": true}
+
+// RenameKeywords appends the suffix to the names of definitions in the AST and
+// its includes that are reserved words in the given languages, or in all known
+// languages if langs is empty. The names of services, functions, fields and
+// parameters are kept, since they are seen by peers on the wire and backends
+// must escape them in the generated code. References to the renamed
+// definitions are updated as well so that every backend sees the same names.
+// The AST must not be resolved yet. It returns a message for each renamed
+// identifier, or an error if a new name is already defined.
+func RenameKeywords(t *Thrift, langs []string, suffix string) (renamed []string, err error) {
+	needed := func(name string) bool {
+		for _, l := range reserved.HitIn(name, langs) {
+			if !escaped[l] {
+				return true
+			}
+		}
+		return false
+	}
+	// names in the same enclosing definition must be unique
+	key := func(id *identifier, name string) string {
+		return strings.Join(id.scope[:len(id.scope)-1], ".") + "." + name
+	}
+
+	// rename definitions and record the new names of those can be referenced
+	asts := make(map[*Thrift]map[string]string)
+	for tt := range t.DepthFirstSearch() {
+		defs := make(map[string]string)
+		asts[tt] = defs
+		enums := make(map[*Enum][]string)
+		for _, e := range tt.Enums {
+			names := []string{e.Name}
+			for _, v := range e.Values {
+				names = append(names, v.Name)
+			}
+			enums[e] = names
+		}
+		taken := make(map[string]bool)
+		identifiers(tt, func(id *identifier) {
+			taken[key(id, *id.name)] = true
+		})
+		identifiers(tt, func(id *identifier) {
+			if err != nil || id.wire {
+				return
+			}
+			name := *id.name
+			for needed(name) {
+				name += suffix
+			}
+			if name == *id.name {
+				return
+			}
+			if taken[key(id, name)] {
+				err = fmt.Errorf("%s: %s can not be renamed to %q which is already defined",
+					tt.Filename, strings.Join(id.scope, "."), name)
+				return
+			}
+			taken[key(id, name)] = true
+			renamed = append(renamed, fmt.Sprintf("%s: %s is renamed to %q",
+				tt.Filename, strings.Join(id.scope, "."), name))
+			if len(id.scope) == 1 {
+				defs[*id.name] = name
+			}
+			*id.name = name
+		})
+		if err != nil {
+			return nil, err
+		}
+		for e, names := range enums {
+			for i, v := range e.Values {
+				defs[names[0]+"."+names[i+1]] = e.Name + "." + v.Name
+			}
+		}
+	}
+
+	for tt, defs := range asts {
+		r := &refRenamer{ast: tt, asts: asts, defs: defs}
+		r.thrift()
+	}
+	return renamed, nil
+}
+
+// refRenamer updates the references in an AST to renamed definitions.
+type refRenamer struct {
+	ast  *Thrift
+	asts map[*Thrift]map[string]string
+	defs map[string]string
+}
+
+// ref returns the new name of a reference in the form of "Name",
+// "Enum.Value", "include.Name" or "include.Enum.Value".
+func (r *refRenamer) ref(name string) string {
+	if i := strings.IndexByte(name, '.'); i > 0 {
+		if inc, ok := r.ast.GetReference(name[:i]); ok {
+			if n, ok := r.asts[inc][name[i+1:]]; ok {
+				return name[:i+1] + n
+			}
+		}
+	}
+	if n, ok := r.defs[name]; ok {
+		return n
+	}
+	return name
+}
+
+func (r *refRenamer) typ(t *Type) {
+	if t == nil {
+		return
+	}
+	switch t.Name {
+	case "map", "list", "set":
+		r.typ(t.KeyType)
+		r.typ(t.ValueType)
+	case "bool", "byte", "i8", "i16", "i32", "i64", "double", "string", "binary", "uuid":
+	default:
+		t.Name = r.ref(t.Name)
+	}
+}
+
+func (r *refRenamer) value(v *ConstValue) {
+	if v == nil || v.TypedValue == nil {
+		return
+	}
+	if id := v.TypedValue.Identifier; id != nil {
+		*id = r.ref(*id)
+	}
+	for _, e := range v.TypedValue.List {
+		r.value(e)
+	}
+	for _, kv := range v.TypedValue.Map {
+		r.value(kv.Key)
+		r.value(kv.Value)
+	}
+}
+
+func (r *refRenamer) fields(fs []*Field) {
+	for _, f := range fs {
+		r.typ(f.Type)
+		r.value(f.Default)
+	}
+}
+
+func (r *refRenamer) thrift() {
+	for _, v := range r.ast.Typedefs {
+		r.typ(v.Type)
+	}
+	for _, v := range r.ast.Constants {
+		r.typ(v.Type)
+		r.value(v.Value)
+	}
+	for _, v := range r.ast.GetStructLikes() {
+		r.fields(v.Fields)
+	}
+	for _, v := range r.ast.Services {
+		if v.Extends != "" {
+			v.Extends = r.ref(v.Extends)
+		}
+		for _, f := range v.Functions {
+			r.typ(f.FunctionType)
+			r.fields(f.Arguments)
+			r.fields(f.Throws)
+		}
+	}
+}
@@ -121,3 +121,71 @@ func TestDetectKeyword(t *testing.T) {
 	warns := DetectKeyword(tree)
 	test.Assert(t, len(warns) == cnt)
 }
+
+func TestDiagnoseKeywords(t *testing.T) {
+	ast, err := ParseString("a.thrift", "struct type {\n  1: i32 from\n}\n")
+	test.Assert(t, err == nil, err)
+	diags := DiagnoseKeywords(ast, []string{"go"}, Severity_Error)
+	test.Assert(t, len(diags) == 1, diags)
+	test.Assert(t, diags[0].Error() == `a.thrift:1:1: "type" is a reserved word in [Go] (<struct:"type">)`, diags[0])
+	test.Assert(t, diags[0].Severity == Severity_Error && diags[0].Code == CodeReservedKeyword)
+	test.Assert(t, len(DiagnoseKeywords(ast, []string{"Python"}, Severity_Warning)) == 1)
+	test.Assert(t, len(DiagnoseKeywords(ast, nil, Severity_Warning)) == 2)
+}
+
+func TestRenameKeywords(t *testing.T) {
+	inc, err := ParseString("inc.thrift", "enum func { default = 1 }\nservice interface {}\n")
+	test.Assert(t, err == nil, err)
+	ast, err := ParseString("a.thrift", `include "inc.thrift"
+enum type { go = 1 }
+const type C = type.go
+const map<string, inc.func> M = {"range": inc.func.default}
+const bool B = true
+struct struct {
+    1: type type = C
+    2: list<struct> l
+}
+service select extends inc.interface {
+    struct import(1: struct var)
+}
+`)
+	test.Assert(t, err == nil, err)
+	ast.Includes[0].Reference = inc
+
+	// the Go backend escapes keywords itself
+	renamed, err := RenameKeywords(ast, []string{"Go"}, "_")
+	test.Assert(t, err == nil && len(renamed) == 0, err, renamed)
+
+	renamed, err = RenameKeywords(ast, nil, "_")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(renamed) == 2, renamed)
+	test.Assert(t, renamed[0] == `inc.thrift: <enum:"func">.<value:"default"> is renamed to "default_"`, renamed[0])
+	test.Assert(t, inc.Enums[0].Name == "func" && inc.Enums[0].Values[0].Name == "default_")
+	test.Assert(t, inc.Services[0].Name == "interface")
+	test.Assert(t, ast.Enums[0].Name == "type" && ast.Enums[0].Values[0].Name == "go")
+	test.Assert(t, ast.Constants[0].Type.Name == "type")
+	test.Assert(t, *ast.Constants[0].Value.TypedValue.Identifier == "type.go")
+	test.Assert(t, ast.Constants[1].Type.ValueType.Name == "inc.func")
+	test.Assert(t, *ast.Constants[1].Value.TypedValue.Map[0].Value.TypedValue.Identifier == "inc.func.default_")
+	test.Assert(t, *ast.Constants[2].Value.TypedValue.Identifier == "true")
+	s := ast.Structs[0]
+	test.Assert(t, s.Name == "struct_" && s.Fields[0].Type.Name == "type")
+	test.Assert(t, *s.Fields[0].Default.TypedValue.Identifier == "C")
+	test.Assert(t, s.Fields[1].Type.ValueType.Name == "struct_")
+
+	// names on the wire are kept
+	test.Assert(t, s.Fields[0].Name == "type")
+	svc := ast.Services[0]
+	test.Assert(t, svc.Name == "select" && svc.Extends == "inc.interface")
+	fn := svc.Functions[0]
+	test.Assert(t, fn.Name == "import" && fn.FunctionType.Name == "struct_")
+	test.Assert(t, fn.Arguments[0].Name == "var" && fn.Arguments[0].Type.Name == "struct_")
+}
+
+func TestRenameKeywordsConflict(t *testing.T) {
+	ast, err := ParseString("a.thrift", "struct class {}\nstruct class_ {}\n")
+	test.Assert(t, err == nil, err)
+	_, err = RenameKeywords(ast, []string{"Java"}, "_")
+	test.Assert(t, err != nil && err.Error() == `a.thrift: <struct:"class"> can not be renamed to "class_" which is already defined`, err)
+	test.Assert(t, ast.Structs[0].Name == "class")
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// isTrivia tells whether a rule only matches spaces, comments or separators
+// which should not be counted as a part of the node it belongs to.
+func isTrivia(rule pegRule) bool {
+	switch rule {
+	case ruleSkip, ruleSkipLine, ruleSpace, ruleIndent, ruleCarriageReturnLineFeed,
+		ruleComment, ruleLongComment, ruleLineComment, ruleUnixComment,
+		ruleReservedComments, ruleReservedEndLineComments, ruleListSeparator:
+		return true
+	}
+	return false
+}
+
+// nodeBegin returns the offset of the first significant rune of the node.
+func nodeBegin(node *node32) uint32 {
+	begin := node.begin
+	for n := node.up; n != nil && n.begin == begin; n = n.next {
+		if !isTrivia(n.pegRule) {
+			return nodeBegin(n)
+		}
+		begin = n.end
+	}
+	return begin
+}
+
+// nodeEnd returns the offset right after the last significant rune of the node.
+func nodeEnd(node *node32) uint32 {
+	var children []*node32
+	for n := node.up; n != nil; n = n.next {
+		children = append(children, n)
+	}
+	end := node.end
+	for i := len(children) - 1; i >= 0 && children[i].end == end; i-- {
+		if !isTrivia(children[i].pegRule) {
+			return nodeEnd(children[i])
+		}
+		end = children[i].begin
+	}
+	return end
+}
+
+// position converts an offset in the buffer to a 1-based line and column.
+func (p *parser) position(offset uint32) (line, column int32) {
+	if p.lineStarts == nil {
+		p.lineStarts = []int{0}
+		for i, r := range p.buffer {
+			if r == '\n' {
+				p.lineStarts = append(p.lineStarts, i+1)
+			}
+		}
+	}
+	idx := sort.SearchInts(p.lineStarts, int(offset)+1) - 1
+	return int32(idx + 1), int32(int(offset)-p.lineStarts[idx]) + 1
+}
+
+// location returns the range from the beginning of the first node to the end of the last node.
+func (p *parser) location(first, last *node32) *Location {
+	var loc Location
+	loc.StartLine, loc.StartColumn = p.position(nodeBegin(first))
+	loc.EndLine, loc.EndColumn = p.position(nodeEnd(last))
+	return &loc
+}
+
+// Contains tells whether the given 1-based line and column is inside the location.
+func (l *Location) Contains(line, column int32) bool {
+	if l == nil {
+		return false
+	}
+	if line < l.StartLine || line == l.StartLine && column < l.StartColumn {
+		return false
+	}
+	if line > l.EndLine || line == l.EndLine && column >= l.EndColumn {
+		return false
+	}
+	return true
+}
+
+// Position returns a string in the form of "filename:line:column" pointing to
+// the start of the location. If the location is nil, the filename is returned.
+func Position(filename string, loc *Location) string {
+	if loc == nil {
+		return filename
+	}
+	return fmt.Sprintf("%s:%d:%d", filename, loc.StartLine, loc.StartColumn)
+}
@@ -24,6 +24,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/cloudwego/thriftgo/pkg/vfs"
 )
 
 // NOTSET is a value to express 'not set'.
@@ -35,24 +37,24 @@ type parser struct {
 	IncludeDirs               []string
 	Annotations               *Annotations
 	DefinitionReservedComment string
+	DefinitionLocation        *Location
+	lineStarts                []int
 }
 
-func exists(path string) bool {
-	fi, err := os.Stat(path)
-	if err != nil {
-		return os.IsExist(err)
-	}
-	return !fi.IsDir()
-}
-
-func search(file, dir string, includeDirs []string) (string, error) {
+// SearchPaths returns the paths tried in order when searching an included
+// file from a file in dir.
+func SearchPaths(file, dir string, includeDirs []string) []string {
 	ps := []string{file, filepath.Join(dir, file)}
 	for _, inc := range includeDirs {
 		ps = append(ps, filepath.Join(inc, file))
 	}
-	for _, p := range ps {
-		if exists(p) {
-			return normalizeFilename(p), nil
+	return ps
+}
+
+func search(fsys vfs.FS, file, dir string, includeDirs []string) (string, error) {
+	for _, p := range SearchPaths(file, dir, includeDirs) {
+		if vfs.Exists(fsys, p) {
+			return normalizeFilenameIn(fsys, p), nil
 		}
 	}
 	return file, &os.PathError{Op: "search", Path: file, Err: os.ErrNotExist}
@@ -95,18 +97,23 @@ func parseBatchStringRecursively(path, dir string, includeDirs []string, thriftM
 	}
 
 	t, err := parseString(path, bs, includeDirs)
-	if err != nil {
+	if t == nil {
 		return nil, fmt.Errorf("parse %s err: %w\n", path, err)
 	}
+	errs := DiagnosticsOf(err, "parser")
 	thriftMap[path] = t
 	dir = filepath.Dir(path)
 	for _, inc := range t.Includes {
 		incPath := inc.Path
-		t, err := parseBatchStringRecursively(incPath, dir, includeDirs, thriftMap, IDLFileContentMap)
-		if err != nil {
+		it, err := parseBatchStringRecursively(incPath, dir, includeDirs, thriftMap, IDLFileContentMap)
+		if it == nil {
 			return nil, err
 		}
-		inc.Reference = t
+		inc.Reference = it
+		errs = append(errs, DiagnosticsOf(err, "parser")...)
+	}
+	if len(errs) > 0 {
+		return t, errs
 	}
 	return t, nil
 }
@@ -115,9 +122,7 @@ func parseBatchStringRecursively(path, dir string, includeDirs []string, thriftM
 // If recursive is true, then the include IDLs are parsed recursively as well.
 func ParseFile(path string, includeDirs []string, recursive bool) (*Thrift, error) {
 	if recursive {
-		thriftMap := make(map[string]*Thrift)
-		dir := filepath.Dir(normalizeFilename(path))
-		return parseFileRecursively(path, dir, includeDirs, thriftMap)
+		return ParseFileWithCache(path, includeDirs, make(map[string]*Thrift))
 	}
 	bs, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -126,30 +131,55 @@ func ParseFile(path string, includeDirs []string, recursive bool) (*Thrift, erro
 	return parseString(path, string(bs), includeDirs)
 }
 
-func parseFileRecursively(file, dir string, includeDirs []string, thriftMap map[string]*Thrift) (*Thrift, error) {
-	path, err := search(file, dir, includeDirs)
+// ParseFileWithCache parses a thrift file and its includes recursively like
+// ParseFile. The ASTs in cache are reused and the newly parsed ones are added
+// to it, so that IDLs sharing includes parse each file only once. The files
+// are read and parsed concurrently, while the result stays the same as parsing
+// them one by one.
+func ParseFileWithCache(path string, includeDirs []string, cache map[string]*Thrift) (*Thrift, error) {
+	return ParseFileFS(vfs.OS, path, includeDirs, cache)
+}
+
+// ParseFileFS is like ParseFileWithCache, and reads the files from fsys.
+func ParseFileFS(fsys vfs.FS, path string, includeDirs []string, cache map[string]*Thrift) (*Thrift, error) {
+	dir := filepath.Dir(normalizeFilenameIn(fsys, path))
+	p := newPrefetcher(fsys, includeDirs, cache)
+	p.prefetch(path, dir)
+	return parseFileRecursively(path, dir, includeDirs, cache, p)
+}
+
+// parseFileRecursively links the files parsed by the prefetcher into thriftMap
+// in the depth-first order of includes.
+func parseFileRecursively(file, dir string, includeDirs []string, thriftMap map[string]*Thrift, p *prefetcher) (*Thrift, error) {
+	path, err := search(p.fsys, file, dir, includeDirs)
 	if err != nil {
 		return nil, err
 	}
 	if t, ok := thriftMap[path]; ok {
 		return t, nil
 	}
-	bs, err := ioutil.ReadFile(path)
-	if err != nil {
-		return nil, err
+	f := p.get(path)
+	if f.readErr != nil {
+		return nil, f.readErr
 	}
-	t, err := parseString(path, string(bs), includeDirs)
-	if err != nil {
+	t, err := f.t, f.err
+	if t == nil {
 		return nil, fmt.Errorf("parse %s err: %w", path, err)
 	}
+	// keep going with the partial AST to find syntax errors in includes
+	errs := DiagnosticsOf(err, "parser")
 	thriftMap[path] = t
 	dir = filepath.Dir(path)
 	for _, inc := range t.Includes {
-		t, err := parseFileRecursively(inc.Path, dir, includeDirs, thriftMap)
-		if err != nil {
+		it, err := parseFileRecursively(inc.Path, dir, includeDirs, thriftMap, p)
+		if it == nil {
 			return nil, err
 		}
-		inc.Reference = t
+		inc.Reference = it
+		errs = append(errs, DiagnosticsOf(err, "parser")...)
+	}
+	if len(errs) > 0 {
+		return t, errs
 	}
 	return t, nil
 }
@@ -159,20 +189,18 @@ func ParseString(path, content string) (*Thrift, error) {
 	return parseString(path, content, nil)
 }
 
+// parseString parses the content. When there are syntax errors, a partial AST
+// that contains all well-formed definitions is returned with a Diagnostics
+// error which reports every syntax error.
 func parseString(path, content string, includeDirs []string) (*Thrift, error) {
-	p := &parser{
-		IncludeDirs: includeDirs,
-	}
-	p.Filename = path
-	p.Buffer = content
-	p.Init()
-	if err := p.ThriftIDL.Parse(); err != nil {
+	t, diags, err := parseWithRecovery(path, content, includeDirs)
+	if err != nil {
 		return nil, err
 	}
-	if err := p.parse(); err != nil {
-		return nil, err
+	if len(diags) > 0 {
+		return t, diags
 	}
-	return &p.Thrift, nil
+	return t, nil
 }
 
 func (p *parser) parse() (err error) {
@@ -261,6 +289,7 @@ func (p *parser) parseHeader(node *node32) (err error) {
 }
 
 func (p *parser) parseInclude(node *node32) (err error) {
+	loc := p.location(node, node)
 	node, err = checkrule(node, ruleInclude)
 	if err != nil {
 		return err
@@ -274,7 +303,7 @@ func (p *parser) parseInclude(node *node32) (err error) {
 			return
 		}
 	}
-	p.Includes = append(p.Includes, &Include{Path: filename})
+	p.Includes = append(p.Includes, &Include{Path: filename, Location: loc})
 	return nil
 }
 
@@ -288,7 +317,7 @@ func (p *parser) parseCppInclude(node *node32) (err error) {
 }
 
 func (p *parser) parseNamespace(node *node32) (err error) {
-	ns := Namespace{}
+	ns := Namespace{Location: p.location(node, node)}
 	node, err = checkrule(node, ruleNamespace)
 	if err != nil {
 		return err
@@ -309,6 +338,7 @@ func (p *parser) parseNamespace(node *node32) (err error) {
 }
 
 func (p *parser) parseDefinition(node *node32) (err error) {
+	p.DefinitionLocation = p.location(node, node)
 	node, err = checkrule(node, ruleDefinition)
 	if err != nil {
 		return err
@@ -390,12 +420,14 @@ func (p *parser) parseConst(node *node32) (err error) {
 	}
 	c := &Constant{Name: name, Type: ft, Value: value}
 	c.ReservedComments = p.DefinitionReservedComment
+	c.Location = p.DefinitionLocation
 	p.Constants = append(p.Constants, c)
 	p.Annotations = &c.Annotations
 	return nil
 }
 
 func (p *parser) parseFieldType(node *node32) (typ *Type, err error) {
+	loc := p.location(node, node)
 	node, err = checkrule(node, ruleFieldType)
 	if err != nil {
 		return nil, err
@@ -412,6 +444,7 @@ func (p *parser) parseFieldType(node *node32) (typ *Type, err error) {
 	default:
 		return typ, fmt.Errorf("unknown rule: " + rul3s[node.pegRule])
 	}
+	typ.Location = loc
 	node = node.next
 	if node != nil && node.pegRule == ruleAnnotations {
 		typ.Annotations, err = p.parseAnnotations(node)
@@ -478,6 +511,11 @@ func (p *parser) parseContainerType(node *node32) (typ *Type, err error) {
 }
 
 func (p *parser) parseConstValue(node *node32) (cv *ConstValue, err error) {
+	defer func() {
+		if cv != nil {
+			cv.Location = p.location(node, node)
+		}
+	}()
 	node, err = checkrule(node, ruleConstValue)
 	if err != nil {
 		return nil, err
@@ -553,6 +591,7 @@ func (p *parser) parseTypedef(node *node32) (err error) {
 	node = node.next
 	typd.Alias = p.pegText(node)
 	typd.ReservedComments = p.DefinitionReservedComment
+	typd.Location = p.DefinitionLocation
 	p.Typedefs = append(p.Typedefs, &typd)
 	p.Annotations = &typd.Annotations
 	return nil
@@ -578,6 +617,7 @@ func (p *parser) parseEnum(node *node32) (err error) {
 		}
 		if n.pegRule == ruleIdentifier {
 			var v EnumValue
+			first := n
 			v.ReservedComments = valueComments
 			v.Name = p.pegText(n)
 			if n.next.pegRule == ruleEQUAL {
@@ -598,6 +638,7 @@ func (p *parser) parseEnum(node *node32) (err error) {
 					return err
 				}
 			}
+			v.Location = p.location(first, n)
 			if n.next.pegRule == ruleListSeparator {
 				n = n.next
 			}
@@ -613,6 +654,7 @@ func (p *parser) parseEnum(node *node32) (err error) {
 	}
 	e := &Enum{Name: name, Values: values}
 	e.ReservedComments = p.DefinitionReservedComment
+	e.Location = p.DefinitionLocation
 	p.Enums = append(p.Enums, e)
 	p.Annotations = &e.Annotations
 	return nil
@@ -647,6 +689,7 @@ func (p *parser) parseUnion(node *node32) (err error) {
 	}
 	u := &StructLike{Category: "union", Name: name, Fields: fields}
 	u.ReservedComments = p.DefinitionReservedComment
+	u.Location = p.DefinitionLocation
 	p.Unions = append(p.Unions, u)
 	p.Annotations = &u.Annotations
 	return nil
@@ -681,6 +724,7 @@ func (p *parser) parseStruct(node *node32) (err error) {
 	}
 	s := &StructLike{Category: "struct", Name: name, Fields: fields}
 	s.ReservedComments = p.DefinitionReservedComment
+	s.Location = p.DefinitionLocation
 	p.Structs = append(p.Structs, s)
 	p.Annotations = &s.Annotations
 	return nil
@@ -713,19 +757,19 @@ func (p *parser) parseException(node *node32) (err error) {
 	}
 	e := &StructLike{Category: "exception", Name: name, Fields: fields}
 	e.ReservedComments = p.DefinitionReservedComment
+	e.Location = p.DefinitionLocation
 	p.Exceptions = append(p.Exceptions, e)
 	p.Annotations = &e.Annotations
 	return nil
 }
 
 func (p *parser) parseField(node *node32) (field *Field, err error) {
+	f := &Field{ID: NOTSET, Location: p.location(node, node)}
 	node, err = checkrule(node, ruleField)
 	if err != nil {
 		return nil, err
 	}
 	// ReservedComments Skip FieldId? FieldReq? FieldType Identifier (EQUAL ConstValue)? Annotations? ListSeparator? ReservedEndLineComments
-	var f Field
-	f.ID = NOTSET
 	for ; node != nil; node = node.next {
 		switch node.pegRule {
 		case ruleSkip, ruleSkipLine:
@@ -776,7 +820,7 @@ func (p *parser) parseField(node *node32) (field *Field, err error) {
 			}
 		}
 	}
-	return &f, nil
+	return f, nil
 }
 
 func (p *parser) parseAnnotations(node *node32) ([]*Annotation, error) {
@@ -794,6 +838,9 @@ func (p *parser) parseAnnotations(node *node32) ([]*Annotation, error) {
 				return nil, err
 			}
 			ret.Append(k, v)
+			if a := ret[len(ret)-1]; a.Key == k && a.Location == nil {
+				a.Location = p.location(node, node)
+			}
 		}
 	}
 	return ret, nil
@@ -836,18 +883,19 @@ func (p *parser) parseService(node *node32) (err error) {
 		}
 	}
 	s.ReservedComments = p.DefinitionReservedComment
+	s.Location = p.DefinitionLocation
 	p.Services = append(p.Services, &s)
 	p.Annotations = &s.Annotations
 	return nil
 }
 
 func (p *parser) parseFunction(node *node32) (fu *Function, err error) {
+	f := &Function{Location: p.location(node, node)}
 	node, err = checkrule(node, ruleFunction)
 	if err != nil {
 		return nil, err
 	}
 	// ReservedComments ONEWAY? FunctionType Identifier LPAR Field* RPAR Throws? Annotations? ListSeparator?
-	var f Function
 	for ; node != nil; node = node.next {
 		switch node.pegRule {
 		case ruleReservedComments:
@@ -867,7 +915,7 @@ func (p *parser) parseFunction(node *node32) (fu *Function, err error) {
 				}
 			} else if n.pegRule == ruleVOID {
 				f.Void = true
-				f.FunctionType = &Type{Name: "void"}
+				f.FunctionType = &Type{Name: "void", Location: p.location(n, n)}
 			}
 		case ruleIdentifier:
 			f.Name = p.pegText(node)
@@ -889,7 +937,7 @@ func (p *parser) parseFunction(node *node32) (fu *Function, err error) {
 			}
 		}
 	}
-	return &f, nil
+	return f, nil
 }
 
 func (p *parser) parseThrows(node *node32) (fs []*Field, err error) {
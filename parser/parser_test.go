@@ -15,10 +15,14 @@
 package parser_test
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/cloudwego/thriftgo/parser"
 	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/semantic"
 )
 
 const testAnnotation = `
@@ -425,6 +429,39 @@ func TestEnumValue(t *testing.T) {
 	test.Assert(t, e3.Values[5].Value == 3)
 }
 
+const testUUID = `
+typedef uuid ID
+const uuid U = "{00112233-4455-6677-8899-AABBCCDDEEFF}"
+struct S {
+    1: uuid id
+    2: map<uuid, list<uuid>> m
+    3: i32 uuidCount
+}
+`
+
+func TestUUID(t *testing.T) {
+	ast, err := parser.ParseString("main.thrift", testUUID)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, ast.Typedefs[0].Type.Name == "uuid")
+	test.Assert(t, ast.Constants[0].Type.Name == "uuid")
+	fs := ast.Structs[0].Fields
+	test.Assert(t, fs[0].Type.Name == "uuid")
+	test.Assert(t, fs[1].Type.KeyType.Name == "uuid" && fs[1].Type.ValueType.ValueType.Name == "uuid")
+	test.Assert(t, fs[2].Type.Name == "i32" && fs[2].Name == "uuidCount")
+	test.Assert(t, parser.Typename2TypeID("uuid") == parser.UUID)
+	test.Assert(t, semantic.ResolveSymbols(ast) == nil)
+	test.Assert(t, fs[0].Type.Category == parser.Category_UUID && fs[0].Type.Category.IsBaseType())
+	test.Assert(t, fs[1].Type.KeyType.Category == parser.Category_UUID)
+
+	uuid, err := parser.ParseUUID(ast.Constants[0].Value.TypedValue.GetLiteral())
+	test.Assert(t, err == nil, err)
+	test.Assert(t, uuid == [16]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}, uuid)
+	for _, s := range []string{"", "00112233445566778899aabbccddeeff", "00112233-4455-6677-8899-aabbccddeefg", "{00112233-4455-6677-8899-aabbccddeeff"} {
+		_, err = parser.ParseUUID(s)
+		test.Assert(t, err != nil, s)
+	}
+}
+
 const testNamespace = `
 namespace * whatever
 namespace go golang
@@ -442,3 +479,163 @@ func TestNamespace(t *testing.T) {
 	test.Assert(t, ast.Namespaces[2].Language == "py")
 	test.Assert(t, ast.Namespaces[2].Name == "python.org")
 }
+
+const testLocation = `include "base.thrift"
+namespace go example
+
+// A doc comment is not a part of the definition.
+struct S {
+    1: required i32 a = 1 (k = "v"), // tail
+    2: list<string> b;
+} (x = "y")
+
+enum E {
+  A = 1,
+  B (z = "1")
+}
+
+service Svc {
+  void f(1: S s) throws (1: S e)
+}
+`
+
+func TestLocation(t *testing.T) {
+	ast, err := parser.ParseString("main.thrift", testLocation)
+	test.Assert(t, err == nil, err)
+
+	at := func(loc *parser.Location, sl, sc, el, ec int32) bool {
+		return loc != nil && loc.StartLine == sl && loc.StartColumn == sc && loc.EndLine == el && loc.EndColumn == ec
+	}
+	test.Assert(t, at(ast.Includes[0].Location, 1, 1, 1, 22), ast.Includes[0].Location)
+	test.Assert(t, at(ast.Namespaces[0].Location, 2, 1, 2, 21), ast.Namespaces[0].Location)
+
+	s := ast.Structs[0]
+	test.Assert(t, at(s.Location, 5, 1, 8, 12), s.Location)
+	test.Assert(t, at(s.Fields[0].Location, 6, 5, 6, 36), s.Fields[0].Location)
+	test.Assert(t, at(s.Fields[0].Type.Location, 6, 17, 6, 20), s.Fields[0].Type.Location)
+	test.Assert(t, at(s.Fields[0].Default.Location, 6, 25, 6, 26), s.Fields[0].Default.Location)
+	test.Assert(t, at(s.Fields[0].Annotations[0].Location, 6, 28, 6, 35), s.Fields[0].Annotations[0].Location)
+	test.Assert(t, at(s.Fields[1].Location, 7, 5, 7, 22), s.Fields[1].Location)
+	test.Assert(t, at(s.Annotations[0].Location, 8, 4, 8, 11), s.Annotations[0].Location)
+
+	e := ast.Enums[0]
+	test.Assert(t, at(e.Location, 10, 1, 13, 2), e.Location)
+	test.Assert(t, at(e.Values[0].Location, 11, 3, 11, 8), e.Values[0].Location)
+	test.Assert(t, at(e.Values[1].Location, 12, 3, 12, 14), e.Values[1].Location)
+
+	fn := ast.Services[0].Functions[0]
+	test.Assert(t, at(ast.Services[0].Location, 15, 1, 17, 2), ast.Services[0].Location)
+	test.Assert(t, at(fn.Location, 16, 3, 16, 33), fn.Location)
+	test.Assert(t, at(fn.Throws[0].Location, 16, 26, 16, 32), fn.Throws[0].Location)
+
+	test.Assert(t, fn.Location.Contains(16, 3))
+	test.Assert(t, !fn.Location.Contains(16, 33))
+	test.Assert(t, parser.Position("main.thrift", fn.Location) == "main.thrift:16:3")
+	test.Assert(t, parser.Position("main.thrift", nil) == "main.thrift")
+}
+
+func TestSyntaxError(t *testing.T) {
+	_, err := parser.ParseString("main.thrift", "struct A {\n  1: i32 a\n  2: xx yy zz\n}\n")
+	test.Assert(t, err != nil)
+	ds := parser.DiagnosticsOf(err, "")
+	test.Assert(t, len(ds) == 1)
+	test.Assert(t, ds[0].Code == parser.CodeSyntaxError && ds[0].Severity == parser.Severity_Error)
+	test.Assert(t, ds[0].Location.StartLine == 4 && ds[0].Location.StartColumn == 1, ds[0].Location)
+	test.Assert(t, err.Error() == `main.thrift:4:1: parse error: expected identifier or "(", found "}"`, err)
+
+	_, err = parser.ParseString("main.thrift", "struct A { 1: i32 a; } xx")
+	test.Assert(t, err != nil && err.Error() == `main.thrift:1:24: parse error: expected "(" or definition, found "xx"`, err)
+
+	_, err = parser.ParseString("main.thrift", "enum E {\n  A = \n}\n")
+	test.Assert(t, err != nil && err.Error() == `main.thrift:3:1: parse error: expected integer, found "}"`, err)
+}
+
+const testRecovery = `namespace go example
+
+// A is broken.
+struct A {
+  1: i32 a
+  2: map<i32 b
+}
+
+struct B {
+  1: i32 b
+}
+
+enum E { X = , Y }
+
+service S {
+  void f(1: i32 a
+}
+
+typedef i32 T
+`
+
+func TestRecovery(t *testing.T) {
+	ast, err := parser.ParseString("main.thrift", testRecovery)
+	test.Assert(t, ast != nil)
+	ds := parser.DiagnosticsOf(err, "")
+	test.Assert(t, len(ds) == 3, ds)
+	test.Assert(t, ds[0].Location.StartLine == 6, ds[0])
+	test.Assert(t, ds[1].Location.StartLine == 13, ds[1])
+	test.Assert(t, ds[2].Location.StartLine == 17, ds[2])
+
+	// well-formed parts are kept and positions are not affected
+	test.Assert(t, len(ast.Namespaces) == 1)
+	test.Assert(t, len(ast.Structs) == 1 && ast.Structs[0].Name == "B")
+	test.Assert(t, ast.Structs[0].ReservedComments == "")
+	test.Assert(t, ast.Structs[0].Location.StartLine == 9)
+	test.Assert(t, len(ast.Enums) == 0 && len(ast.Services) == 0)
+	test.Assert(t, len(ast.Typedefs) == 1 && ast.Typedefs[0].Location.StartLine == 19)
+}
+
+func TestParseFileWithCache(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"base.thrift": "struct Base {}\n",
+		"a.thrift":    "include \"base.thrift\"\n",
+		"b.thrift":    "include \"base.thrift\"\n",
+	} {
+		test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+	}
+	cache := make(map[string]*parser.Thrift)
+	a, err := parser.ParseFileWithCache(filepath.Join(dir, "a.thrift"), nil, cache)
+	test.Assert(t, err == nil, err)
+	b, err := parser.ParseFileWithCache(filepath.Join(dir, "b.thrift"), nil, cache)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(cache) == 3, cache)
+	test.Assert(t, a.Includes[0].Reference == b.Includes[0].Reference)
+}
+
+func TestParseIncludeGraph(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"main.thrift": "include \"a.thrift\"\ninclude \"b.thrift\"\n",
+		"a.thrift":    "include \"c.thrift\"\nstruct A { xx }\n",
+		"b.thrift":    "include \"c.thrift\"\nstruct B { xx }\n",
+		"c.thrift":    "struct C { xx }\n",
+	}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("d%d.thrift", i)
+		files["main.thrift"] += fmt.Sprintf("include %q\n", name)
+		files[name] = "include \"c.thrift\"\n"
+	}
+	for name, content := range files {
+		test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+	}
+	for i := 0; i < 10; i++ {
+		ast, err := parser.ParseFile(filepath.Join(dir, "main.thrift"), nil, true)
+		test.Assert(t, ast != nil, err)
+		// diagnostics are reported in the same order as a serial parse
+		ds := parser.DiagnosticsOf(err, "")
+		test.Assert(t, len(ds) == 3, ds)
+		for j, name := range []string{"a.thrift", "c.thrift", "b.thrift"} {
+			test.Assert(t, filepath.Base(ds[j].Filename) == name, ds)
+		}
+		// shared includes are parsed once
+		c := ast.Includes[0].Reference.Includes[0].Reference
+		for _, inc := range ast.Includes[1:] {
+			test.Assert(t, inc.Reference.Includes[0].Reference == c)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+)
+
+// parsed is the result of reading and parsing a file.
+type parsed struct {
+	t       *Thrift
+	err     error
+	readErr error
+}
+
+// prefetcher reads and parses the files of an include graph concurrently.
+// Includes are linked afterwards by a serial walk over the parsed files, so
+// that the ASTs and the order of diagnostics are the same as a serial parse.
+type prefetcher struct {
+	fsys        vfs.FS
+	includeDirs []string
+	known       map[string]*Thrift // not written while prefetching
+	sem         chan struct{}
+	wg          sync.WaitGroup
+
+	mu    sync.Mutex
+	files map[string]*parsed
+}
+
+func newPrefetcher(fsys vfs.FS, includeDirs []string, known map[string]*Thrift) *prefetcher {
+	return &prefetcher{
+		fsys:        fsys,
+		includeDirs: includeDirs,
+		known:       known,
+		sem:         make(chan struct{}, runtime.GOMAXPROCS(0)),
+		files:       make(map[string]*parsed),
+	}
+}
+
+// prefetch parses the file and everything it includes, transitively, and
+// waits until all of them are parsed. Files that can not be found are left
+// for the serial walk to report.
+func (p *prefetcher) prefetch(file, dir string) {
+	p.fetch(file, dir)
+	p.wg.Wait()
+}
+
+func (p *prefetcher) fetch(file, dir string) {
+	path, err := search(p.fsys, file, dir, p.includeDirs)
+	if err != nil {
+		return
+	}
+	if _, ok := p.known[path]; ok {
+		return
+	}
+	p.mu.Lock()
+	if _, ok := p.files[path]; ok {
+		p.mu.Unlock()
+		return
+	}
+	f := new(parsed)
+	p.files[path] = f
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.sem <- struct{}{}
+		*f = p.parseFile(path)
+		<-p.sem
+		if f.t != nil {
+			dir := filepath.Dir(path)
+			for _, inc := range f.t.Includes {
+				p.fetch(inc.Path, dir)
+			}
+		}
+	}()
+}
+
+// get returns the parsed file, parsing it now if it was not prefetched.
+func (p *prefetcher) get(path string) parsed {
+	p.mu.Lock()
+	f, ok := p.files[path]
+	p.mu.Unlock()
+	if ok {
+		return *f
+	}
+	return p.parseFile(path)
+}
+
+func (p *prefetcher) parseFile(path string) (res parsed) {
+	bs, err := p.fsys.ReadFile(path)
+	if err != nil {
+		res.readErr = err
+		return
+	}
+	res.t, res.err = parseString(path, string(bs), p.includeDirs)
+	return
+}
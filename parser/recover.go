@@ -0,0 +1,215 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// syncPoint matches the keywords that start a header or a definition at the
+// beginning of a line. The parser resynchronizes at these points after a
+// syntax error.
+var syncPoint = regexp.MustCompile(
+	`(?m)^[ \t]*(include|cpp_include|namespace|const|typedef|enum|struct|union|exception|service)\b`)
+
+// candidate is a token tried at the position of a syntax error to find out
+// what the parser expects there.
+type candidate struct {
+	desc string
+	text string
+}
+
+var candidates = []candidate{
+	{"identifier", "_"},
+	{"integer", "0"},
+	{"string literal", `""`},
+	{`"{"`, "{"},
+	{`"}"`, "}"},
+	{`"("`, "("},
+	{`")"`, ")"},
+	{`"<"`, "<"},
+	{`">"`, ">"},
+	{`"["`, "["},
+	{`"]"`, "]"},
+	{`"="`, "="},
+	{`":"`, ":"},
+	{`","`, ","},
+	{"definition", "struct _ {}"},
+}
+
+// parseWithRecovery parses the content. When a syntax error occurs, the
+// definition containing the error is discarded and the parser resynchronizes
+// at the next header or definition. It returns a partial AST along with a
+// diagnostic for each syntax error found.
+func parseWithRecovery(path, content string, includeDirs []string) (*Thrift, Diagnostics, error) {
+	var diags Diagnostics
+	buf := []rune(content)
+	for {
+		p := &parser{IncludeDirs: includeDirs}
+		p.Filename = path
+		p.Buffer = string(buf)
+		p.Init()
+		err := p.ThriftIDL.Parse()
+		if err == nil {
+			if err := p.parse(); err != nil {
+				return nil, diags, err
+			}
+			return &p.Thrift, diags, nil
+		}
+		var pe *parseError
+		if !errors.As(err, &pe) {
+			return nil, diags, err
+		}
+		pos := errorPosition(&p.ThriftIDL, pe)
+		begin, end := syncRange(buf, pos)
+		diags = append(diags, p.syntaxDiagnostic(pos, buf[begin:end], pos-begin))
+		if !blank(buf[begin:end]) {
+			// no progress can be made
+			return nil, diags, nil
+		}
+	}
+}
+
+// errorPosition returns the offset of the first rune that the parser fails to consume.
+func errorPosition(idl *ThriftIDL, pe *parseError) int {
+	pos := int(pe.max.end)
+	for pos < len(idl.buffer) && unicode.IsSpace(idl.buffer[pos]) {
+		pos++
+	}
+	if pos >= len(idl.buffer) || idl.buffer[pos] == endSymbol {
+		pos = len(idl.buffer) - 1
+	}
+	return pos
+}
+
+// syncRange finds the range of the header or definition that contains pos.
+// The range begins at the last sync point before pos (including the comments
+// right above it) and ends at the first sync point after pos.
+func syncRange(buf []rune, pos int) (begin, end int) {
+	text := string(buf)
+	// offsets from the regexp are in bytes
+	toRune := func(byteOffset int) int { return len([]rune(text[:byteOffset])) }
+	end = len(buf)
+	for _, m := range syncPoint.FindAllStringIndex(text, -1) {
+		offset := toRune(m[0])
+		if offset <= pos {
+			begin = offset
+		} else if offset > pos {
+			end = offset
+			break
+		}
+	}
+	// include leading comments
+	for begin > 0 {
+		lineStart := begin - 1
+		for lineStart > 0 && buf[lineStart-1] != '\n' {
+			lineStart--
+		}
+		line := strings.TrimSpace(string(buf[lineStart : begin-1]))
+		if !strings.HasPrefix(line, "//") && !strings.HasPrefix(line, "#") &&
+			!strings.HasPrefix(line, "/*") && !strings.HasPrefix(line, "*") {
+			break
+		}
+		begin = lineStart
+	}
+	return begin, end
+}
+
+// blank replaces all runes with spaces except line breaks so that positions
+// of the rest content are kept. It reports whether anything has been replaced.
+func blank(rs []rune) (changed bool) {
+	for i, r := range rs {
+		if r != '\n' && r != '\r' && r != ' ' {
+			rs[i] = ' '
+			changed = true
+		}
+	}
+	return
+}
+
+// syntaxDiagnostic builds a diagnostic for the syntax error at pos. The chunk is the
+// header or definition containing the error and offset is the position of the error in it.
+func (p *parser) syntaxDiagnostic(pos int, chunk []rune, offset int) *Diagnostic {
+	found := foundToken(p.buffer, pos)
+	var loc Location
+	loc.StartLine, loc.StartColumn = p.position(uint32(pos))
+	loc.EndLine, loc.EndColumn = p.position(uint32(pos + len([]rune(found))))
+	if found == "" {
+		found = "end of file"
+	} else {
+		found = strconv.Quote(found)
+	}
+	var msg string
+	if expected := expectedTokens(chunk, offset); len(expected) > 0 {
+		msg = "parse error: expected " + strings.Join(expected, " or ") + ", found " + found
+	} else {
+		msg = "parse error: unexpected " + found
+	}
+	return &Diagnostic{
+		Severity: Severity_Error,
+		Code:     CodeSyntaxError,
+		Message:  msg,
+		Source:   "parser",
+		Filename: p.Filename,
+		Location: &loc,
+	}
+}
+
+// foundToken returns the token at pos or an empty string at the end of the buffer.
+func foundToken(buf []rune, pos int) string {
+	if pos >= len(buf) || buf[pos] == endSymbol {
+		return ""
+	}
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+	}
+	end := pos + 1
+	switch r := buf[pos]; {
+	case isWord(r):
+		for end < len(buf) && isWord(buf[end]) {
+			end++
+		}
+	case r == '"' || r == '\'':
+		for end < len(buf) && buf[end] != r && buf[end] != '\n' {
+			end++
+		}
+		if end < len(buf) && buf[end] == r {
+			end++
+		}
+	}
+	return string(buf[pos:end])
+}
+
+// expectedTokens inserts each candidate at the offset of the chunk and reports
+// those which let the parser go further than the offset.
+func expectedTokens(chunk []rune, offset int) (res []string) {
+	for _, c := range candidates {
+		text := string(chunk[:offset]) + " " + c.text + " " + string(chunk[offset:])
+		reach := offset + len([]rune(c.text)) + 1
+		var idl ThriftIDL
+		idl.Buffer = text
+		idl.Init()
+		err := idl.Parse()
+		var pe *parseError
+		if err == nil || errors.As(err, &pe) && errorPosition(&idl, pe) > reach {
+			res = append(res, c.desc)
+		}
+	}
+	return
+}
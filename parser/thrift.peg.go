@@ -80,6 +80,7 @@ const (
 	ruleDOUBLE
 	ruleSTRING
 	ruleBINARY
+	ruleUUID
 	ruleCONST
 	ruleONEWAY
 	ruleTYPEDEF
@@ -176,6 +177,7 @@ var rul3s = [...]string{
 	"DOUBLE",
 	"STRING",
 	"BINARY",
+	"UUID",
 	"CONST",
 	"ONEWAY",
 	"TYPEDEF",
@@ -320,7 +322,7 @@ func (t *tokens32) Tokens() []token32 {
 type ThriftIDL struct {
 	Buffer string
 	buffer []rune
-	rules  [93]func() bool
+	rules  [94]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -1443,7 +1445,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position115, tokenIndex115
 			return false
 		},
-		/* 21 BaseType <- <(BOOL / BYTE / I8 / I16 / I32 / I64 / DOUBLE / STRING / BINARY)> */
+		/* 21 BaseType <- <(BOOL / BYTE / I8 / I16 / I32 / I64 / DOUBLE / STRING / BINARY / UUID)> */
 		func() bool {
 			position122, tokenIndex122 := position, tokenIndex
 			{
@@ -1499,6 +1501,12 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 				l132:
 					position, tokenIndex = position124, tokenIndex124
 					if !_rules[ruleBINARY]() {
+						goto l527
+					}
+					goto l124
+				l527:
+					position, tokenIndex = position124, tokenIndex124
+					if !_rules[ruleUUID]() {
 						goto l122
 					}
 				}
@@ -3376,7 +3384,61 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position387, tokenIndex387
 			return false
 		},
-		/* 62 CONST <- <(Skip ('c' 'o' 'n' 's' 't') !LetterOrDigit Indent*)> */
+		/* 62 UUID <- <(Skip <('u' 'u' 'i' 'd')> !LetterOrDigit Indent*)> */
+		func() bool {
+			position528, tokenIndex528 := position, tokenIndex
+			{
+				position529 := position
+				if !_rules[ruleSkip]() {
+					goto l528
+				}
+				{
+					position530 := position
+					if buffer[position] != rune('u') {
+						goto l528
+					}
+					position++
+					if buffer[position] != rune('u') {
+						goto l528
+					}
+					position++
+					if buffer[position] != rune('i') {
+						goto l528
+					}
+					position++
+					if buffer[position] != rune('d') {
+						goto l528
+					}
+					position++
+					add(rulePegText, position530)
+				}
+				{
+					position531, tokenIndex531 := position, tokenIndex
+					if !_rules[ruleLetterOrDigit]() {
+						goto l531
+					}
+					goto l528
+				l531:
+					position, tokenIndex = position531, tokenIndex531
+				}
+			l532:
+				{
+					position533, tokenIndex533 := position, tokenIndex
+					if !_rules[ruleIndent]() {
+						goto l533
+					}
+					goto l532
+				l533:
+					position, tokenIndex = position533, tokenIndex533
+				}
+				add(ruleUUID, position529)
+			}
+			return true
+		l528:
+			position, tokenIndex = position528, tokenIndex528
+			return false
+		},
+		/* 63 CONST <- <(Skip ('c' 'o' 'n' 's' 't') !LetterOrDigit Indent*)> */
 		func() bool {
 			position393, tokenIndex393 := position, tokenIndex
 			{
@@ -3430,7 +3492,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position393, tokenIndex393
 			return false
 		},
-		/* 63 ONEWAY <- <(Skip ('o' 'n' 'e' 'w' 'a' 'y') !LetterOrDigit Indent*)> */
+		/* 64 ONEWAY <- <(Skip ('o' 'n' 'e' 'w' 'a' 'y') !LetterOrDigit Indent*)> */
 		func() bool {
 			position398, tokenIndex398 := position, tokenIndex
 			{
@@ -3488,7 +3550,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position398, tokenIndex398
 			return false
 		},
-		/* 64 TYPEDEF <- <(Skip ('t' 'y' 'p' 'e' 'd' 'e' 'f') !LetterOrDigit Indent*)> */
+		/* 65 TYPEDEF <- <(Skip ('t' 'y' 'p' 'e' 'd' 'e' 'f') !LetterOrDigit Indent*)> */
 		func() bool {
 			position403, tokenIndex403 := position, tokenIndex
 			{
@@ -3550,7 +3612,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position403, tokenIndex403
 			return false
 		},
-		/* 65 MAP <- <(Skip ('m' 'a' 'p') !LetterOrDigit Indent*)> */
+		/* 66 MAP <- <(Skip ('m' 'a' 'p') !LetterOrDigit Indent*)> */
 		func() bool {
 			position408, tokenIndex408 := position, tokenIndex
 			{
@@ -3596,7 +3658,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position408, tokenIndex408
 			return false
 		},
-		/* 66 SET <- <(Skip ('s' 'e' 't') !LetterOrDigit Indent*)> */
+		/* 67 SET <- <(Skip ('s' 'e' 't') !LetterOrDigit Indent*)> */
 		func() bool {
 			position413, tokenIndex413 := position, tokenIndex
 			{
@@ -3642,7 +3704,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position413, tokenIndex413
 			return false
 		},
-		/* 67 LIST <- <(Skip ('l' 'i' 's' 't') !LetterOrDigit Indent*)> */
+		/* 68 LIST <- <(Skip ('l' 'i' 's' 't') !LetterOrDigit Indent*)> */
 		func() bool {
 			position418, tokenIndex418 := position, tokenIndex
 			{
@@ -3692,7 +3754,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position418, tokenIndex418
 			return false
 		},
-		/* 68 VOID <- <(Skip ('v' 'o' 'i' 'd') !LetterOrDigit Indent*)> */
+		/* 69 VOID <- <(Skip ('v' 'o' 'i' 'd') !LetterOrDigit Indent*)> */
 		func() bool {
 			position423, tokenIndex423 := position, tokenIndex
 			{
@@ -3742,7 +3804,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position423, tokenIndex423
 			return false
 		},
-		/* 69 THROWS <- <(Skip ('t' 'h' 'r' 'o' 'w' 's') !LetterOrDigit Indent*)> */
+		/* 70 THROWS <- <(Skip ('t' 'h' 'r' 'o' 'w' 's') !LetterOrDigit Indent*)> */
 		func() bool {
 			position428, tokenIndex428 := position, tokenIndex
 			{
@@ -3800,7 +3862,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position428, tokenIndex428
 			return false
 		},
-		/* 70 EXCEPTION <- <(Skip ('e' 'x' 'c' 'e' 'p' 't' 'i' 'o' 'n') !LetterOrDigit Indent*)> */
+		/* 71 EXCEPTION <- <(Skip ('e' 'x' 'c' 'e' 'p' 't' 'i' 'o' 'n') !LetterOrDigit Indent*)> */
 		func() bool {
 			position433, tokenIndex433 := position, tokenIndex
 			{
@@ -3870,7 +3932,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position433, tokenIndex433
 			return false
 		},
-		/* 71 EXTENDS <- <(Skip ('e' 'x' 't' 'e' 'n' 'd' 's') !LetterOrDigit Indent*)> */
+		/* 72 EXTENDS <- <(Skip ('e' 'x' 't' 'e' 'n' 'd' 's') !LetterOrDigit Indent*)> */
 		func() bool {
 			position438, tokenIndex438 := position, tokenIndex
 			{
@@ -3932,7 +3994,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position438, tokenIndex438
 			return false
 		},
-		/* 72 SERVICE <- <(Skip ('s' 'e' 'r' 'v' 'i' 'c' 'e') !LetterOrDigit Indent*)> */
+		/* 73 SERVICE <- <(Skip ('s' 'e' 'r' 'v' 'i' 'c' 'e') !LetterOrDigit Indent*)> */
 		func() bool {
 			position443, tokenIndex443 := position, tokenIndex
 			{
@@ -3994,7 +4056,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position443, tokenIndex443
 			return false
 		},
-		/* 73 STRUCT <- <(Skip ('s' 't' 'r' 'u' 'c' 't') !LetterOrDigit Indent*)> */
+		/* 74 STRUCT <- <(Skip ('s' 't' 'r' 'u' 'c' 't') !LetterOrDigit Indent*)> */
 		func() bool {
 			position448, tokenIndex448 := position, tokenIndex
 			{
@@ -4052,7 +4114,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position448, tokenIndex448
 			return false
 		},
-		/* 74 UNION <- <(Skip ('u' 'n' 'i' 'o' 'n') !LetterOrDigit Indent*)> */
+		/* 75 UNION <- <(Skip ('u' 'n' 'i' 'o' 'n') !LetterOrDigit Indent*)> */
 		func() bool {
 			position453, tokenIndex453 := position, tokenIndex
 			{
@@ -4106,7 +4168,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position453, tokenIndex453
 			return false
 		},
-		/* 75 ENUM <- <(Skip ('e' 'n' 'u' 'm') !LetterOrDigit Indent*)> */
+		/* 76 ENUM <- <(Skip ('e' 'n' 'u' 'm') !LetterOrDigit Indent*)> */
 		func() bool {
 			position458, tokenIndex458 := position, tokenIndex
 			{
@@ -4156,7 +4218,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position458, tokenIndex458
 			return false
 		},
-		/* 76 INCLUDE <- <(Skip ('i' 'n' 'c' 'l' 'u' 'd' 'e') !LetterOrDigit Indent*)> */
+		/* 77 INCLUDE <- <(Skip ('i' 'n' 'c' 'l' 'u' 'd' 'e') !LetterOrDigit Indent*)> */
 		func() bool {
 			position463, tokenIndex463 := position, tokenIndex
 			{
@@ -4218,7 +4280,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position463, tokenIndex463
 			return false
 		},
-		/* 77 CPPINCLUDE <- <(Skip ('c' 'p' 'p' '_' 'i' 'n' 'c' 'l' 'u' 'd' 'e') !LetterOrDigit Indent*)> */
+		/* 78 CPPINCLUDE <- <(Skip ('c' 'p' 'p' '_' 'i' 'n' 'c' 'l' 'u' 'd' 'e') !LetterOrDigit Indent*)> */
 		func() bool {
 			position468, tokenIndex468 := position, tokenIndex
 			{
@@ -4296,7 +4358,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position468, tokenIndex468
 			return false
 		},
-		/* 78 NAMESPACE <- <(Skip ('n' 'a' 'm' 'e' 's' 'p' 'a' 'c' 'e') !LetterOrDigit Indent*)> */
+		/* 79 NAMESPACE <- <(Skip ('n' 'a' 'm' 'e' 's' 'p' 'a' 'c' 'e') !LetterOrDigit Indent*)> */
 		func() bool {
 			position473, tokenIndex473 := position, tokenIndex
 			{
@@ -4366,7 +4428,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position473, tokenIndex473
 			return false
 		},
-		/* 79 CPPTYPE <- <(Skip ('c' 'p' 'p' '_' 't' 'y' 'p' 'e') !LetterOrDigit Indent*)> */
+		/* 80 CPPTYPE <- <(Skip ('c' 'p' 'p' '_' 't' 'y' 'p' 'e') !LetterOrDigit Indent*)> */
 		func() bool {
 			position478, tokenIndex478 := position, tokenIndex
 			{
@@ -4432,7 +4494,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position478, tokenIndex478
 			return false
 		},
-		/* 80 LBRK <- <(Skip '[' Indent*)> */
+		/* 81 LBRK <- <(Skip '[' Indent*)> */
 		func() bool {
 			position483, tokenIndex483 := position, tokenIndex
 			{
@@ -4461,7 +4523,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position483, tokenIndex483
 			return false
 		},
-		/* 81 RBRK <- <(Skip ']' Indent*)> */
+		/* 82 RBRK <- <(Skip ']' Indent*)> */
 		func() bool {
 			position487, tokenIndex487 := position, tokenIndex
 			{
@@ -4490,7 +4552,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position487, tokenIndex487
 			return false
 		},
-		/* 82 LWING <- <(Skip '{' Indent*)> */
+		/* 83 LWING <- <(Skip '{' Indent*)> */
 		func() bool {
 			position491, tokenIndex491 := position, tokenIndex
 			{
@@ -4519,7 +4581,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position491, tokenIndex491
 			return false
 		},
-		/* 83 RWING <- <(Skip '}' Indent*)> */
+		/* 84 RWING <- <(Skip '}' Indent*)> */
 		func() bool {
 			position495, tokenIndex495 := position, tokenIndex
 			{
@@ -4548,7 +4610,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position495, tokenIndex495
 			return false
 		},
-		/* 84 EQUAL <- <(Skip '=' Indent*)> */
+		/* 85 EQUAL <- <(Skip '=' Indent*)> */
 		func() bool {
 			position499, tokenIndex499 := position, tokenIndex
 			{
@@ -4577,7 +4639,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position499, tokenIndex499
 			return false
 		},
-		/* 85 LPOINT <- <(Skip '<' Indent*)> */
+		/* 86 LPOINT <- <(Skip '<' Indent*)> */
 		func() bool {
 			position503, tokenIndex503 := position, tokenIndex
 			{
@@ -4606,7 +4668,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position503, tokenIndex503
 			return false
 		},
-		/* 86 RPOINT <- <(Skip '>' Indent*)> */
+		/* 87 RPOINT <- <(Skip '>' Indent*)> */
 		func() bool {
 			position507, tokenIndex507 := position, tokenIndex
 			{
@@ -4635,7 +4697,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position507, tokenIndex507
 			return false
 		},
-		/* 87 COMMA <- <(Skip ',' Indent*)> */
+		/* 88 COMMA <- <(Skip ',' Indent*)> */
 		func() bool {
 			position511, tokenIndex511 := position, tokenIndex
 			{
@@ -4664,7 +4726,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position511, tokenIndex511
 			return false
 		},
-		/* 88 LPAR <- <(Skip '(' Indent*)> */
+		/* 89 LPAR <- <(Skip '(' Indent*)> */
 		func() bool {
 			position515, tokenIndex515 := position, tokenIndex
 			{
@@ -4693,7 +4755,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position515, tokenIndex515
 			return false
 		},
-		/* 89 RPAR <- <(Skip ')' Indent*)> */
+		/* 90 RPAR <- <(Skip ')' Indent*)> */
 		func() bool {
 			position519, tokenIndex519 := position, tokenIndex
 			{
@@ -4722,7 +4784,7 @@ func (p *ThriftIDL) Init(options ...func(*ThriftIDL) error) error {
 			position, tokenIndex = position519, tokenIndex519
 			return false
 		},
-		/* 90 COLON <- <(Skip ':' Indent*)> */
+		/* 91 COLON <- <(Skip ':' Indent*)> */
 		func() bool {
 			position523, tokenIndex523 := position, tokenIndex
 			{
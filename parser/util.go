@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/cloudwego/thriftgo/pkg/vfs"
 	"github.com/cloudwego/thriftgo/utils/dir_utils"
 )
 
@@ -38,6 +39,25 @@ func normalizeFilename(fn string) string {
 	return ref
 }
 
+// normalizeFilenameIn returns the name of a file in fsys relative to the
+// working directory, which is the directory of a vfs.Dir.
+func normalizeFilenameIn(fsys vfs.FS, fn string) string {
+	switch d := fsys.(type) {
+	case vfs.Dir:
+		if filepath.IsAbs(fn) {
+			if rel, err := filepath.Rel(string(d), fn); err == nil {
+				return rel
+			}
+		}
+		return filepath.Clean(fn)
+	}
+	if fsys != vfs.FS(vfs.OS) {
+		// the working directory is irrelevant to other file systems
+		return filepath.Clean(fn)
+	}
+	return normalizeFilename(fn)
+}
+
 func refName(filename string) string {
 	n := strings.Split(filepath.Base(filename), ".")
 	return strings.Join(n[:len(n)-1], ".")
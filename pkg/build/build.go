@@ -0,0 +1,130 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package build generates codes for a project described by a thriftgo.yaml.
+package build
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	targs "github.com/cloudwego/thriftgo/args"
+	"github.com/cloudwego/thriftgo/sdk"
+)
+
+// Run parses the arguments of the build subcommand and generates codes for
+// the project.
+func Run(args []string) error {
+	var configFile string
+	var watch bool
+	f := flag.NewFlagSet("thriftgo build", flag.ContinueOnError)
+	f.StringVar(&configFile, "c", "", "")
+	f.StringVar(&configFile, "config", "", "")
+	f.BoolVar(&watch, "w", false, "")
+	f.BoolVar(&watch, "watch", false, "")
+	f.Usage = func() {
+		println(`Usage: thriftgo build [options]
+Generate codes for all IDLs and targets declared in the project configuration.
+Options:
+  -c, --config file   The YAML configuration of the project.
+                      Default: ` + strings.Join(DefaultConfigFiles, " or ") + ` in the working directory.
+  -w, --watch         Generate codes again for the IDLs affected by changes until interrupted.`)
+	}
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	if configFile == "" {
+		for _, name := range DefaultConfigFiles {
+			if _, err := os.Stat(name); err == nil {
+				configFile = name
+				break
+			}
+		}
+		if configFile == "" {
+			return fmt.Errorf("build: no configuration found, expecting %s", strings.Join(DefaultConfigFiles, " or "))
+		}
+	}
+	cfg, err := LoadConfig(configFile)
+	if err != nil {
+		return err
+	}
+	if watch {
+		args, err := cfg.Arguments()
+		if err != nil {
+			return err
+		}
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return sdk.Watch(ctx, args, nil)
+	}
+	return Build(cfg)
+}
+
+// Build generates codes for every IDL and target in the configuration. The
+// IDLs share one parse of their common includes.
+func Build(cfg *Config) error {
+	args, err := cfg.Arguments()
+	if err != nil {
+		return err
+	}
+	s := sdk.NewSession()
+	defer s.Close()
+	for _, a := range args {
+		if err := s.Invoke(a, nil); err != nil {
+			return fmt.Errorf("build: %s: %w", a.IDL, err)
+		}
+	}
+	return nil
+}
+
+// Arguments returns the arguments of thriftgo for each IDL and target.
+func (cfg *Config) Arguments() ([]*targs.Arguments, error) {
+	files, err := cfg.Files()
+	if err != nil {
+		return nil, err
+	}
+	var res []*targs.Arguments
+	for _, file := range files {
+		for _, t := range cfg.Targets {
+			a := new(targs.Arguments)
+			if err := a.Parse(cfg.argv(t, file)); err != nil {
+				return nil, fmt.Errorf("build: target %s: %w", t.Gen, err)
+			}
+			res = append(res, a)
+		}
+	}
+	return res, nil
+}
+
+// argv returns the command line of thriftgo that generates codes for the
+// target of the IDL.
+func (cfg *Config) argv(t *Target, idl string) []string {
+	argv := append([]string{"thriftgo"}, cfg.Flags...)
+	if cfg.Recurse {
+		argv = append(argv, "-r")
+	}
+	for _, inc := range cfg.Includes {
+		argv = append(argv, "-i", inc)
+	}
+	argv = append(argv, "-g", t.Gen, "-o", t.Out)
+	for _, p := range t.Plugins {
+		argv = append(argv, "-p", p.String())
+	}
+	return append(argv, idl)
+}
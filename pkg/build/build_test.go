@@ -0,0 +1,100 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+const testConfig = `idls:
+  - idl/*.thrift
+includes:
+  - common
+flags: [-q]
+targets:
+  - gen: go:package_prefix=example.com/gen
+    out: out/go
+  - gen: fastgo:package_prefix=example.com/fast
+    plugins:
+      - name: dummy
+        path: bin/dummy
+        options: [a=1, b]
+      - other=/bin/other:c=2
+`
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		test.Assert(t, os.MkdirAll(filepath.Dir(path), 0o755) == nil)
+		test.Assert(t, os.WriteFile(path, []byte(content), 0o644) == nil)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"thriftgo.yaml": testConfig})
+	cfg, err := LoadConfig(filepath.Join(dir, "thriftgo.yaml"))
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, cfg.IDLs, []string{filepath.Join(dir, "idl/*.thrift")})
+	test.DeepEqual(t, cfg.argv(cfg.Targets[1], "a.thrift"), []string{
+		"thriftgo", "-q", "-i", filepath.Join(dir, "common"),
+		"-g", "fastgo:package_prefix=example.com/fast", "-o", filepath.Join(dir, "gen-fastgo"),
+		"-p", "dummy=" + filepath.Join(dir, "bin/dummy") + ":a=1,b",
+		"-p", "other=/bin/other:c=2",
+		"a.thrift",
+	})
+
+	for content, msg := range map[string]string{
+		"targets: [{gen: go}]":            "no idls specified",
+		"idls: [a.thrift]":                "no targets specified",
+		"idls: [a.thrift]\ntargets: [{}]": "targets[0]: gen is required",
+	} {
+		writeFiles(t, dir, map[string]string{"thriftgo.yaml": content})
+		_, err := LoadConfig(filepath.Join(dir, "thriftgo.yaml"))
+		test.Assert(t, err != nil && strings.HasSuffix(err.Error(), msg), err)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"common/base.thrift": "namespace go base\nstruct Base { 1: string LogID }\n",
+		"idl/a.thrift":       "namespace go a\ninclude \"base.thrift\"\nstruct A { 1: base.Base base }\n",
+		"idl/b.thrift":       "namespace go b\ninclude \"base.thrift\"\nstruct B { 1: base.Base base }\n",
+		"thriftgo.yaml": `idls: [idl/*.thrift]
+includes: [common]
+recurse: true
+targets:
+  - gen: go:package_prefix=example.com/gen
+    out: out
+`,
+	})
+	cfg, err := LoadConfig(filepath.Join(dir, "thriftgo.yaml"))
+	test.Assert(t, err == nil, err)
+	test.Assert(t, Build(cfg) == nil)
+	for _, name := range []string{"a/a.go", "b/b.go", "base/base.go"} {
+		_, err := os.Stat(filepath.Join(dir, "out", name))
+		test.Assert(t, err == nil, err)
+	}
+
+	cfg.IDLs = append(cfg.IDLs, filepath.Join(dir, "none/*.thrift"))
+	err = Build(cfg)
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "no IDL matches"), err)
+}
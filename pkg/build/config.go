@@ -0,0 +1,189 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFiles are the configuration files looked up in the working
+// directory when none is specified.
+var DefaultConfigFiles = []string{"thriftgo.yaml", "thriftgo.yml"}
+
+// Config describes the IDLs of a project and the codes to generate for them.
+// For example:
+//
+//	idls:
+//	  - idl/services/*.thrift
+//	includes:
+//	  - idl/common
+//	flags: [--check-keywords]
+//	targets:
+//	  - gen: go:package_prefix=example.com/gen,frugal_tag
+//	    out: gen-go
+//	    plugins:
+//	      - name: validator
+//	        options: [func=my_func]
+//	      - thrift-gen-rpc=./bin/rpc:service=echo
+//
+// Relative paths are relative to the directory of the configuration file.
+type Config struct {
+	// IDLs are the main IDL files. Glob patterns are supported.
+	IDLs []string `yaml:"idls"`
+	// Includes are the search paths for includes.
+	Includes []string `yaml:"includes"`
+	// Recurse generates codes for the included IDLs as well.
+	Recurse bool `yaml:"recurse"`
+	// Flags are extra command line flags passed to thriftgo, like
+	// --check-keywords or --verbose.
+	Flags   []string  `yaml:"flags"`
+	Targets []*Target `yaml:"targets"`
+}
+
+// Target is a language to generate codes for.
+type Target struct {
+	// Gen is the language and its options in the form of the -g flag.
+	Gen string `yaml:"gen"`
+	// Out is the output directory. Default: gen-<language>.
+	Out     string    `yaml:"out"`
+	Plugins []*Plugin `yaml:"plugins"`
+}
+
+// Plugin is a plugin used by a target.
+type Plugin struct {
+	Name string `yaml:"name"`
+	// Path is the executable of the plugin. Default: thrift-gen-<name> in $PATH.
+	Path    string   `yaml:"path"`
+	Options []string `yaml:"options"`
+
+	compact string
+}
+
+// UnmarshalYAML allows a plugin to be configured in the form of the -p flag.
+func (p *Plugin) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&p.compact)
+	}
+	type plain Plugin
+	return node.Decode((*plain)(p))
+}
+
+// String returns the plugin in the form of the -p flag.
+func (p *Plugin) String() string {
+	if p.compact != "" {
+		return p.compact
+	}
+	res := p.Name
+	if p.Path != "" {
+		res += "=" + p.Path
+	}
+	if len(p.Options) > 0 {
+		res += ":" + strings.Join(p.Options, ",")
+	}
+	return res
+}
+
+// LoadConfig reads the configuration from the file and resolves the relative
+// paths in it.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("build: parse %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("build: %s: %w", path, err)
+	}
+	cfg.resolve(filepath.Dir(path))
+	return &cfg, nil
+}
+
+func (cfg *Config) validate() error {
+	if len(cfg.IDLs) == 0 {
+		return errors.New("no idls specified")
+	}
+	if len(cfg.Targets) == 0 {
+		return errors.New("no targets specified")
+	}
+	for i, t := range cfg.Targets {
+		if t.Gen == "" {
+			return fmt.Errorf("targets[%d]: gen is required", i)
+		}
+		for j, p := range t.Plugins {
+			if p.compact == "" && p.Name == "" {
+				return fmt.Errorf("targets[%d].plugins[%d]: name is required", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func (cfg *Config) resolve(dir string) {
+	join := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	for i := range cfg.IDLs {
+		cfg.IDLs[i] = join(cfg.IDLs[i])
+	}
+	for i := range cfg.Includes {
+		cfg.Includes[i] = join(cfg.Includes[i])
+	}
+	for _, t := range cfg.Targets {
+		if t.Out == "" {
+			t.Out = "gen-" + strings.SplitN(t.Gen, ":", 2)[0]
+		}
+		t.Out = join(t.Out)
+		for _, p := range t.Plugins {
+			p.Path = join(p.Path)
+		}
+	}
+}
+
+// Files expands the glob patterns of IDLs. Each file appears once even if it
+// is matched by several patterns. It is an error if a pattern matches nothing.
+func (cfg *Config) Files() ([]string, error) {
+	var res []string
+	seen := make(map[string]bool)
+	for _, pattern := range cfg.IDLs {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("build: %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("build: no IDL matches %s", pattern)
+		}
+		sort.Strings(matches)
+		for _, m := range matches {
+			if !seen[m] {
+				seen[m] = true
+				res = append(res, m)
+			}
+		}
+	}
+	return res, nil
+}
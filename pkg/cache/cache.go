@@ -0,0 +1,206 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache records the inputs and outputs of code generation, so that
+// thriftgo can skip the work whose inputs have not changed since the last run.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DefaultDir returns the default cache directory, which is thriftgo in the
+// user cache directory.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "thriftgo"), nil
+}
+
+// Hash returns the hex encoded SHA-256 of the parts. The parts are
+// length-prefixed so that different splits of the same bytes differ.
+func Hash(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%d:%s", len(p), p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// HashFile returns the hash of the content of a file.
+func HashFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return Hash(string(content)), nil
+}
+
+// Cache stores entries in a directory. Each entry is a JSON file named by
+// its key.
+type Cache struct {
+	dir string
+}
+
+// Open creates the directory if it does not exist.
+func Open(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+	return &Cache{dir: dir}, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Load returns the entry of the key, or nil if it does not exist or can not
+// be read.
+func (c *Cache) Load(key string) *Entry {
+	content, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil
+	}
+	e := NewEntry()
+	if err := json.Unmarshal(content, e); err != nil {
+		return nil
+	}
+	return e
+}
+
+// Store saves the entry of the key. The file is replaced atomically so that
+// concurrent runs never see a partial entry.
+func (c *Cache) Store(key string, e *Entry) error {
+	content, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+	_, err = tmp.Write(content)
+	if e := tmp.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("cache: %w", err)
+	}
+	return nil
+}
+
+// Entry records the files read and written by a generation. It is safe for
+// concurrent use.
+type Entry struct {
+	// Inputs maps the IDLs to the hashes of their content.
+	Inputs map[string]string `json:"inputs"`
+	// Outputs maps the generated files to the hashes of their content.
+	Outputs map[string]string `json:"outputs"`
+	// Sources maps the generated files to the hashes of their content before
+	// post-processing.
+	Sources map[string]string `json:"sources"`
+	// Absent lists the paths tried before an included file was found. Any
+	// of them being created would change the result of the search.
+	Absent []string `json:"absent,omitempty"`
+
+	mu sync.Mutex
+}
+
+// NewEntry creates an empty entry.
+func NewEntry() *Entry {
+	return &Entry{
+		Inputs:  make(map[string]string),
+		Outputs: make(map[string]string),
+		Sources: make(map[string]string),
+	}
+}
+
+// AddInput records the current content of an input file.
+func (e *Entry) AddInput(path string) error {
+	hash, err := HashFile(path)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.Inputs[path] = hash
+	e.mu.Unlock()
+	return nil
+}
+
+// AddAbsent records a path that did not exist.
+func (e *Entry) AddAbsent(path string) {
+	e.mu.Lock()
+	e.Absent = append(e.Absent, path)
+	e.mu.Unlock()
+}
+
+// AddOutput records a generated file by the hashes of its content before and
+// after post-processing.
+func (e *Entry) AddOutput(path, source, hash string) {
+	e.mu.Lock()
+	e.Outputs[path] = hash
+	e.Sources[path] = source
+	e.mu.Unlock()
+}
+
+// Unchanged reports whether the file at path was generated from the source
+// hash and has not been modified since. It returns the hash of the file.
+func (e *Entry) Unchanged(path, source string) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	e.mu.Lock()
+	want, ok := e.Outputs[path]
+	ok = ok && e.Sources[path] == source
+	e.mu.Unlock()
+	if !ok {
+		return "", false
+	}
+	hash, err := HashFile(path)
+	return hash, err == nil && hash == want
+}
+
+// UpToDate reports whether no input or output has changed and no absent path
+// has been created since the entry was recorded. A nil entry is never up to
+// date.
+func (e *Entry) UpToDate() bool {
+	if e == nil || len(e.Inputs) == 0 {
+		return false
+	}
+	for _, files := range []map[string]string{e.Inputs, e.Outputs} {
+		for path, want := range files {
+			if hash, err := HashFile(path); err != nil || hash != want {
+				return false
+			}
+		}
+	}
+	for _, path := range e.Absent {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestHash(t *testing.T) {
+	test.Assert(t, Hash("ab", "c") != Hash("a", "bc"))
+	test.Assert(t, Hash("a") == Hash("a") && len(Hash()) == 64)
+}
+
+func TestEntry(t *testing.T) {
+	dir := t.TempDir()
+	idl, out := filepath.Join(dir, "a.thrift"), filepath.Join(dir, "a.go")
+	test.Assert(t, os.WriteFile(idl, []byte("struct A {}"), 0o644) == nil)
+	test.Assert(t, os.WriteFile(out, []byte("package a"), 0o644) == nil)
+
+	c, err := Open(filepath.Join(dir, "cache"))
+	test.Assert(t, err == nil, err)
+	test.Assert(t, c.Load("key") == nil)
+	test.Assert(t, !c.Load("key").UpToDate())
+
+	e := NewEntry()
+	test.Assert(t, e.AddInput(idl) == nil)
+	e.AddOutput(out, Hash("raw"), Hash("package a"))
+	test.Assert(t, c.Store("key", e) == nil)
+
+	e = c.Load("key")
+	test.Assert(t, e != nil && e.UpToDate(), e)
+	_, ok := e.Unchanged(out, Hash("raw"))
+	test.Assert(t, ok)
+	_, ok = e.Unchanged(out, Hash("other"))
+	test.Assert(t, !ok)
+
+	test.Assert(t, os.WriteFile(out, []byte("package b"), 0o644) == nil)
+	test.Assert(t, !e.UpToDate())
+	_, ok = e.Unchanged(out, Hash("raw"))
+	test.Assert(t, !ok)
+
+	test.Assert(t, os.WriteFile(out, []byte("package a"), 0o644) == nil)
+	test.Assert(t, os.WriteFile(idl, []byte("struct B {}"), 0o644) == nil)
+	test.Assert(t, !e.UpToDate())
+	test.Assert(t, os.Remove(idl) == nil)
+	test.Assert(t, !e.UpToDate())
+}
+
+func TestEntryAbsent(t *testing.T) {
+	dir := t.TempDir()
+	idl, absent := filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift")
+	test.Assert(t, os.WriteFile(idl, []byte("struct A {}"), 0o644) == nil)
+
+	e := NewEntry()
+	test.Assert(t, e.AddInput(idl) == nil)
+	e.AddAbsent(absent)
+	test.Assert(t, e.UpToDate())
+
+	test.Assert(t, os.WriteFile(absent, []byte("struct B {}"), 0o644) == nil)
+	test.Assert(t, !e.UpToDate())
+}
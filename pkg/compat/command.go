@@ -0,0 +1,294 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compat
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+// Options of the compat subcommand.
+type Options struct {
+	Old         string   // a directory, a file or a git revision
+	New         string   // the IDL file
+	IncludeDirs []string // search paths for includes, relative to the root of each version
+	Format      string   // text or json
+	FailOn      Level    // the lowest level that makes the command fail
+}
+
+// Run parses the arguments of the compat subcommand and reports the changes.
+// It fails if any change reaches the level given by --fail-on.
+func Run(args []string) error {
+	return run(args, os.Stdout)
+}
+
+type stringSlice []string
+
+func (ss *stringSlice) String() string {
+	return fmt.Sprintf("%v", *ss)
+}
+
+func (ss *stringSlice) Set(value string) error {
+	*ss = append(*ss, value)
+	return nil
+}
+
+func run(args []string, stdout io.Writer) error {
+	var opts Options
+	var failOn string
+	f := flag.NewFlagSet("thriftgo compat", flag.ContinueOnError)
+	f.StringVar(&opts.Old, "old", "", "")
+	f.StringVar(&opts.New, "new", "", "")
+	f.Var((*stringSlice)(&opts.IncludeDirs), "i", "")
+	f.Var((*stringSlice)(&opts.IncludeDirs), "include", "")
+	f.StringVar(&opts.Format, "format", "text", "")
+	f.StringVar(&failOn, "fail-on", "breaking", "")
+	f.Usage = func() {
+		println(`Usage: thriftgo compat --old <dir|file|git-ref> --new <file> [options]
+Report changes between two versions of an IDL that affect the compatibility on the wire.
+The old version of the new file is looked up at the same relative path in the
+directory or the git revision.
+Options:
+  --old STR           A directory, a file or a git revision of the old version.
+  --new STR           The IDL file of the new version.
+  -i, --include dir   Add a search path for includes. Relative paths are applied to both versions.
+  --format STR        Output format: text or json. Default: text.
+  --fail-on STR       Fail if any change is at least of the level: safe, risky or breaking.
+                      Default: breaking.`)
+	}
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	if opts.Old == "" || opts.New == "" || f.NArg() > 0 {
+		f.Usage()
+		return flag.ErrHelp
+	}
+	level, err := ParseLevel(failOn)
+	if err != nil {
+		return err
+	}
+	opts.FailOn = level
+	if opts.Format != "text" && opts.Format != "json" {
+		return fmt.Errorf("unknown format %q, expecting one of: text, json", opts.Format)
+	}
+
+	fsys, oldPath, oldIncludes, cleanup, err := locateOld(&opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	oldAST, err := load(fsys, oldPath, oldIncludes)
+	if err != nil {
+		return fmt.Errorf("old version: %w", err)
+	}
+	newAST, err := load(vfs.OS, opts.New, opts.IncludeDirs)
+	if err != nil {
+		return fmt.Errorf("new version: %w", err)
+	}
+	findings := Compare(oldAST, newAST)
+	if err := write(stdout, opts.Format, findings); err != nil {
+		return err
+	}
+	failed := 0
+	for _, f := range findings {
+		if f.Level >= opts.FailOn {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("compat: %d change(s) at level %s or above", failed, opts.FailOn)
+	}
+	return nil
+}
+
+func load(fsys vfs.FS, path string, includeDirs []string) (*parser.Thrift, error) {
+	ast, err := parser.ParseFileFS(fsys, path, includeDirs, make(map[string]*parser.Thrift))
+	if err != nil {
+		return nil, err
+	}
+	if err := semantic.ResolveSymbols(ast); err != nil {
+		return nil, err
+	}
+	return ast, nil
+}
+
+// locateOld finds the old version of the new file and the include paths for
+// it. If the old version is a git revision, the files of the whole repository
+// are extracted into a temporary directory that the cleanup function removes.
+// They are read from fsys with paths relative to the top level of the
+// repository, so that the includes never resolve to files in the working
+// directory and the findings of removed elements point to repository paths.
+func locateOld(opts *Options) (fsys vfs.FS, path string, includeDirs []string, cleanup func(), err error) {
+	cleanup = func() {}
+	rel := opts.New
+	if filepath.IsAbs(rel) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, "", nil, cleanup, err
+		}
+		if rel, err = filepath.Rel(wd, opts.New); err != nil {
+			return nil, "", nil, cleanup, err
+		}
+	}
+
+	fsys, root, top := vfs.OS, opts.Old, ""
+	if info, err := os.Stat(opts.Old); err == nil && !info.IsDir() {
+		return fsys, opts.Old, opts.IncludeDirs, cleanup, nil
+	} else if err != nil {
+		// not a path, so it should be a git revision
+		var extracted string
+		if top, extracted, err = extract(opts.Old); err != nil {
+			return nil, "", nil, cleanup, err
+		}
+		cleanup = func() { os.RemoveAll(extracted) }
+		prefix, err := git("rev-parse", "--show-prefix")
+		if err != nil {
+			return nil, "", nil, cleanup, err
+		}
+		fsys = vfs.FromFS(os.DirFS(extracted))
+		root = filepath.Clean(strings.TrimSpace(string(prefix)))
+	}
+
+	for _, dir := range opts.IncludeDirs {
+		switch {
+		case !filepath.IsAbs(dir):
+			dir = filepath.Join(root, dir)
+		case top != "":
+			// absolute paths in the working tree are mapped into the revision
+			r, err := filepath.Rel(top, dir)
+			if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+				return nil, "", nil, cleanup, fmt.Errorf("include path %s is outside of the git repository", dir)
+			}
+			dir = r
+		}
+		includeDirs = append(includeDirs, dir)
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		// the new file is outside the working directory
+		rel = filepath.Base(rel)
+	}
+	path = filepath.Join(root, rel)
+	if !vfs.Exists(fsys, path) {
+		return nil, "", nil, cleanup, fmt.Errorf("the old version of %s is not found in %s", opts.New, opts.Old)
+	}
+	return fsys, path, includeDirs, cleanup, nil
+}
+
+func git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// extract writes the files of the git revision into a temporary directory.
+// The whole repository is extracted even in a subdirectory of it, and top is
+// the top level of the working tree.
+func extract(rev string) (top, dir string, err error) {
+	out, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", "", err
+	}
+	top = strings.TrimSpace(string(out))
+	if out, err = git("-C", top, "archive", "--format=tar", rev); err != nil {
+		return "", "", err
+	}
+	if dir, err = os.MkdirTemp("", "thriftgo-compat-"); err != nil {
+		return "", "", err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+	r := tar.NewReader(bytes.NewReader(out))
+	for {
+		h, err := r.Next()
+		if err == io.EOF {
+			return top, dir, nil
+		}
+		if err != nil {
+			return "", "", err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		path := filepath.Join(dir, filepath.FromSlash(h.Name))
+		if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return "", "", errors.New("invalid path in git archive: " + h.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", "", err
+		}
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return "", "", err
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return "", "", err
+		}
+	}
+}
+
+type jsonFinding struct {
+	Level    string `json:"level"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Filename string `json:"file"`
+	Line     int32  `json:"line,omitempty"`
+	Column   int32  `json:"column,omitempty"`
+}
+
+func write(w io.Writer, format string, findings []*Finding) error {
+	if format == "json" {
+		res := make([]*jsonFinding, 0, len(findings))
+		for _, f := range findings {
+			jf := &jsonFinding{Level: f.Level.String(), Code: f.Code, Message: f.Message, Filename: f.Filename}
+			if f.Location != nil {
+				jf.Line, jf.Column = f.Location.StartLine, f.Location.StartColumn
+			}
+			res = append(res, jf)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+	var sb strings.Builder
+	counts := make(map[Level]int)
+	for _, f := range findings {
+		sb.WriteString(f.String() + "\n")
+		counts[f.Level]++
+	}
+	fmt.Fprintf(&sb, "%d breaking, %d risky, %d safe change(s)\n", counts[Breaking], counts[Risky], counts[Safe])
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,592 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compat detects changes between two versions of an IDL that break
+// the compatibility of the data on the wire. It implements the compat
+// subcommand.
+package compat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+// Level tells how a change affects peers that still use the old IDL.
+type Level int
+
+// Levels of changes.
+const (
+	// Safe changes do not affect peers using the old IDL.
+	Safe Level = iota
+	// Risky changes keep the data decodable but may change its meaning or
+	// break peers in some cases, like protocols that encode names.
+	Risky
+	// Breaking changes make the data undecodable or the calls fail.
+	Breaking
+)
+
+// String returns the lower-case name of the level.
+func (l Level) String() string {
+	switch l {
+	case Safe:
+		return "safe"
+	case Risky:
+		return "risky"
+	case Breaking:
+		return "breaking"
+	}
+	return "Level(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ParseLevel converts a name returned by Level.String to a level.
+func ParseLevel(s string) (Level, error) {
+	for l := Safe; l <= Breaking; l++ {
+		if l.String() == s {
+			return l, nil
+		}
+	}
+	return Safe, fmt.Errorf("unknown level %q, expecting one of: safe, risky, breaking", s)
+}
+
+// Codes of findings.
+const (
+	CodeTypeAdded           = "type-added"
+	CodeTypeRemoved         = "type-removed"
+	CodeTypeKindChanged     = "type-kind-changed"
+	CodeTypedefChanged      = "typedef-changed"
+	CodeConstRemoved        = "const-removed"
+	CodeConstChanged        = "const-changed"
+	CodeFieldAdded          = "field-added"
+	CodeFieldRemoved        = "field-removed"
+	CodeFieldRenamed        = "field-renamed"
+	CodeFieldIDChanged      = "field-id-changed"
+	CodeFieldTypeChanged    = "field-type-changed"
+	CodeRequirednessChanged = "requiredness-changed"
+	CodeDefaultChanged      = "default-changed"
+	CodeEnumValueAdded      = "enum-value-added"
+	CodeEnumValueRemoved    = "enum-value-removed"
+	CodeEnumValueRenamed    = "enum-value-renamed"
+	CodeEnumValueChanged    = "enum-value-changed"
+	CodeServiceRemoved      = "service-removed"
+	CodeExtendsChanged      = "extends-changed"
+	CodeMethodAdded         = "method-added"
+	CodeMethodRemoved       = "method-removed"
+	CodeMethodRenamed       = "method-renamed"
+	CodeOnewayChanged       = "oneway-changed"
+	CodeResultChanged       = "result-changed"
+	CodeExceptionAdded      = "exception-added"
+	CodeExceptionRemoved    = "exception-removed"
+	CodeExceptionChanged    = "exception-changed"
+)
+
+// Finding is a change between the old and the new IDL.
+type Finding struct {
+	Level   Level
+	Code    string
+	Message string
+	// Filename and Location point to the changed element in the new IDL, or
+	// to the element in the old IDL if it is removed.
+	Filename string
+	Location *parser.Location
+}
+
+func (f *Finding) String() string {
+	return parser.Position(f.Filename, f.Location) + ": " + f.Level.String() + ": " + f.Message + " [" + f.Code + "]"
+}
+
+// Compare reports the changes from old to new. Both ASTs must be parsed with
+// their includes and resolved by semantic.ResolveSymbols. Included IDLs are
+// compared if they are included with the same path in both versions.
+func Compare(old, new *parser.Thrift) []*Finding {
+	c := &comparer{visited: make(map[*parser.Thrift]bool)}
+	c.file(old, new)
+	return c.findings
+}
+
+type comparer struct {
+	findings []*Finding
+	visited  map[*parser.Thrift]bool
+}
+
+func (c *comparer) report(level Level, code string, ast *parser.Thrift, loc *parser.Location, format string, args ...interface{}) {
+	c.findings = append(c.findings, &Finding{
+		Level:    level,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Filename: ast.Filename,
+		Location: loc,
+	})
+}
+
+func (c *comparer) file(old, new *parser.Thrift) {
+	if c.visited[new] {
+		return
+	}
+	c.visited[new] = true
+
+	c.typedefs(old, new)
+	c.consts(old, new)
+	c.enums(old, new)
+	c.structLikes(old, new)
+	c.services(old, new)
+
+	for _, inc := range new.Includes {
+		for _, o := range old.Includes {
+			if o.Path == inc.Path && o.Reference != nil && inc.Reference != nil {
+				c.file(o.Reference, inc.Reference)
+				break
+			}
+		}
+	}
+}
+
+func (c *comparer) typedefs(old, new *parser.Thrift) {
+	for _, td := range new.Typedefs {
+		o, ok := old.GetTypedef(td.Alias)
+		if !ok {
+			if _, exist := old.Name2Category[td.Alias]; !exist {
+				c.report(Safe, CodeTypeAdded, new, td.Location, "typedef %s is added", td.Alias)
+			}
+			continue
+		}
+		if level, from, to := c.compareTypes(old, o.Type, new, td.Type); from != to {
+			c.report(level, CodeTypedefChanged, new, td.Location, "typedef %s changed from %s to %s", td.Alias, from, to)
+		}
+	}
+	for _, o := range old.Typedefs {
+		if _, ok := new.GetTypedef(o.Alias); !ok {
+			c.report(Risky, CodeTypeRemoved, old, o.Location, "typedef %s is removed", o.Alias)
+		}
+	}
+}
+
+func (c *comparer) consts(old, new *parser.Thrift) {
+	for _, v := range new.Constants {
+		o, ok := old.GetConstant(v.Name)
+		if !ok {
+			continue
+		}
+		if from, to := constString(o.Value), constString(v.Value); from != to {
+			c.report(Risky, CodeConstChanged, new, v.Location, "constant %s changed from %s to %s", v.Name, from, to)
+		}
+	}
+	for _, o := range old.Constants {
+		if _, ok := new.GetConstant(o.Name); !ok {
+			c.report(Risky, CodeConstRemoved, old, o.Location, "constant %s is removed", o.Name)
+		}
+	}
+}
+
+func (c *comparer) enums(old, new *parser.Thrift) {
+	for _, e := range new.Enums {
+		o, ok := old.GetEnum(e.Name)
+		if !ok {
+			if _, exist := old.Name2Category[e.Name]; !exist {
+				c.report(Safe, CodeTypeAdded, new, e.Location, "enum %s is added", e.Name)
+			}
+			continue
+		}
+		oldByName := make(map[string]*parser.EnumValue)
+		oldByValue := make(map[int64]*parser.EnumValue)
+		for _, v := range o.Values {
+			oldByName[v.Name] = v
+			oldByValue[v.Value] = v
+		}
+		newByName := make(map[string]*parser.EnumValue)
+		newByValue := make(map[int64]*parser.EnumValue)
+		for _, v := range e.Values {
+			newByName[v.Name] = v
+			newByValue[v.Value] = v
+		}
+		for _, v := range e.Values {
+			if ov, ok := oldByName[v.Name]; ok {
+				if ov.Value != v.Value {
+					c.report(Breaking, CodeEnumValueChanged, new, v.Location,
+						"value of %s.%s changed from %d to %d", e.Name, v.Name, ov.Value, v.Value)
+				}
+			} else if ov, ok := oldByValue[v.Value]; ok && newByName[ov.Name] == nil {
+				c.report(Risky, CodeEnumValueRenamed, new, v.Location,
+					"%s.%s is renamed to %s", e.Name, ov.Name, v.Name)
+			} else {
+				c.report(Risky, CodeEnumValueAdded, new, v.Location,
+					"%s.%s = %d is added and may be unknown to old peers", e.Name, v.Name, v.Value)
+			}
+		}
+		for _, ov := range o.Values {
+			if newByName[ov.Name] != nil {
+				continue
+			}
+			if v, ok := newByValue[ov.Value]; ok && oldByName[v.Name] == nil {
+				continue // renamed
+			}
+			c.report(Breaking, CodeEnumValueRemoved, old, ov.Location,
+				"%s.%s = %d is removed", e.Name, ov.Name, ov.Value)
+		}
+	}
+	for _, o := range old.Enums {
+		if _, ok := new.Name2Category[o.Name]; !ok {
+			c.report(Risky, CodeTypeRemoved, old, o.Location, "enum %s is removed", o.Name)
+		}
+	}
+}
+
+func (c *comparer) structLikes(old, new *parser.Thrift) {
+	olds := make(map[string]*parser.StructLike)
+	for _, s := range old.GetStructLikes() {
+		olds[s.Name] = s
+	}
+	news := make(map[string]*parser.StructLike)
+	for _, s := range new.GetStructLikes() {
+		news[s.Name] = s
+		o, ok := olds[s.Name]
+		if !ok {
+			if _, exist := old.Name2Category[s.Name]; !exist {
+				c.report(Safe, CodeTypeAdded, new, s.Location, "%s %s is added", s.Category, s.Name)
+			}
+			continue
+		}
+		if o.Category != s.Category {
+			// structs and exceptions are encoded in the same way
+			level := Safe
+			if o.Category == "union" || s.Category == "union" {
+				level = Risky
+			}
+			c.report(level, CodeTypeKindChanged, new, s.Location,
+				"%s %s is changed to a %s", o.Category, s.Name, s.Category)
+		}
+		c.fields(s.Category+" "+s.Name, old, o.Fields, new, s.Fields)
+	}
+	for _, o := range old.GetStructLikes() {
+		if _, ok := new.Name2Category[o.Name]; !ok {
+			c.report(Risky, CodeTypeRemoved, old, o.Location, "%s %s is removed", o.Category, o.Name)
+		}
+	}
+}
+
+// fields compares the fields of a struct or the arguments of a function.
+func (c *comparer) fields(owner string, oldAST *parser.Thrift, olds []*parser.Field, newAST *parser.Thrift, news []*parser.Field) {
+	oldByID := make(map[int32]*parser.Field)
+	oldByName := make(map[string]*parser.Field)
+	for _, f := range olds {
+		oldByID[f.ID] = f
+		oldByName[f.Name] = f
+	}
+	newByID := make(map[int32]*parser.Field)
+	newByName := make(map[string]*parser.Field)
+	for _, f := range news {
+		newByID[f.ID] = f
+		newByName[f.Name] = f
+	}
+
+	for _, f := range news {
+		o, ok := oldByID[f.ID]
+		if !ok {
+			if o, ok := oldByName[f.Name]; ok && newByID[o.ID] == nil {
+				c.report(Breaking, CodeFieldIDChanged, newAST, f.Location,
+					"ID of field %s in %s changed from %d to %d", f.Name, owner, o.ID, f.ID)
+			} else if f.Requiredness == parser.FieldType_Required {
+				c.report(Breaking, CodeFieldAdded, newAST, f.Location,
+					"required field %d: %s is added to %s", f.ID, f.Name, owner)
+			} else {
+				c.report(Safe, CodeFieldAdded, newAST, f.Location, "field %d: %s is added to %s", f.ID, f.Name, owner)
+			}
+			continue
+		}
+		if o.Name != f.Name {
+			c.report(Risky, CodeFieldRenamed, newAST, f.Location,
+				"field %d in %s is renamed from %s to %s", f.ID, owner, o.Name, f.Name)
+		}
+		if level, from, to := c.compareTypes(oldAST, o.Type, newAST, f.Type); from != to {
+			c.report(level, CodeFieldTypeChanged, newAST, f.Location,
+				"type of field %d: %s in %s changed from %s to %s", f.ID, f.Name, owner, from, to)
+		}
+		if o.Requiredness != f.Requiredness {
+			level := Safe
+			switch {
+			case f.Requiredness == parser.FieldType_Required:
+				level = Breaking // old writers may omit it
+			case o.Requiredness == parser.FieldType_Required:
+				level = Risky // old readers reject data without it
+			}
+			c.report(level, CodeRequirednessChanged, newAST, f.Location,
+				"field %d: %s in %s changed from %s to %s", f.ID, f.Name, owner,
+				requiredness(o.Requiredness), requiredness(f.Requiredness))
+		}
+		if from, to := constString(o.Default), constString(f.Default); from != to {
+			c.report(Risky, CodeDefaultChanged, newAST, f.Location,
+				"default value of field %d: %s in %s changed from %s to %s", f.ID, f.Name, owner, from, to)
+		}
+	}
+	for _, o := range olds {
+		if newByID[o.ID] != nil {
+			continue
+		}
+		if f, ok := newByName[o.Name]; ok && oldByID[f.ID] == nil {
+			continue // the ID is changed
+		}
+		if o.Requiredness == parser.FieldType_Required {
+			c.report(Breaking, CodeFieldRemoved, oldAST, o.Location,
+				"required field %d: %s is removed from %s", o.ID, o.Name, owner)
+		} else {
+			c.report(Risky, CodeFieldRemoved, oldAST, o.Location,
+				"field %d: %s is removed from %s and its ID must not be reused", o.ID, o.Name, owner)
+		}
+	}
+}
+
+func requiredness(r parser.FieldType) string {
+	switch r {
+	case parser.FieldType_Required:
+		return "required"
+	case parser.FieldType_Optional:
+		return "optional"
+	}
+	return "default"
+}
+
+func (c *comparer) services(old, new *parser.Thrift) {
+	for _, s := range new.Services {
+		o, ok := old.GetService(s.Name)
+		if !ok {
+			c.report(Safe, CodeTypeAdded, new, s.Location, "service %s is added", s.Name)
+			continue
+		}
+		if o.Extends != s.Extends {
+			c.report(Breaking, CodeExtendsChanged, new, s.Location,
+				"base of service %s changed from %q to %q", s.Name, o.Extends, s.Extends)
+		}
+		c.functions(s.Name, old, o.Functions, new, s.Functions)
+	}
+	for _, o := range old.Services {
+		if _, ok := new.GetService(o.Name); !ok {
+			c.report(Breaking, CodeServiceRemoved, old, o.Location, "service %s is removed", o.Name)
+		}
+	}
+}
+
+func (c *comparer) functions(svc string, oldAST *parser.Thrift, olds []*parser.Function, newAST *parser.Thrift, news []*parser.Function) {
+	oldByName := make(map[string]*parser.Function)
+	for _, f := range olds {
+		oldByName[f.Name] = f
+	}
+	newByName := make(map[string]*parser.Function)
+	for _, f := range news {
+		newByName[f.Name] = f
+	}
+	var added []*parser.Function
+	for _, f := range news {
+		o, ok := oldByName[f.Name]
+		if !ok {
+			added = append(added, f)
+			continue
+		}
+		name := svc + "." + f.Name
+		if o.Oneway != f.Oneway {
+			c.report(Breaking, CodeOnewayChanged, newAST, f.Location, "oneway of method %s changed to %v", name, f.Oneway)
+		}
+		from, to := "void", "void"
+		level := Breaking
+		switch {
+		case !o.Void && !f.Void:
+			level, from, to = c.compareTypes(oldAST, o.FunctionType, newAST, f.FunctionType)
+		case !o.Void:
+			from = typeName(o.FunctionType)
+		case !f.Void:
+			to = typeName(f.FunctionType)
+		}
+		if from != to {
+			c.report(level, CodeResultChanged, newAST, f.Location, "result of method %s changed from %s to %s", name, from, to)
+		}
+		c.fields("arguments of method "+name, oldAST, o.Arguments, newAST, f.Arguments)
+		c.exceptions(name, oldAST, o.Throws, newAST, f)
+	}
+	renamed := make(map[*parser.Function]bool)
+	for _, o := range olds {
+		if newByName[o.Name] != nil {
+			continue
+		}
+		// a new method with the same signature is likely to be renamed from it
+		var to *parser.Function
+		for _, f := range added {
+			if !renamed[f] && c.signature(oldAST, o) == c.signature(newAST, f) {
+				to = f
+				break
+			}
+		}
+		if to != nil {
+			renamed[to] = true
+			c.report(Breaking, CodeMethodRenamed, newAST, to.Location,
+				"method %s.%s is renamed to %s", svc, o.Name, to.Name)
+		} else {
+			c.report(Breaking, CodeMethodRemoved, oldAST, o.Location, "method %s.%s is removed", svc, o.Name)
+		}
+	}
+	for _, f := range added {
+		if !renamed[f] {
+			c.report(Safe, CodeMethodAdded, newAST, f.Location, "method %s.%s is added", svc, f.Name)
+		}
+	}
+}
+
+func (c *comparer) exceptions(name string, oldAST *parser.Thrift, olds []*parser.Field, newAST *parser.Thrift, fn *parser.Function) {
+	oldByID := make(map[int32]*parser.Field)
+	for _, e := range olds {
+		oldByID[e.ID] = e
+	}
+	newByID := make(map[int32]*parser.Field)
+	for _, e := range fn.Throws {
+		newByID[e.ID] = e
+		o, ok := oldByID[e.ID]
+		if !ok {
+			c.report(Risky, CodeExceptionAdded, newAST, e.Location,
+				"exception %d: %s is added to method %s and unknown to old clients", e.ID, typeName(e.Type), name)
+			continue
+		}
+		if level, from, to := c.compareTypes(oldAST, o.Type, newAST, e.Type); from != to {
+			c.report(level, CodeExceptionChanged, newAST, e.Location,
+				"exception %d of method %s changed from %s to %s", e.ID, name, from, to)
+		}
+	}
+	for _, o := range olds {
+		if newByID[o.ID] == nil {
+			c.report(Breaking, CodeExceptionRemoved, newAST, fn.Location,
+				"exception %d: %s is removed from method %s", o.ID, typeName(o.Type), name)
+		}
+	}
+}
+
+// signature describes the types on the wire of a function.
+func (c *comparer) signature(ast *parser.Thrift, fn *parser.Function) string {
+	var sb strings.Builder
+	if fn.Oneway {
+		sb.WriteString("oneway ")
+	}
+	if fn.Void {
+		sb.WriteString("void")
+	} else {
+		sb.WriteString(describe(ast, fn.FunctionType, true))
+	}
+	for _, list := range [][]*parser.Field{fn.Arguments, fn.Throws} {
+		sb.WriteString("(")
+		for _, f := range list {
+			fmt.Fprintf(&sb, "%d:%s,", f.ID, describe(ast, f.Type, true))
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// compareTypes returns the level of the change from the old type to the new
+// one and their names. The names are the same if the types are identical.
+func (c *comparer) compareTypes(oldAST *parser.Thrift, old *parser.Type, newAST *parser.Thrift, new *parser.Type) (level Level, from, to string) {
+	from, to = typeName(old), typeName(new)
+	switch {
+	case describe(oldAST, old, true) != describe(newAST, new, true):
+		level = Breaking
+	case describe(oldAST, old, false) != describe(newAST, new, false):
+		level = Risky // like i32 to an enum or string to binary
+	case from != to:
+		level = Safe // like a typedef to its underlying type
+	}
+	if from == to && level != Safe {
+		from, to = describe(oldAST, old, false), describe(newAST, new, false)
+	}
+	return
+}
+
+func typeName(t *parser.Type) string {
+	switch t.Name {
+	case "map":
+		return "map<" + typeName(t.KeyType) + "," + typeName(t.ValueType) + ">"
+	case "list", "set":
+		return t.Name + "<" + typeName(t.ValueType) + ">"
+	}
+	return t.Name
+}
+
+// describe returns the canonical form of the type with typedefs dereferenced.
+// If wire is true, types with the same encoding are described in the same way:
+// enums as i32 and binary as string, and struct-likes by their names without
+// the IDL they belong to.
+func describe(ast *parser.Thrift, t *parser.Type, wire bool) string {
+	ast, dt, err := semantic.Deref(ast, t)
+	if err != nil {
+		return t.Name
+	}
+	t = dt
+	switch t.Category {
+	case parser.Category_Map:
+		return "map<" + describe(ast, t.KeyType, wire) + "," + describe(ast, t.ValueType, wire) + ">"
+	case parser.Category_List, parser.Category_Set:
+		return t.Name + "<" + describe(ast, t.ValueType, wire) + ">"
+	case parser.Category_Byte:
+		return "byte" // i8 is an alias of byte
+	case parser.Category_Binary:
+		if wire {
+			return "string"
+		}
+	case parser.Category_Enum:
+		if wire {
+			return "i32"
+		}
+		return "enum " + semantic.IDLPrefix(ast.Filename) + "." + t.Name
+	case parser.Category_Struct, parser.Category_Union, parser.Category_Exception:
+		name := t.Name
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			name = name[i+1:]
+		}
+		if wire {
+			return "struct " + name
+		}
+		return "struct " + semantic.IDLPrefix(ast.Filename) + "." + name
+	}
+	return t.Name
+}
+
+// constString formats a constant value for comparison.
+func constString(v *parser.ConstValue) string {
+	if v == nil {
+		return "none"
+	}
+	tv := v.TypedValue
+	switch {
+	case tv == nil:
+		return "none"
+	case tv.Double != nil:
+		return strconv.FormatFloat(*tv.Double, 'g', -1, 64)
+	case tv.Int != nil:
+		return strconv.FormatInt(*tv.Int, 10)
+	case tv.Literal != nil:
+		return strconv.Quote(*tv.Literal)
+	case tv.Identifier != nil:
+		return *tv.Identifier
+	case v.Type == parser.ConstType_ConstList:
+		var ss []string
+		for _, e := range tv.List {
+			ss = append(ss, constString(e))
+		}
+		return "[" + strings.Join(ss, ", ") + "]"
+	case v.Type == parser.ConstType_ConstMap:
+		var ss []string
+		for _, kv := range tv.Map {
+			ss = append(ss, constString(kv.Key)+": "+constString(kv.Value))
+		}
+		return "{" + strings.Join(ss, ", ") + "}"
+	}
+	return "none"
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compat
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+)
+
+const oldBase = `
+enum Status {
+    OK = 0
+    FAILED = 1
+    UNKNOWN = 2
+}
+typedef i64 ID
+`
+
+const newBase = `
+enum Status {
+    OK = 0
+    FAILURE = 1
+    TIMEOUT = 3
+}
+typedef i32 ID
+`
+
+const oldMain = `
+include "base.thrift"
+
+const i32 MAX = 10
+
+struct Request {
+    1: required string name
+    2: optional i32 count
+    3: base.ID id
+    4: list<string> tags
+    5: i32 status
+    6: optional string removed
+}
+
+exception Error {
+    1: string message
+}
+
+service Base {}
+
+service S extends Base {
+    oneway void ping()
+    Request get(1: i64 id) throws (1: Error err)
+    void remove(1: i64 id)
+    void old_name(1: string s)
+}
+`
+
+const newMain = `
+include "base.thrift"
+
+const i32 MAX = 20
+
+typedef string Name
+
+struct Request {
+    1: required Name name
+    2: required i32 count
+    3: base.ID id
+    4: list<i32> tags
+    5: base.Status status
+    7: i32 added
+}
+
+exception Error {
+    1: string message
+}
+
+service S {
+    void ping()
+    Request get(1: i64 id)
+    void new_name(1: string s)
+    void added()
+}
+`
+
+func setup(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+	}
+	return dir
+}
+
+func TestCompare(t *testing.T) {
+	oldDir := setup(t, map[string]string{"base.thrift": oldBase, "main.thrift": oldMain})
+	newDir := setup(t, map[string]string{"base.thrift": newBase, "main.thrift": newMain})
+	oldAST, err := load(vfs.OS, filepath.Join(oldDir, "main.thrift"), nil)
+	test.Assert(t, err == nil, err)
+	newAST, err := load(vfs.OS, filepath.Join(newDir, "main.thrift"), nil)
+	test.Assert(t, err == nil, err)
+
+	res := make(map[string]Level)
+	for _, f := range Compare(oldAST, newAST) {
+		res[f.Code+" "+f.Message] = f.Level
+	}
+	test.DeepEqual(t, res, map[string]Level{
+		"const-changed constant MAX changed from 10 to 20":                                                  Risky,
+		"type-added typedef Name is added":                                                                  Safe,
+		"field-type-changed type of field 1: name in struct Request changed from string to Name":            Safe,
+		"requiredness-changed field 2: count in struct Request changed from optional to required":           Breaking,
+		"field-type-changed type of field 3: id in struct Request changed from i64 to i32":                  Breaking,
+		"field-type-changed type of field 4: tags in struct Request changed from list<string> to list<i32>": Breaking,
+		"field-type-changed type of field 5: status in struct Request changed from i32 to base.Status":      Risky,
+		"field-added field 7: added is added to struct Request":                                             Safe,
+		"field-removed field 6: removed is removed from struct Request and its ID must not be reused":       Risky,
+		"service-removed service Base is removed":                                                           Breaking,
+		"extends-changed base of service S changed from \"Base\" to \"\"":                                   Breaking,
+		"oneway-changed oneway of method S.ping changed to false":                                           Breaking,
+		"exception-removed exception 1: Error is removed from method S.get":                                 Breaking,
+		"method-renamed method S.old_name is renamed to new_name":                                           Breaking,
+		"method-removed method S.remove is removed":                                                         Breaking,
+		"method-added method S.added is added":                                                              Safe,
+		"typedef-changed typedef ID changed from i64 to i32":                                                Breaking,
+		"enum-value-renamed Status.FAILED is renamed to FAILURE":                                            Risky,
+		"enum-value-added Status.TIMEOUT = 3 is added and may be unknown to old peers":                      Risky,
+		"enum-value-removed Status.UNKNOWN = 2 is removed":                                                  Breaking,
+	})
+}
+
+func TestRun(t *testing.T) {
+	oldDir := setup(t, map[string]string{"base.thrift": oldBase, "main.thrift": oldMain})
+	newDir := setup(t, map[string]string{"base.thrift": newBase, "main.thrift": oldMain})
+	newPath := filepath.Join(newDir, "main.thrift")
+
+	var out bytes.Buffer
+	err := run([]string{"--old", oldDir, "--new", newPath, "--fail-on", "risky"}, &out)
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "5 change(s) at level risky"), err)
+	test.Assert(t, strings.HasSuffix(out.String(), "3 breaking, 2 risky, 0 safe change(s)\n"), out.String())
+
+	// the same version
+	out.Reset()
+	err = run([]string{"--old", filepath.Join(newDir, "main.thrift"), "--new", newPath, "--format", "json"}, &out)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, out.String() == "[]\n", out.String())
+}
+
+func TestRunGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not found")
+	}
+	repo := t.TempDir()
+	dir := filepath.Join(repo, "idl")
+	test.Assert(t, os.Mkdir(dir, 0o755) == nil)
+	write := func(files map[string]string) {
+		for name, content := range files {
+			test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+		}
+	}
+	write(map[string]string{"base.thrift": oldBase, "main.thrift": oldMain})
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "old"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+		test.Assert(t, err == nil, err, string(out))
+	}
+	write(map[string]string{"base.thrift": newBase})
+
+	// run in a subdirectory of the repository
+	wd, err := os.Getwd()
+	test.Assert(t, err == nil, err)
+	test.Assert(t, os.Chdir(dir) == nil)
+	defer os.Chdir(wd)
+
+	var out bytes.Buffer
+	err = run([]string{"--old", "HEAD", "--new", filepath.Join(dir, "main.thrift"), "--format", "json"}, &out)
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "change(s) at level breaking"), err)
+	var findings []*jsonFinding
+	test.Assert(t, json.Unmarshal(out.Bytes(), &findings) == nil, out.String())
+	removed := 0
+	for _, f := range findings {
+		if strings.HasSuffix(f.Code, "-removed") {
+			removed++
+			test.Assert(t, f.Filename == filepath.Join("idl", "base.thrift"), f.Filename)
+		}
+	}
+	test.Assert(t, removed > 0, out.String())
+}
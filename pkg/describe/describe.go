@@ -0,0 +1,166 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package describe implements the describe subcommand, which lists the
+// backends of thriftgo with their options, built-in plugins and annotations.
+package describe
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/sdk"
+	"github.com/cloudwego/thriftgo/version"
+)
+
+// Description is what thriftgo supports.
+type Description struct {
+	Version  string    `json:"version"`
+	Backends []Backend `json:"backends"`
+}
+
+// Backend describes a backend.
+type Backend struct {
+	Name           string                   `json:"name"`
+	Lang           string                   `json:"lang"`
+	Options        []backend.OptionInfo     `json:"options"`
+	BuiltinPlugins []Plugin                 `json:"builtin_plugins"`
+	Annotations    []backend.AnnotationInfo `json:"annotations"`
+}
+
+// Plugin describes a built-in plugin of a backend.
+type Plugin struct {
+	Name    string   `json:"name"`
+	Options []Option `json:"options"`
+}
+
+// Option is an option of a plugin.
+type Option struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Describe describes the backends. The options of backends that do not
+// implement backend.Describer are reported as strings without defaults.
+func Describe(backends []backend.Backend) *Description {
+	d := &Description{Version: version.ThriftgoVersion, Backends: []Backend{}}
+	for _, be := range backends {
+		b := Backend{
+			Name:           be.Name(),
+			Lang:           be.Lang(),
+			Options:        []backend.OptionInfo{},
+			BuiltinPlugins: []Plugin{},
+			Annotations:    []backend.AnnotationInfo{},
+		}
+		if desc, ok := be.(backend.Describer); ok {
+			b.Options = append(b.Options, desc.DescribeOptions()...)
+			b.Annotations = append(b.Annotations, desc.Annotations()...)
+		} else {
+			for _, opt := range be.Options() {
+				b.Options = append(b.Options, backend.OptionInfo{Name: opt.Name, Type: "string", Description: opt.Desc})
+			}
+		}
+		for _, p := range be.BuiltinPlugins() {
+			bp := Plugin{Name: p.Name, Options: []Option{}}
+			for _, opt := range p.Options {
+				bp.Options = append(bp.Options, Option{Name: opt.Name, Description: opt.Desc})
+			}
+			b.BuiltinPlugins = append(b.BuiltinPlugins, bp)
+		}
+		d.Backends = append(d.Backends, b)
+	}
+	return d
+}
+
+// Run parses the arguments of the describe subcommand and prints the
+// description of the default backends.
+func Run(args []string) error {
+	return run(args, os.Stdout)
+}
+
+func run(args []string, stdout io.Writer) error {
+	var asJSON bool
+	f := flag.NewFlagSet("thriftgo describe", flag.ContinueOnError)
+	f.BoolVar(&asJSON, "json", false, "")
+	f.Usage = func() {
+		println(`Usage: thriftgo describe [options]
+List the backends with their options, built-in plugins and supported annotations.
+Options:
+  --json              Print the description as JSON.`)
+	}
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	if f.NArg() > 0 {
+		f.Usage()
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(f.Args(), " "))
+	}
+
+	d := Describe(sdk.DefaultBackends())
+	if asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	}
+	return writeText(stdout, d)
+}
+
+func writeText(w io.Writer, d *Description) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Version: %s\n", d.Version)
+	for _, b := range d.Backends {
+		fmt.Fprintf(&sb, "\n%s (%s):\n  options:\n", b.Name, b.Lang)
+		for _, o := range b.Options {
+			fmt.Fprintf(&sb, "    %s (%s", o.Name, o.Type)
+			if o.Default != "" {
+				fmt.Fprintf(&sb, ", default %s", o.Default)
+			}
+			fmt.Fprintf(&sb, "): %s\n", o.Description)
+			relations := []struct {
+				name  string
+				names []string
+			}{{"conflicts with", o.Conflicts}, {"requires", o.Requires}, {"has no effect without", o.EffectiveWith}}
+			for _, r := range relations {
+				if len(r.names) > 0 {
+					fmt.Fprintf(&sb, "      %s: %s\n", r.name, strings.Join(r.names, ", "))
+				}
+			}
+		}
+		if len(b.BuiltinPlugins) > 0 {
+			sb.WriteString("  built-in plugins:\n")
+			for _, p := range b.BuiltinPlugins {
+				fmt.Fprintf(&sb, "    %s\n", p.Name)
+				for _, o := range p.Options {
+					fmt.Fprintf(&sb, "      %s: %s\n", o.Name, o.Description)
+				}
+			}
+		}
+		if len(b.Annotations) > 0 {
+			sb.WriteString("  annotations:\n")
+			for _, a := range b.Annotations {
+				fmt.Fprintf(&sb, "    %s (on %s): %s\n", a.Name, strings.Join(a.On, ", "), a.Description)
+				if len(a.Values) > 0 {
+					fmt.Fprintf(&sb, "      values: %s\n", strings.Join(a.Values, ", "))
+				}
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package describe
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/plugin"
+	"github.com/cloudwego/thriftgo/version"
+)
+
+func TestRunJSON(t *testing.T) {
+	var buf bytes.Buffer
+	test.Assert(t, run([]string{"--json"}, &buf) == nil)
+
+	var d Description
+	test.Assert(t, json.Unmarshal(buf.Bytes(), &d) == nil, buf.String())
+	test.Assert(t, d.Version == version.ThriftgoVersion)
+	test.Assert(t, len(d.Backends) == 2 && d.Backends[0].Name == "go" && d.Backends[1].Name == "fastgo", d.Backends)
+
+	options := make(map[string]backend.OptionInfo)
+	for _, o := range d.Backends[0].Options {
+		options[o.Name] = o
+	}
+	test.Assert(t, options["gen_json_tag"].Type == "bool" && options["gen_json_tag"].Default == "true")
+	test.Assert(t, options["naming_style"].Type == "string" && options["naming_style"].Default == "thriftgo")
+	test.Assert(t, options["use_package"].Repeated)
+	test.DeepEqual(t, options["with_field_mask"].Requires, []string{"with_reflection"})
+	test.DeepEqual(t, options["apache_adaptor"].Conflicts, []string{"apache_warning"})
+	test.DeepEqual(t, options["streamx"].EffectiveWith, []string{"thrift_streaming"})
+
+	var annotations []string
+	for _, a := range d.Backends[0].Annotations {
+		annotations = append(annotations, a.Name)
+	}
+	test.Assert(t, strings.Contains(strings.Join(annotations, " "), "thrift.nested"), annotations)
+}
+
+type plainBackend struct{ backend.Backend }
+
+func (plainBackend) Name() string { return "plain" }
+func (plainBackend) Lang() string { return "Plain" }
+func (plainBackend) Options() []plugin.Option {
+	return []plugin.Option{{Name: "opt", Desc: "an option"}}
+}
+
+func (plainBackend) BuiltinPlugins() []*plugin.Desc {
+	return []*plugin.Desc{{Name: "p", Options: []plugin.Option{{Name: "k", Desc: "a key"}}}}
+}
+
+func TestDescribe(t *testing.T) {
+	d := Describe([]backend.Backend{plainBackend{}})
+	test.DeepEqual(t, d.Backends, []Backend{{
+		Name:           "plain",
+		Lang:           "Plain",
+		Options:        []backend.OptionInfo{{Name: "opt", Type: "string", Description: "an option"}},
+		BuiltinPlugins: []Plugin{{Name: "p", Options: []Option{{Name: "k", Description: "a key"}}}},
+		Annotations:    []backend.AnnotationInfo{},
+	}})
+
+	var buf bytes.Buffer
+	test.Assert(t, writeText(&buf, d) == nil)
+	test.Assert(t, strings.Contains(buf.String(), "    opt (string): an option\n"), buf.String())
+	test.Assert(t, run([]string{"extra"}, &buf) != nil)
+}
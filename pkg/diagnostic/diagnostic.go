@@ -0,0 +1,341 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diagnostic renders parser.Diagnostic in formats that can be
+// consumed by humans or tools like CI bots and editors.
+package diagnostic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/version"
+)
+
+// Supported output formats.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Formats lists all supported output formats.
+var Formats = []string{FormatText, FormatJSON, FormatSARIF}
+
+// IsValidFormat reports whether the format is supported.
+func IsValidFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Write renders the diagnostics into w with the given format.
+func Write(w io.Writer, format string, diags parser.Diagnostics) error {
+	switch format {
+	case FormatText:
+		return WriteText(w, diags)
+	case FormatJSON:
+		return WriteJSON(w, diags)
+	case FormatSARIF:
+		return WriteSARIF(w, diags)
+	}
+	return fmt.Errorf("unknown diagnostics format %q, expecting one of: %s",
+		format, strings.Join(Formats, ", "))
+}
+
+// SeverityName returns the lower-case name of the severity.
+func SeverityName(s parser.Severity) string {
+	return strings.ToLower(s.String())
+}
+
+// WriteText writes one line for each diagnostic in the form of
+// "filename:line:column: severity: message [code]", followed by indented
+// lines for related locations and suggested fixes.
+func WriteText(w io.Writer, diags parser.Diagnostics) error {
+	var sb strings.Builder
+	for _, d := range diags {
+		if d.Filename != "" {
+			sb.WriteString(parser.Position(d.Filename, d.Location))
+			sb.WriteString(": ")
+		}
+		sb.WriteString(SeverityName(d.Severity))
+		sb.WriteString(": ")
+		sb.WriteString(d.Message)
+		if d.Code != "" {
+			sb.WriteString(" [" + d.Code + "]")
+		}
+		sb.WriteString("\n")
+		for _, r := range d.Related {
+			sb.WriteString("\t" + parser.Position(r.Filename, r.Location) + ": " + r.Message + "\n")
+		}
+		if d.Fix != nil {
+			sb.WriteString("\tfix: " + d.Fix.Message + "\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type jsonDiagnostic struct {
+	Severity string         `json:"severity"`
+	Code     string         `json:"code,omitempty"`
+	Message  string         `json:"message"`
+	Source   string         `json:"source,omitempty"`
+	Filename string         `json:"file,omitempty"`
+	Range    *jsonRange     `json:"range,omitempty"`
+	Related  []*jsonRelated `json:"related,omitempty"`
+	Fix      *jsonFix       `json:"fix,omitempty"`
+}
+
+type jsonPosition struct {
+	Line   int32 `json:"line"`
+	Column int32 `json:"column"`
+}
+
+type jsonRange struct {
+	Start jsonPosition `json:"start"`
+	End   jsonPosition `json:"end"`
+}
+
+type jsonRelated struct {
+	Filename string     `json:"file"`
+	Range    *jsonRange `json:"range,omitempty"`
+	Message  string     `json:"message"`
+}
+
+type jsonEdit struct {
+	Filename string     `json:"file"`
+	Range    *jsonRange `json:"range,omitempty"`
+	NewText  string     `json:"newText"`
+}
+
+type jsonFix struct {
+	Message string      `json:"message"`
+	Edits   []*jsonEdit `json:"edits,omitempty"`
+}
+
+func toJSONRange(loc *parser.Location) *jsonRange {
+	if loc == nil {
+		return nil
+	}
+	return &jsonRange{
+		Start: jsonPosition{Line: loc.StartLine, Column: loc.StartColumn},
+		End:   jsonPosition{Line: loc.EndLine, Column: loc.EndColumn},
+	}
+}
+
+// WriteJSON writes the diagnostics as a JSON array.
+func WriteJSON(w io.Writer, diags parser.Diagnostics) error {
+	res := make([]*jsonDiagnostic, 0, len(diags))
+	for _, d := range diags {
+		jd := &jsonDiagnostic{
+			Severity: SeverityName(d.Severity),
+			Code:     d.Code,
+			Message:  d.Message,
+			Source:   d.Source,
+			Filename: d.Filename,
+			Range:    toJSONRange(d.Location),
+		}
+		for _, r := range d.Related {
+			jd.Related = append(jd.Related, &jsonRelated{
+				Filename: r.Filename, Range: toJSONRange(r.Location), Message: r.Message,
+			})
+		}
+		if d.Fix != nil {
+			jd.Fix = &jsonFix{Message: d.Fix.Message}
+			for _, e := range d.Fix.Edits {
+				jd.Fix.Edits = append(jd.Fix.Edits, &jsonEdit{
+					Filename: e.Filename, Range: toJSONRange(e.Location), NewText: e.NewText,
+				})
+			}
+		}
+		res = append(res, jd)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// The following types are a subset of the SARIF 2.1.0 schema.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool      `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID           string           `json:"ruleId,omitempty"`
+	Level            string           `json:"level"`
+	Message          sarifMessage     `json:"message"`
+	Locations        []*sarifLocation `json:"locations,omitempty"`
+	RelatedLocations []*sarifLocation `json:"relatedLocations,omitempty"`
+	Fixes            []*sarifFix      `json:"fixes,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int32 `json:"startLine"`
+	StartColumn int32 `json:"startColumn"`
+	EndLine     int32 `json:"endLine"`
+	EndColumn   int32 `json:"endColumn"`
+}
+
+type sarifFix struct {
+	Description     sarifMessage           `json:"description"`
+	ArtifactChanges []*sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []*sarifReplacement   `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   *sarifRegion  `json:"deletedRegion"`
+	InsertedContent *sarifMessage `json:"insertedContent,omitempty"`
+}
+
+func toSARIFLevel(s parser.Severity) string {
+	switch s {
+	case parser.Severity_Error:
+		return "error"
+	case parser.Severity_Warning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func toSARIFRegion(loc *parser.Location) *sarifRegion {
+	if loc == nil {
+		return nil
+	}
+	return &sarifRegion{
+		StartLine:   loc.StartLine,
+		StartColumn: loc.StartColumn,
+		EndLine:     loc.EndLine,
+		EndColumn:   loc.EndColumn,
+	}
+}
+
+func toSARIFLocation(filename string, loc *parser.Location, message string) *sarifLocation {
+	sl := &sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(filename)},
+			Region:           toSARIFRegion(loc),
+		},
+	}
+	if message != "" {
+		sl.Message = &sarifMessage{Text: message}
+	}
+	return sl
+}
+
+// WriteSARIF writes the diagnostics as a SARIF 2.1.0 log with a single run.
+func WriteSARIF(w io.Writer, diags parser.Diagnostics) error {
+	run := &sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "thriftgo",
+			Version:        version.ThriftgoVersion,
+			InformationURI: "https://github.com/cloudwego/thriftgo",
+		}},
+		Results: []*sarifResult{},
+	}
+	rules := make(map[string]bool)
+	for _, d := range diags {
+		r := &sarifResult{
+			RuleID:  d.Code,
+			Level:   toSARIFLevel(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+		}
+		if d.Code != "" && !rules[d.Code] {
+			rules[d.Code] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, &sarifRule{ID: d.Code})
+		}
+		if d.Filename != "" {
+			r.Locations = append(r.Locations, toSARIFLocation(d.Filename, d.Location, ""))
+		}
+		for _, rel := range d.Related {
+			r.RelatedLocations = append(r.RelatedLocations, toSARIFLocation(rel.Filename, rel.Location, rel.Message))
+		}
+		if d.Fix != nil && len(d.Fix.Edits) > 0 {
+			fix := &sarifFix{Description: sarifMessage{Text: d.Fix.Message}}
+			for _, e := range d.Fix.Edits {
+				fix.ArtifactChanges = append(fix.ArtifactChanges, &sarifArtifactChange{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(e.Filename)},
+					Replacements: []*sarifReplacement{{
+						DeletedRegion:   toSARIFRegion(e.Location),
+						InsertedContent: &sarifMessage{Text: e.NewText},
+					}},
+				})
+			}
+			r.Fixes = append(r.Fixes, fix)
+		}
+		run.Results = append(run.Results, r)
+	}
+	log := &sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []*sarifRun{run},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diagnostic
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func sample() parser.Diagnostics {
+	loc := &parser.Location{StartLine: 3, StartColumn: 5, EndLine: 3, EndColumn: 13}
+	prev := &parser.Location{StartLine: 2, StartColumn: 5, EndLine: 2, EndColumn: 13}
+	return parser.Diagnostics{
+		parser.NewDiagnosticf(parser.Severity_Error, "semantic", "duplicated-field-id", "a.thrift", loc,
+			"duplicated field ID %d", 1).AddRelated("a.thrift", prev, "field a uses the same ID"),
+		parser.NewDiagnosticf(parser.Severity_Warning, "go", "", "", nil, "something"),
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	test.Assert(t, Write(&buf, FormatText, sample()) == nil)
+	expected := "a.thrift:3:5: error: duplicated field ID 1 [duplicated-field-id]\n" +
+		"\ta.thrift:2:5: field a uses the same ID\n" +
+		"warning: something\n"
+	test.Assert(t, buf.String() == expected, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	test.Assert(t, Write(&buf, FormatJSON, sample()) == nil)
+	var res []map[string]interface{}
+	test.Assert(t, json.Unmarshal(buf.Bytes(), &res) == nil)
+	test.Assert(t, len(res) == 2)
+	test.Assert(t, res[0]["severity"] == "error" && res[0]["code"] == "duplicated-field-id", res[0])
+	test.Assert(t, res[1]["severity"] == "warning" && res[1]["range"] == nil, res[1])
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	test.Assert(t, Write(&buf, FormatSARIF, sample()) == nil)
+	var log sarifLog
+	test.Assert(t, json.Unmarshal(buf.Bytes(), &log) == nil)
+	test.Assert(t, log.Version == "2.1.0" && len(log.Runs) == 1)
+	rs := log.Runs[0].Results
+	test.Assert(t, len(rs) == 2)
+	test.Assert(t, rs[0].Level == "error" && rs[0].RuleID == "duplicated-field-id")
+	test.Assert(t, rs[0].Locations[0].PhysicalLocation.Region.StartLine == 3)
+	test.Assert(t, len(rs[0].RelatedLocations) == 1)
+	test.Assert(t, rs[1].Level == "warning" && len(rs[1].Locations) == 0)
+	test.Assert(t, Write(&buf, "xml", nil) != nil)
+}
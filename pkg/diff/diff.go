@@ -0,0 +1,175 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diff computes line-based unified diffs.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines around a hunk.
+const contextLines = 3
+
+type edit struct {
+	op   byte // ' ', '-' or '+'
+	line string
+}
+
+// splitLines splits the text into lines keeping the line breaks.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// maxTableSize limits the memory used to compute the longest common
+// subsequence. Larger inputs are diffed as a whole replacement of their
+// differing middle parts.
+const maxTableSize = 1 << 24
+
+// edits computes the shortest edit script from a to b with the longest
+// common subsequence. The common prefix and suffix are skipped first, so that
+// small changes in large files are cheap.
+func edits(a, b []string) []edit {
+	var prefix, suffix []edit
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		prefix = append(prefix, edit{' ', a[0]})
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		suffix = append(suffix, edit{' ', a[len(a)-1]})
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+	res := prefix
+	if (len(a)+1)*(len(b)+1) > maxTableSize {
+		for _, line := range a {
+			res = append(res, edit{'-', line})
+		}
+		for _, line := range b {
+			res = append(res, edit{'+', line})
+		}
+	} else {
+		res = append(res, lcsEdits(a, b)...)
+	}
+	for i := len(suffix) - 1; i >= 0; i-- {
+		res = append(res, suffix[i])
+	}
+	return res
+}
+
+func lcsEdits(a, b []string) []edit {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var res []edit
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			res = append(res, edit{' ', a[i]})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, edit{'-', a[i]})
+			i++
+		default:
+			res = append(res, edit{'+', b[j]})
+			j++
+		}
+	}
+	return res
+}
+
+// Unified returns the unified diff between the old and the new content of
+// the file, or an empty string if they are the same.
+func Unified(path, old, new string) string {
+	es := edits(splitLines(old), splitLines(new))
+	var sb strings.Builder
+	// line numbers of the old and the new content before es[i]
+	aLine, bLine := make([]int, len(es)+1), make([]int, len(es)+1)
+	for i, e := range es {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if e.op != '+' {
+			aLine[i+1]++
+		}
+		if e.op != '-' {
+			bLine[i+1]++
+		}
+	}
+	for i := 0; i < len(es); {
+		if es[i].op == ' ' {
+			i++
+			continue
+		}
+		// extend the hunk until there are enough unchanged lines after a change
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for k := i; k < len(es) && k-end <= 2*contextLines; k++ {
+			if es[k].op != ' ' {
+				end = k + 1
+			}
+		}
+		stop := end + contextLines
+		if stop > len(es) {
+			stop = len(es)
+		}
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", path, path)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n",
+			hunkRange(aLine[start], aLine[stop]), hunkRange(bLine[start], bLine[stop]))
+		for _, e := range es[start:stop] {
+			sb.WriteByte(e.op)
+			sb.WriteString(e.line)
+			if !strings.HasSuffix(e.line, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = stop
+	}
+	return sb.String()
+}
+
+// hunkRange formats the range of lines [from, to) in a hunk header.
+func hunkRange(from, to int) string {
+	if to-from == 1 {
+		return fmt.Sprint(from + 1)
+	}
+	if to == from {
+		return fmt.Sprintf("%d,0", from)
+	}
+	return fmt.Sprintf("%d,%d", from+1, to-from)
+}
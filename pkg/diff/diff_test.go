@@ -0,0 +1,54 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestUnified(t *testing.T) {
+	test.Assert(t, Unified("a", "x\n", "x\n") == "")
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	new := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\nfifteen"
+	test.Assert(t, Unified("a", old, new) == `--- a
++++ a
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -12,4 +12,4 @@
+ 12
+ 13
+ 14
+-15
++fifteen
+\ No newline at end of file
+`, Unified("a", old, new))
+}
+
+func TestLargeReplacement(t *testing.T) {
+	old := strings.Repeat("a\n", 5000) + "x\n"
+	new := strings.Repeat("b\n", 5000) + "x\n"
+	res := Unified("a", old, new)
+	test.Assert(t, strings.HasPrefix(res, "--- a\n+++ a\n@@ -1,5001 +1,5001 @@\n-a\n"), res[:40])
+	test.Assert(t, strings.Count(res, "\n-a") == 5000 && strings.Count(res, "\n+b") == 5000)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package format implements the fmt subcommand which rewrites thrift IDLs in
+// the canonical style. See parser.Format for the details of the style.
+package format
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/diff"
+)
+
+// Options controls what to do with the formatted content.
+type Options struct {
+	Write bool // write the result to the source file instead of stdout
+	Diff  bool // print the diff instead of the formatted content
+	List  bool // list the files whose formatting differs
+}
+
+// Run parses the arguments of the fmt subcommand and formats the files.
+func Run(args []string) error {
+	return run(args, os.Stdin, os.Stdout, os.Stderr)
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var opts Options
+	f := flag.NewFlagSet("thriftgo fmt", flag.ContinueOnError)
+	f.SetOutput(stderr)
+	f.BoolVar(&opts.Write, "w", false, "")
+	f.BoolVar(&opts.Diff, "d", false, "")
+	f.BoolVar(&opts.List, "l", false, "")
+	f.Usage = func() {
+		fmt.Fprintln(stderr, `Usage: thriftgo fmt [options] [path ...]
+Format thrift IDLs. Directories are walked for .thrift files.
+Without a path, the IDL is read from stdin.
+Options:
+  -w   Write the result to the source file instead of stdout.
+  -d   Print the diff instead of the formatted content.
+  -l   List the files whose formatting differs.`)
+	}
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+
+	if f.NArg() == 0 {
+		if opts.Write {
+			return fmt.Errorf("fmt: cannot use -w with stdin")
+		}
+		content, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		return process("<standard input>", content, &opts, stdout)
+	}
+
+	failed := 0
+	report := func(err error) {
+		fmt.Fprintln(stderr, err.Error())
+		failed++
+	}
+	for _, arg := range f.Args() {
+		err := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || path != arg && !strings.HasSuffix(path, ".thrift") {
+				return nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				report(err)
+				return nil
+			}
+			if err := process(path, content, &opts, stdout); err != nil {
+				report(err)
+			}
+			return nil
+		})
+		if err != nil {
+			report(err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("fmt: %d error(s) occurred", failed)
+	}
+	return nil
+}
+
+// process formats the content of a file and handles the result according to
+// the options.
+func process(path string, content []byte, opts *Options, stdout io.Writer) error {
+	res, err := parser.Format(path, string(content))
+	if err != nil {
+		return err
+	}
+	changed := !bytes.Equal(content, []byte(res))
+	if opts.List && changed {
+		fmt.Fprintln(stdout, path)
+	}
+	if opts.Write && changed {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(res), info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if opts.Diff && changed {
+		_, err := io.WriteString(stdout, diff.Unified(path, string(content), res))
+		return err
+	}
+	if !opts.List && !opts.Write && !opts.Diff {
+		_, err := io.WriteString(stdout, res)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+const (
+	unformatted = "struct A {\n    1: i32 a,\n    2: i32 b\n}\n"
+	formatted   = "struct A {\n    1: i32 a\n    2: i32 b\n}\n"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.thrift")
+	b := filepath.Join(dir, "sub", "b.thrift")
+	test.Assert(t, os.MkdirAll(filepath.Dir(b), 0o755) == nil)
+	test.Assert(t, os.WriteFile(a, []byte(unformatted), 0o644) == nil)
+	test.Assert(t, os.WriteFile(b, []byte(formatted), 0o644) == nil)
+	test.Assert(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("not an IDL"), 0o644) == nil)
+
+	var stdout, stderr bytes.Buffer
+	test.Assert(t, run([]string{"-l", dir}, nil, &stdout, &stderr) == nil, stderr.String())
+	test.Assert(t, stdout.String() == a+"\n", stdout.String())
+
+	stdout.Reset()
+	test.Assert(t, run([]string{"-d", a}, nil, &stdout, &stderr) == nil, stderr.String())
+	diff := stdout.String()
+	test.Assert(t, diff == "--- "+a+"\n+++ "+a+"\n@@ -1,4 +1,4 @@\n struct A {\n-    1: i32 a,\n+    1: i32 a\n     2: i32 b\n }\n", diff)
+
+	stdout.Reset()
+	test.Assert(t, run([]string{"-w", dir}, nil, &stdout, &stderr) == nil, stderr.String())
+	test.Assert(t, stdout.Len() == 0, stdout.String())
+	content, err := os.ReadFile(a)
+	test.Assert(t, err == nil && string(content) == formatted, string(content))
+
+	test.Assert(t, run(nil, strings.NewReader(unformatted), &stdout, &stderr) == nil, stderr.String())
+	test.Assert(t, stdout.String() == formatted, stdout.String())
+
+	stdout.Reset()
+	err = run(nil, strings.NewReader("struct A {"), &stdout, &stderr)
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "<standard input>:1:"), err)
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/diagnostic"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+type stringSlice []string
+
+func (ss *stringSlice) String() string {
+	return fmt.Sprintf("%v", *ss)
+}
+
+func (ss *stringSlice) Set(value string) error {
+	*ss = append(*ss, value)
+	return nil
+}
+
+// Run parses the arguments of the lint subcommand and lints the files. It
+// fails if any finding is an error.
+func Run(args []string) error {
+	return run(args, os.Stdout)
+}
+
+func run(args []string, stdout io.Writer) error {
+	var configFile, format string
+	var includeDirs []string
+	var list bool
+	f := flag.NewFlagSet("thriftgo lint", flag.ContinueOnError)
+	f.StringVar(&configFile, "c", "", "")
+	f.StringVar(&configFile, "config", "", "")
+	f.Var((*stringSlice)(&includeDirs), "i", "")
+	f.Var((*stringSlice)(&includeDirs), "include", "")
+	f.StringVar(&format, "format", diagnostic.FormatText, "")
+	f.BoolVar(&list, "list", false, "")
+	f.Usage = func() {
+		println(`Usage: thriftgo lint [options] path ...
+Check thrift IDLs with configurable rules. Directories are walked for .thrift files.
+Options:
+  -c, --config file   The YAML configuration of rules.
+                      Default: ` + strings.Join(DefaultConfigFiles, " or ") + ` in the working directory if exists.
+  -i, --include dir   Add a search path for includes.
+  --format STR        Output format: ` + strings.Join(diagnostic.Formats, ", ") + `. Default: text.
+  --list              List the rules and their default severities.`)
+	}
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	if list {
+		for _, r := range Rules() {
+			fmt.Fprintf(stdout, "%-22s %-8s %s\n", r.Name, diagnostic.SeverityName(r.Severity), r.Doc)
+		}
+		return nil
+	}
+	if f.NArg() == 0 {
+		f.Usage()
+		return flag.ErrHelp
+	}
+	if !diagnostic.IsValidFormat(format) {
+		return fmt.Errorf("unknown format %q, expecting one of: %s", format, strings.Join(diagnostic.Formats, ", "))
+	}
+
+	var cfg *Config
+	if configFile == "" {
+		for _, name := range DefaultConfigFiles {
+			if _, err := os.Stat(name); err == nil {
+				configFile = name
+				break
+			}
+		}
+	}
+	if configFile != "" {
+		var err error
+		if cfg, err = LoadConfig(configFile); err != nil {
+			return err
+		}
+	}
+	l, err := New(cfg)
+	if err != nil {
+		return err
+	}
+
+	var diags parser.Diagnostics
+	for _, arg := range f.Args() {
+		err := filepath.WalkDir(arg, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || path != arg && !strings.HasSuffix(path, ".thrift") {
+				return nil
+			}
+			ast, err := parser.ParseFile(path, includeDirs, true)
+			if err == nil {
+				err = semantic.ResolveSymbols(ast)
+			}
+			if err != nil {
+				diags = append(diags, parser.DiagnosticsOf(err, "parser")...)
+				return nil
+			}
+			diags = append(diags, l.Lint(ast)...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := diagnostic.Write(stdout, format, diags); err != nil {
+		return err
+	}
+	if n := len(diags.Filter(parser.Severity_Error)); n > 0 {
+		return fmt.Errorf("lint: %d error(s) found", n)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SeverityOff turns a rule off in the configuration.
+const SeverityOff = "off"
+
+// DefaultConfigFiles are the configuration files looked up in the working
+// directory when none is specified.
+var DefaultConfigFiles = []string{"thriftgo-lint.yml", "thriftgo-lint.yaml"}
+
+// Config configures the rules of a linter. For example:
+//
+//	rules:
+//	  required-field: off
+//	  field-id-gap:
+//	    severity: warning
+type Config struct {
+	Rules map[string]*RuleConfig `yaml:"rules"`
+}
+
+// RuleConfig configures a rule.
+type RuleConfig struct {
+	// Severity is one of error, warning, info, hint or off. An empty string
+	// keeps the default severity of the rule.
+	Severity string `yaml:"severity"`
+}
+
+// UnmarshalYAML allows a rule to be configured with its severity only.
+func (rc *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&rc.Severity)
+	}
+	type plain RuleConfig
+	return node.Decode((*plain)(rc))
+}
+
+// LoadConfig reads the configuration from the file.
+func LoadConfig(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("lint: parse %s: %w", path, err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lint implements a configurable linter for thrift IDLs. Rules are
+// registered with Register and run over a *parser.Thrift. Their severities
+// can be changed or they can be turned off with a YAML configuration, and
+// single findings can be suppressed with a "// thriftgo:nolint" comment.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+)
+
+// diagnosticSource is the source of diagnostics reported by rules.
+const diagnosticSource = "lint"
+
+// Rule is a check over a single IDL.
+type Rule struct {
+	// Name identifies the rule in configurations and suppressions. It is
+	// also the code of the diagnostics reported by the rule.
+	Name string
+	// Doc describes what the rule checks in one sentence.
+	Doc string
+	// Severity is the default severity of the findings.
+	Severity parser.Severity
+	// Run checks the IDL in the pass and reports findings with it.
+	Run func(pass *Pass)
+}
+
+// Pass is the information available to a rule when it checks an IDL.
+type Pass struct {
+	// AST is the IDL being checked. Its includes are parsed and, if the IDL
+	// is resolved by semantic.ResolveSymbols, Name2Category is set.
+	AST *parser.Thrift
+	// CST is the concrete syntax tree of the IDL. It is nil if the source of
+	// the IDL can not be read.
+	CST *parser.CSTNode
+
+	rule     *Rule
+	severity parser.Severity
+	diags    parser.Diagnostics
+}
+
+// Reportf reports a finding at the location.
+func (p *Pass) Reportf(loc *parser.Location, format string, args ...interface{}) {
+	p.diags = append(p.diags, parser.NewDiagnosticf(
+		p.severity, diagnosticSource, p.rule.Name, p.AST.Filename, loc, format, args...))
+}
+
+var registry = make(map[string]*Rule)
+
+// Register adds a rule to the rules run by linters. It panics if a rule with
+// the same name exists.
+func Register(rule *Rule) {
+	if _, ok := registry[rule.Name]; ok {
+		panic("lint: duplicated rule " + rule.Name)
+	}
+	registry[rule.Name] = rule
+}
+
+// Rules returns the registered rules sorted by name.
+func Rules() (rules []*Rule) {
+	for _, r := range registry {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return
+}
+
+// Linter runs the registered rules with their configured severities.
+type Linter struct {
+	rules      []*Rule
+	severities map[string]parser.Severity
+}
+
+// New creates a linter with the configuration. A nil configuration runs all
+// rules with their default severities.
+func New(cfg *Config) (*Linter, error) {
+	l := &Linter{severities: make(map[string]parser.Severity)}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	for name := range cfg.Rules {
+		if _, ok := registry[name]; !ok {
+			return nil, fmt.Errorf("lint: unknown rule %q", name)
+		}
+	}
+	for _, r := range Rules() {
+		severity := r.Severity
+		if rc, ok := cfg.Rules[r.Name]; ok && rc.Severity != "" {
+			if rc.Severity == SeverityOff {
+				continue
+			}
+			s, err := parseSeverity(rc.Severity)
+			if err != nil {
+				return nil, fmt.Errorf("lint: rule %q: %w", r.Name, err)
+			}
+			severity = s
+		}
+		l.rules = append(l.rules, r)
+		l.severities[r.Name] = severity
+	}
+	return l, nil
+}
+
+func parseSeverity(s string) (parser.Severity, error) {
+	for _, sev := range []parser.Severity{
+		parser.Severity_Error, parser.Severity_Warning, parser.Severity_Info, parser.Severity_Hint,
+	} {
+		if strings.EqualFold(s, sev.String()) {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q, expecting one of: error, warning, info, hint, %s", s, SeverityOff)
+}
+
+// Lint runs the rules over the IDL and returns the findings that are not
+// suppressed. Included IDLs are not checked. The source is read from the file
+// of the AST to find suppressions.
+func (l *Linter) Lint(ast *parser.Thrift) parser.Diagnostics {
+	var cst *parser.CSTNode
+	if content, err := os.ReadFile(ast.Filename); err == nil {
+		cst, _ = parser.ParseCST(ast.Filename, string(content))
+	}
+	return l.LintSource(ast, cst)
+}
+
+// LintSource is like Lint but takes the concrete syntax tree of the IDL. The
+// tree may be nil, which disables suppressions and rules that need it.
+func (l *Linter) LintSource(ast *parser.Thrift, cst *parser.CSTNode) (diags parser.Diagnostics) {
+	for _, r := range l.rules {
+		pass := &Pass{AST: ast, CST: cst, rule: r, severity: l.severities[r.Name]}
+		r.Run(pass)
+		diags = append(diags, pass.diags...)
+	}
+	if cst != nil {
+		diags = suppress(diags, cst)
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Location, diags[j].Location
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.StartLine < b.StartLine || a.StartLine == b.StartLine && a.StartColumn < b.StartColumn
+	})
+	return diags
+}
+
+var nolint = regexp.MustCompile(`thriftgo:nolint(?::([\w,-]+))?`)
+
+// suppress removes the diagnostics suppressed by "thriftgo:nolint" comments.
+// A comment at the end of a line applies to that line and a comment on its own
+// line applies to the next line. The comment applies to all rules unless the
+// rules are listed like "thriftgo:nolint:rule1,rule2".
+func suppress(diags parser.Diagnostics, cst *parser.CSTNode) (res parser.Diagnostics) {
+	tokenLines := make(map[int32]bool)
+	for _, t := range cst.Tokens() {
+		tokenLines[t.Location.StartLine] = true
+	}
+	suppressed := make(map[int32][]string) // line -> rules; an empty list for all rules
+	for _, c := range cst.FindAll(parser.CSTComment) {
+		m := nolint.FindStringSubmatch(c.Text)
+		if m == nil {
+			continue
+		}
+		line := c.Location.StartLine
+		if !tokenLines[line] {
+			line = c.Location.EndLine + 1
+		}
+		rules := []string{}
+		if m[1] != "" {
+			rules = strings.Split(m[1], ",")
+		}
+		if prev, ok := suppressed[line]; ok && (len(prev) == 0 || len(rules) == 0) {
+			rules = []string{}
+		} else {
+			rules = append(prev, rules...)
+		}
+		suppressed[line] = rules
+	}
+	for _, d := range diags {
+		if d.Location != nil {
+			if rules, ok := suppressed[d.Location.StartLine]; ok && (len(rules) == 0 || contains(rules, d.Code)) {
+				continue
+			}
+		}
+		res = append(res, d)
+	}
+	return
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+const testIDL = `include "base.thrift"
+include "unused.thrift"
+
+enum status {
+    OK = 0
+    FAILED // thriftgo:nolint:enum-explicit-value
+    UNKNOWN
+}
+
+struct Request {
+    1: required string Name
+    3: base.ID id
+    6: i32 count
+    i32 noID // thriftgo:nolint:field-name
+}
+
+// thriftgo:nolint
+struct bad_name {
+    1: i32 a
+}
+
+service Echo {
+    void echo(1: Request badArg)
+}
+`
+
+func lintFile(t *testing.T, cfg *Config) parser.Diagnostics {
+	dir := t.TempDir()
+	test.Assert(t, os.WriteFile(filepath.Join(dir, "base.thrift"), []byte("typedef i64 ID\n"), 0o644) == nil)
+	test.Assert(t, os.WriteFile(filepath.Join(dir, "unused.thrift"), []byte("const i32 X = 1\n"), 0o644) == nil)
+	path := filepath.Join(dir, "main.thrift")
+	test.Assert(t, os.WriteFile(path, []byte(testIDL), 0o644) == nil)
+	ast, err := parser.ParseFile(path, nil, true)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, semantic.ResolveSymbols(ast) == nil)
+	l, err := New(cfg)
+	test.Assert(t, err == nil, err)
+	return l.Lint(ast)
+}
+
+func findings(diags parser.Diagnostics) (res []string) {
+	for _, d := range diags {
+		res = append(res, parser.Position("", d.Location)[1:]+" "+diagnosticName(d)+" "+d.Message)
+	}
+	return
+}
+
+func diagnosticName(d *parser.Diagnostic) string {
+	return strings.ToLower(d.Severity.String()) + " " + d.Code
+}
+
+func TestLint(t *testing.T) {
+	test.DeepEqual(t, findings(lintFile(t, nil)), []string{
+		"2:1 warning unused-include include \"unused.thrift\" is not used",
+		"4:1 warning type-name enum name \"status\" should be in PascalCase",
+		"7:5 warning enum-explicit-value enum value status.UNKNOWN has no explicit value",
+		"10:1 info field-id-gap field ID 2 of struct Request is skipped",
+		"10:1 info field-id-gap field IDs 4 to 5 of struct Request are skipped",
+		"11:5 warning field-name field name \"Name\" should be in snake_case",
+		"11:5 warning required-field field Name of struct Request is required, consider making it optional",
+		"14:5 warning missing-field-id field noID has no explicit ID",
+		"22:1 info undocumented-service service Echo has no doc comment",
+		"23:15 warning field-name argument name \"badArg\" should be in snake_case",
+	})
+
+	cfg := &Config{Rules: map[string]*RuleConfig{
+		"field-id-gap":         {Severity: SeverityOff},
+		"undocumented-service": {Severity: "off"},
+		"required-field":       {Severity: "error"},
+		"field-name":           {Severity: SeverityOff},
+		"type-name":            {Severity: SeverityOff},
+		"unused-include":       {},
+	}}
+	test.DeepEqual(t, findings(lintFile(t, cfg)), []string{
+		"2:1 warning unused-include include \"unused.thrift\" is not used",
+		"7:5 warning enum-explicit-value enum value status.UNKNOWN has no explicit value",
+		"11:5 error required-field field Name of struct Request is required, consider making it optional",
+		"14:5 warning missing-field-id field noID has no explicit ID",
+	})
+
+	_, err := New(&Config{Rules: map[string]*RuleConfig{"none": {}}})
+	test.Assert(t, err != nil && strings.Contains(err.Error(), `unknown rule "none"`), err)
+	_, err = New(&Config{Rules: map[string]*RuleConfig{"type-name": {Severity: "fatal"}}})
+	test.Assert(t, err != nil && strings.Contains(err.Error(), `unknown severity "fatal"`), err)
+}
+
+func TestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lint.yaml")
+	test.Assert(t, os.WriteFile(path, []byte("rules:\n  required-field: off\n  field-id-gap:\n    severity: warning\n"), 0o644) == nil)
+	cfg, err := LoadConfig(path)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, cfg.Rules["required-field"].Severity == SeverityOff, cfg.Rules["required-field"])
+	test.Assert(t, cfg.Rules["field-id-gap"].Severity == "warning", cfg.Rules["field-id-gap"])
+
+	dir := t.TempDir()
+	idl := filepath.Join(dir, "a.thrift")
+	test.Assert(t, os.WriteFile(idl, []byte("struct A {\n    1: required i32 a\n    3: i32 b\n}\n"), 0o644) == nil)
+	var out bytes.Buffer
+	err = run([]string{"-c", path, dir}, &out)
+	test.Assert(t, err == nil, err)
+	test.Assert(t, strings.HasSuffix(out.String(), "a.thrift:1:1: warning: field ID 2 of struct A is skipped [field-id-gap]\n"), out.String())
+}
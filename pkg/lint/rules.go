@@ -0,0 +1,221 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/cloudwego/thriftgo/parser"
+)
+
+func init() {
+	for _, r := range []*Rule{
+		{
+			Name:     "type-name",
+			Doc:      "Names of structs, unions, exceptions, enums and services should be in PascalCase.",
+			Severity: parser.Severity_Warning,
+			Run:      checkTypeNames,
+		},
+		{
+			Name:     "field-name",
+			Doc:      "Names of fields and arguments should be in snake_case.",
+			Severity: parser.Severity_Warning,
+			Run:      checkFieldNames,
+		},
+		{
+			Name:     "missing-field-id",
+			Doc:      "Fields, arguments and exceptions should have explicit IDs.",
+			Severity: parser.Severity_Warning,
+			Run:      checkMissingFieldIDs,
+		},
+		{
+			Name:     "required-field",
+			Doc:      "Required fields can never be removed without breaking old readers.",
+			Severity: parser.Severity_Warning,
+			Run:      checkRequiredFields,
+		},
+		{
+			Name:     "enum-explicit-value",
+			Doc:      "Enum values should be assigned explicitly.",
+			Severity: parser.Severity_Warning,
+			Run:      checkEnumValues,
+		},
+		{
+			Name:     "undocumented-service",
+			Doc:      "Services should have a doc comment.",
+			Severity: parser.Severity_Info,
+			Run:      checkServiceDocs,
+		},
+		{
+			Name:     "unused-include",
+			Doc:      "Included IDLs should be referenced.",
+			Severity: parser.Severity_Warning,
+			Run:      checkUnusedIncludes,
+		},
+		{
+			Name:     "field-id-gap",
+			Doc:      "Field IDs in a struct should be contiguous from 1.",
+			Severity: parser.Severity_Info,
+			Run:      checkFieldIDGaps,
+		},
+	} {
+		Register(r)
+	}
+}
+
+var (
+	pascalCase = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	snakeCase  = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+)
+
+func checkTypeNames(pass *Pass) {
+	check := func(kind, name string, loc *parser.Location) {
+		if !pascalCase.MatchString(name) {
+			pass.Reportf(loc, "%s name %q should be in PascalCase", kind, name)
+		}
+	}
+	for _, s := range pass.AST.GetStructLikes() {
+		check(s.Category, s.Name, s.Location)
+	}
+	for _, e := range pass.AST.Enums {
+		check("enum", e.Name, e.Location)
+	}
+	for _, s := range pass.AST.Services {
+		check("service", s.Name, s.Location)
+	}
+}
+
+func checkFieldNames(pass *Pass) {
+	check := func(kind, name string, loc *parser.Location) {
+		if !snakeCase.MatchString(name) {
+			pass.Reportf(loc, "%s name %q should be in snake_case", kind, name)
+		}
+	}
+	for _, s := range pass.AST.GetStructLikes() {
+		for _, f := range s.Fields {
+			check("field", f.Name, f.Location)
+		}
+	}
+	for _, s := range pass.AST.Services {
+		for _, fn := range s.Functions {
+			for _, f := range fn.Arguments {
+				check("argument", f.Name, f.Location)
+			}
+		}
+	}
+}
+
+func checkMissingFieldIDs(pass *Pass) {
+	if pass.CST == nil {
+		return
+	}
+	for _, f := range pass.CST.FindAll("Field") {
+		if len(f.FindAll("FieldId")) == 0 {
+			pass.Reportf(f.Location, "%s has no explicit ID", fieldText(f))
+		}
+	}
+}
+
+// fieldText returns the name of a field in the concrete syntax tree.
+func fieldText(f *parser.CSTNode) string {
+	for _, c := range f.Children {
+		if c.Kind == "Identifier" {
+			return "field " + c.Tokens()[0].Text
+		}
+	}
+	return "field"
+}
+
+func checkRequiredFields(pass *Pass) {
+	for _, s := range pass.AST.GetStructLikes() {
+		for _, f := range s.Fields {
+			if f.Requiredness == parser.FieldType_Required {
+				pass.Reportf(f.Location, "field %s of %s %s is required, consider making it optional", f.Name, s.Category, s.Name)
+			}
+		}
+	}
+}
+
+func checkEnumValues(pass *Pass) {
+	if pass.CST == nil {
+		return
+	}
+	for _, e := range pass.CST.FindAll("Enum") {
+		// ENUM Identifier LWING (Identifier (EQUAL IntConstant)? ...)* RWING
+		var name *parser.CSTNode
+		for i, c := range e.Children {
+			if c.Kind != "Identifier" {
+				continue
+			}
+			if name == nil {
+				name = c
+				continue
+			}
+			explicit := false
+			for _, next := range e.Children[i+1:] {
+				if !next.IsTrivia() {
+					explicit = next.Kind == "EQUAL"
+					break
+				}
+			}
+			if !explicit {
+				pass.Reportf(c.Location, "enum value %s.%s has no explicit value", name.Tokens()[0].Text, c.Tokens()[0].Text)
+			}
+		}
+	}
+}
+
+func checkServiceDocs(pass *Pass) {
+	for _, s := range pass.AST.Services {
+		if s.ReservedComments == "" {
+			pass.Reportf(s.Location, "service %s has no doc comment", s.Name)
+		}
+	}
+}
+
+func checkUnusedIncludes(pass *Pass) {
+	if pass.AST.Name2Category == nil {
+		return // not resolved
+	}
+	for _, inc := range pass.AST.Includes {
+		if !inc.GetUsed() {
+			pass.Reportf(inc.Location, "include %q is not used", inc.Path)
+		}
+	}
+}
+
+func checkFieldIDGaps(pass *Pass) {
+	for _, s := range pass.AST.GetStructLikes() {
+		var ids []int
+		for _, f := range s.Fields {
+			if f.ID > 0 {
+				ids = append(ids, int(f.ID))
+			}
+		}
+		sort.Ints(ids)
+		next := 1
+		for _, id := range ids {
+			if id > next {
+				if id == next+1 {
+					pass.Reportf(s.Location, "field ID %d of %s %s is skipped", next, s.Category, s.Name)
+				} else {
+					pass.Reportf(s.Location, "field IDs %d to %d of %s %s are skipped", next, id-1, s.Category, s.Name)
+				}
+			}
+			next = id + 1
+		}
+	}
+}
@@ -0,0 +1,428 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+)
+
+// symbolAt finds the definition referred by the identifier at the position.
+func symbolAt(f *file, pos Position) (*symbol, token, bool) {
+	line, column := f.fromPosition(pos)
+	tok, ok := tokenAt(f.tokens(), line, column)
+	if !ok {
+		return nil, tok, false
+	}
+	segs := tok.segments()
+	idx := tok.segmentAt(column)
+	// resolve the segments up to the cursor so that the prefix of "base.Status"
+	// refers to the include and "Status" refers to the type
+	return resolve(f.ast, segs[:idx+1]), tok, true
+}
+
+// definition returns the location where the symbol at the position is defined.
+func (s *snapshot) definition(f *file, pos Position) []Location {
+	sym, _, _ := symbolAt(f, pos)
+	if sym == nil {
+		return nil
+	}
+	target := s.fileOf(sym.ast)
+	if target == nil {
+		return nil
+	}
+	loc := Location{URI: pathToURI(target.path)}
+	if sym.kind != kindInclude {
+		loc.Range = target.toRange(nameLocation(target.tokens(), sym))
+	}
+	return []Location{loc}
+}
+
+// references returns all places in the snapshot that refer to the symbol at the position.
+func (s *snapshot) references(f *file, pos Position, includeDeclaration bool) []Location {
+	sym, _, _ := symbolAt(f, pos)
+	if sym == nil {
+		return nil
+	}
+	var decl *parser.Location
+	if def := s.fileOf(sym.ast); def != nil && sym.kind != kindInclude {
+		decl = nameLocation(def.tokens(), sym)
+	}
+	paths := make([]string, 0, len(s.files))
+	for p := range s.files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	name := sym.shortName()
+	var res []Location
+	for _, p := range paths {
+		ff := s.files[p]
+		for _, tok := range ff.tokens() {
+			segs := tok.segments()
+			for i := range segs {
+				if segs[i] != name || !sym.same(resolve(ff.ast, segs[:i+1])) {
+					continue
+				}
+				loc := tok.segmentLocation(i)
+				if !includeDeclaration && decl != nil && ff.ast.Filename == sym.ast.Filename &&
+					*loc == *decl {
+					continue
+				}
+				res = append(res, Location{URI: pathToURI(ff.path), Range: ff.toRange(loc)})
+			}
+		}
+	}
+	return res
+}
+
+// hover describes the definition or the field at the position.
+func (s *snapshot) hover(f *file, pos Position) *Hover {
+	sym, tok, ok := symbolAt(f, pos)
+	if !ok {
+		return nil
+	}
+	var text string
+	if sym != nil {
+		text = describe(sym)
+	} else if text = describeMember(f.ast, tok); text == "" {
+		return nil
+	}
+	rng := f.toRange(&parser.Location{
+		StartLine: tok.line, StartColumn: tok.column,
+		EndLine: tok.line, EndColumn: tok.column + int32(len([]rune(tok.text))),
+	})
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: "```thrift\n" + text + "\n```"},
+		Range:    &rng,
+	}
+}
+
+// typeString formats a type as it is written in IDLs.
+func typeString(t *parser.Type) string {
+	if t == nil {
+		return ""
+	}
+	switch t.Name {
+	case "map":
+		return fmt.Sprintf("map<%s, %s>", typeString(t.KeyType), typeString(t.ValueType))
+	case "list", "set":
+		return fmt.Sprintf("%s<%s>", t.Name, typeString(t.ValueType))
+	}
+	return t.Name
+}
+
+// withComments prepends the comments to the declaration.
+func withComments(comments, decl string) string {
+	if comments = strings.TrimSpace(comments); comments != "" {
+		return comments + "\n" + decl
+	}
+	return decl
+}
+
+func fieldString(f *parser.Field) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d: ", f.ID)
+	switch f.Requiredness {
+	case parser.FieldType_Required:
+		sb.WriteString("required ")
+	case parser.FieldType_Optional:
+		sb.WriteString("optional ")
+	}
+	sb.WriteString(typeString(f.Type) + " " + f.Name)
+	return sb.String()
+}
+
+func fieldList(fs []*parser.Field) string {
+	ss := make([]string, 0, len(fs))
+	for _, f := range fs {
+		ss = append(ss, fieldString(f))
+	}
+	return strings.Join(ss, ", ")
+}
+
+func functionString(f *parser.Function) string {
+	var sb strings.Builder
+	if f.Oneway {
+		sb.WriteString("oneway ")
+	}
+	if f.Void {
+		sb.WriteString("void")
+	} else {
+		sb.WriteString(typeString(f.FunctionType))
+	}
+	fmt.Fprintf(&sb, " %s(%s)", f.Name, fieldList(f.Arguments))
+	if len(f.Throws) > 0 {
+		fmt.Fprintf(&sb, " throws (%s)", fieldList(f.Throws))
+	}
+	return sb.String()
+}
+
+// describe returns the declaration of the symbol with its comments.
+func describe(sym *symbol) string {
+	switch v := sym.node.(type) {
+	case *parser.Typedef:
+		return withComments(v.ReservedComments, fmt.Sprintf("typedef %s %s", typeString(v.Type), v.Alias))
+	case *parser.Constant:
+		return withComments(v.ReservedComments, fmt.Sprintf("const %s %s", typeString(v.Type), v.Name))
+	case *parser.Enum:
+		var sb strings.Builder
+		sb.WriteString("enum " + v.Name + " {\n")
+		for _, ev := range v.Values {
+			fmt.Fprintf(&sb, "    %s = %d\n", ev.Name, ev.Value)
+		}
+		sb.WriteString("}")
+		return withComments(v.ReservedComments, sb.String())
+	case *parser.EnumValue:
+		return withComments(v.ReservedComments, fmt.Sprintf("%s = %d", sym.name, v.Value))
+	case *parser.StructLike:
+		var sb strings.Builder
+		sb.WriteString(v.Category + " " + v.Name + " {\n")
+		for _, f := range v.Fields {
+			sb.WriteString("    " + fieldString(f) + "\n")
+		}
+		sb.WriteString("}")
+		return withComments(v.ReservedComments, sb.String())
+	case *parser.Service:
+		var sb strings.Builder
+		sb.WriteString("service " + v.Name)
+		if v.Extends != "" {
+			sb.WriteString(" extends " + v.Extends)
+		}
+		sb.WriteString(" {\n")
+		for _, f := range v.Functions {
+			sb.WriteString("    " + functionString(f) + "\n")
+		}
+		sb.WriteString("}")
+		return withComments(v.ReservedComments, sb.String())
+	}
+	return fmt.Sprintf("include %q", sym.ast.Filename)
+}
+
+// describeMember describes the field, argument or function whose name is the token.
+func describeMember(ast *parser.Thrift, tok token) string {
+	match := func(name string, loc *parser.Location) bool {
+		return name == tok.text && loc.Contains(tok.line, tok.column)
+	}
+	for _, s := range ast.GetStructLikes() {
+		for _, f := range s.Fields {
+			if match(f.Name, f.Location) {
+				return withComments(f.ReservedComments, fmt.Sprintf("%s (in %s %s)", fieldString(f), s.Category, s.Name))
+			}
+		}
+	}
+	for _, svc := range ast.Services {
+		for _, fn := range svc.Functions {
+			for _, f := range append(append([]*parser.Field{}, fn.Arguments...), fn.Throws...) {
+				if match(f.Name, f.Location) {
+					return withComments(f.ReservedComments, fmt.Sprintf("%s (in %s.%s)", fieldString(f), svc.Name, fn.Name))
+				}
+			}
+			if match(fn.Name, fn.Location) {
+				return withComments(fn.ReservedComments, functionString(fn))
+			}
+		}
+	}
+	return ""
+}
+
+// baseTypes are the keywords that can be used as types.
+var baseTypes = []string{
+	"bool", "byte", "i8", "i16", "i32", "i64", "double", "string", "binary", "uuid",
+	"list", "set", "map", "void",
+}
+
+// knownAnnotations are the annotation keys recognized by thriftgo.
+var knownAnnotations = []string{
+	"go.tag", "cpp.template", "thrift.nested", "thrift.is_alias", "thrift.is_interface",
+}
+
+// funcDecl matches the part of a function declaration before its argument list.
+var funcDecl = regexp.MustCompile(`^\s*(oneway\s+)?([\w.]+|(list|set|map)\s*<.*>)\s+\w+\s*$`)
+
+// annotationList matches the content of an annotation list that is being written.
+var annotationList = regexp.MustCompile(`^(\s*[\w.]+\s*=\s*("[^"]*"|'[^']*')\s*[,;]?)*\s*[\w.]*$`)
+
+// inAnnotations tells whether the text before the cursor ends in an unclosed annotation list.
+func inAnnotations(before string) bool {
+	open := strings.LastIndex(before, "(")
+	if open < 0 || strings.Contains(before[open:], ")") {
+		return false
+	}
+	lineStart := strings.LastIndex(before[:open], "\n") + 1
+	decl := before[lineStart:open]
+	if funcDecl.MatchString(decl) && !strings.HasPrefix(strings.TrimSpace(decl), "typedef") {
+		return false
+	}
+	return annotationList.MatchString(before[open+1:])
+}
+
+func completionKind(kind string) int {
+	switch kind {
+	case kindInclude:
+		return completionKindModule
+	case kindTypedef:
+		return completionKindClass
+	case kindConstant:
+		return completionKindConstant
+	case kindEnum:
+		return completionKindEnum
+	case kindEnumValue:
+		return completionKindEnumMember
+	case kindService:
+		return completionKindInterface
+	}
+	return completionKindStruct
+}
+
+// definitions lists the symbols defined in the AST.
+func definitions(ast *parser.Thrift) (res []*symbol) {
+	add := func(kind, name string, node interface{}) {
+		res = append(res, &symbol{ast: ast, kind: kind, name: name, node: node})
+	}
+	for _, v := range ast.Typedefs {
+		add(kindTypedef, v.Alias, v)
+	}
+	for _, v := range ast.Constants {
+		add(kindConstant, v.Name, v)
+	}
+	for _, v := range ast.Enums {
+		add(kindEnum, v.Name, v)
+	}
+	for _, v := range ast.GetStructLikes() {
+		add(v.Category, v.Name, v)
+	}
+	for _, v := range ast.Services {
+		add(kindService, v.Name, v)
+	}
+	return
+}
+
+func symbolItem(label string, sym *symbol) CompletionItem {
+	return CompletionItem{Label: label, Kind: completionKind(sym.kind), Detail: sym.kind, Documentation: describe(sym)}
+}
+
+// completion suggests annotation keys inside annotation lists and type names elsewhere.
+func (s *snapshot) completion(f *file, pos Position) *CompletionList {
+	off := f.offset(pos)
+	before := f.text[:off]
+	res := &CompletionList{Items: []CompletionItem{}}
+	if inAnnotations(before) {
+		for _, key := range s.annotationKeys() {
+			res.Items = append(res.Items, CompletionItem{Label: key, Kind: completionKindProperty})
+		}
+		return res
+	}
+
+	word := before[strings.LastIndexFunc(before, func(r rune) bool { return !isIdentPart(r) })+1:]
+	if idx := strings.LastIndex(word, "."); idx >= 0 {
+		// complete the members of an include or an enum
+		segs := strings.Split(word[:idx], ".")
+		if ref, ok := f.ast.GetReference(segs[0]); ok && len(segs) == 1 {
+			for _, sym := range definitions(ref) {
+				res.Items = append(res.Items, symbolItem(sym.name, sym))
+			}
+		}
+		if sym := resolve(f.ast, segs); sym != nil && sym.kind == kindEnum {
+			for _, v := range sym.node.(*parser.Enum).Values {
+				res.Items = append(res.Items, CompletionItem{Label: v.Name, Kind: completionKindEnumMember})
+			}
+		}
+		return res
+	}
+
+	for _, t := range baseTypes {
+		res.Items = append(res.Items, CompletionItem{Label: t, Kind: completionKindKeyword})
+	}
+	for _, sym := range definitions(f.ast) {
+		res.Items = append(res.Items, symbolItem(sym.name, sym))
+	}
+	for _, inc := range f.ast.Includes {
+		if inc.Reference == nil {
+			continue
+		}
+		base := filepath.Base(inc.Path)
+		prefix := strings.TrimSuffix(base, filepath.Ext(base))
+		res.Items = append(res.Items, CompletionItem{Label: prefix, Kind: completionKindModule, Detail: inc.Path})
+		for _, sym := range definitions(inc.Reference) {
+			res.Items = append(res.Items, symbolItem(prefix+"."+sym.name, sym))
+		}
+	}
+	return res
+}
+
+// annotationKeys returns the known annotation keys and those used in the snapshot.
+func (s *snapshot) annotationKeys() []string {
+	keys := make(map[string]bool)
+	for _, k := range knownAnnotations {
+		keys[k] = true
+	}
+	add := func(as parser.Annotations) {
+		for _, a := range as {
+			keys[a.Key] = true
+		}
+	}
+	var addType func(t *parser.Type)
+	addType = func(t *parser.Type) {
+		if t != nil {
+			add(t.Annotations)
+			addType(t.KeyType)
+			addType(t.ValueType)
+		}
+	}
+	addFields := func(fs []*parser.Field) {
+		for _, f := range fs {
+			add(f.Annotations)
+			addType(f.Type)
+		}
+	}
+	for _, f := range s.files {
+		ast := f.ast
+		for _, v := range ast.Typedefs {
+			add(v.Annotations)
+			addType(v.Type)
+		}
+		for _, v := range ast.Enums {
+			add(v.Annotations)
+			for _, ev := range v.Values {
+				add(ev.Annotations)
+			}
+		}
+		for _, v := range ast.GetStructLikes() {
+			add(v.Annotations)
+			addFields(v.Fields)
+		}
+		for _, v := range ast.Services {
+			add(v.Annotations)
+			for _, fn := range v.Functions {
+				add(fn.Annotations)
+				addType(fn.FunctionType)
+				addFields(fn.Arguments)
+				addFields(fn.Throws)
+			}
+		}
+	}
+	res := make([]string, 0, len(keys))
+	for k := range keys {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,403 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lsp implements a language server for thrift IDLs. It speaks the
+// Language Server Protocol over stdio and provides diagnostics, go to
+// definition, find references, hover and completion.
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/version"
+)
+
+// Options controls the behavior of the server.
+type Options struct {
+	// IncludeDirs are the search paths for includes in addition to the
+	// directory of the including file.
+	IncludeDirs []string
+}
+
+// Server is a language server for thrift IDLs. It handles messages one by one
+// and is not safe for concurrent use.
+type Server struct {
+	opts      Options
+	conn      *conn
+	roots     []string          // the workspace folders
+	docs      map[string]string // the content of open documents
+	snap      *snapshot         // the snapshot of open documents
+	published map[string]bool   // files that have diagnostics published
+	shutdown  bool
+}
+
+// NewServer creates a server.
+func NewServer(opts Options) *Server {
+	return &Server{
+		opts:      opts,
+		docs:      make(map[string]string),
+		published: make(map[string]bool),
+	}
+}
+
+// Run parses the arguments of the lsp subcommand and serves on stdio.
+func Run(args []string) error {
+	var opts Options
+	f := flag.NewFlagSet("thriftgo lsp", flag.ContinueOnError)
+	f.Var((*stringSlice)(&opts.IncludeDirs), "i", "")
+	f.Var((*stringSlice)(&opts.IncludeDirs), "include", "")
+	f.Usage = func() {
+		println(`Usage: thriftgo lsp [options]
+Start a language server for thrift IDLs that communicates over stdio.
+Options:
+  -i, --include dir   Add a search path for includes.`)
+	}
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	return NewServer(opts).Serve(os.Stdin, os.Stdout)
+}
+
+type stringSlice []string
+
+func (ss *stringSlice) String() string {
+	return fmt.Sprintf("%v", *ss)
+}
+
+func (ss *stringSlice) Set(value string) error {
+	abs, err := filepath.Abs(value)
+	if err != nil {
+		return err
+	}
+	*ss = append(*ss, abs)
+	return nil
+}
+
+// errExit is returned by handlers when the client asks the server to exit.
+var errExit = errors.New("exit")
+
+// Serve reads requests from r and writes responses to w until the client
+// sends the exit notification or r is closed.
+func (s *Server) Serve(r io.Reader, w io.Writer) error {
+	s.conn = newConn(r, w)
+	for {
+		msg, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			var re *rpcError
+			if errors.As(err, &re) {
+				if err := s.conn.reply(nil, nil, re); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+		result, err := s.handle(msg)
+		if err == errExit {
+			if !s.shutdown {
+				return errors.New("lsp: exit without shutdown")
+			}
+			return nil
+		}
+		if msg.ID == nil {
+			// notifications have no response
+			continue
+		}
+		if err := s.conn.reply(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) (interface{}, error) {
+	decode := func(v interface{}) error {
+		if err := json.Unmarshal(msg.Params, v); err != nil {
+			return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return s.initialize(&params), nil
+	case "initialized":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "exit":
+		return nil, errExit
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return nil, s.didOpen(&params)
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return nil, s.didChange(&params)
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return nil, s.didClose(&params)
+	case "textDocument/didSave", "workspace/didChangeWatchedFiles":
+		return nil, s.update()
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		snap, f, err := s.fileOf(params.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return snap.definition(f, params.Position), nil
+	case "textDocument/references":
+		var params ReferenceParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return s.references(&params)
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		snap, f, err := s.fileOf(params.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return snap.hover(f, params.Position), nil
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		snap, f, err := s.fileOf(params.TextDocument.URI)
+		if err != nil {
+			return nil, err
+		}
+		return snap.completion(f, params.Position), nil
+	}
+	if msg.ID == nil {
+		// unknown notifications like "$/cancelRequest" are ignored
+		return nil, nil
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+func (s *Server) initialize(params *InitializeParams) *InitializeResult {
+	for _, f := range params.WorkspaceFolders {
+		if p, err := uriToPath(f.URI); err == nil {
+			s.roots = append(s.roots, p)
+		}
+	}
+	if len(s.roots) == 0 && params.RootURI != "" {
+		if p, err := uriToPath(params.RootURI); err == nil {
+			s.roots = append(s.roots, p)
+		}
+	}
+	if opts := params.InitializationOptions; opts != nil {
+		for _, dir := range opts.IncludeDirs {
+			if !filepath.IsAbs(dir) && len(s.roots) > 0 {
+				dir = filepath.Join(s.roots[0], dir)
+			}
+			s.opts.IncludeDirs = append(s.opts.IncludeDirs, dir)
+		}
+	}
+	return &InitializeResult{
+		Capabilities: ServerCapabilities{
+			TextDocumentSync:   syncFull,
+			DefinitionProvider: true,
+			ReferencesProvider: true,
+			HoverProvider:      true,
+			CompletionProvider: &CompletionOptions{TriggerCharacters: []string{".", "("}},
+		},
+		ServerInfo: ServerInfo{Name: "thriftgo", Version: version.ThriftgoVersion},
+	}
+}
+
+func (s *Server) didOpen(params *DidOpenTextDocumentParams) error {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	s.docs[path] = params.TextDocument.Text
+	return s.update()
+}
+
+func (s *Server) didChange(params *DidChangeTextDocumentParams) error {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	text := s.docs[path]
+	for _, c := range params.ContentChanges {
+		text = applyChange(text, c)
+	}
+	s.docs[path] = text
+	return s.update()
+}
+
+func (s *Server) didClose(params *DidCloseTextDocumentParams) error {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return err
+	}
+	delete(s.docs, path)
+	return s.update()
+}
+
+// newSnapshot parses the open documents and the given files.
+func (s *Server) newSnapshot(extra ...string) *snapshot {
+	snap := newSnapshot(s.docs, s.opts.IncludeDirs)
+	paths := make([]string, 0, len(s.docs))
+	for p := range s.docs {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range append(paths, extra...) {
+		snap.addRoot(p)
+	}
+	return snap
+}
+
+// update rebuilds the snapshot of open documents and publishes diagnostics.
+func (s *Server) update() error {
+	s.snap = s.newSnapshot()
+	diags := s.snap.diagnose()
+	for path := range s.published {
+		if _, ok := diags[path]; !ok {
+			diags[path] = nil
+		}
+	}
+	paths := make([]string, 0, len(diags))
+	for p := range diags {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		f := s.snap.files[path]
+		params := &PublishDiagnosticsParams{URI: pathToURI(path), Diagnostics: []Diagnostic{}}
+		for _, d := range diags[path] {
+			params.Diagnostics = append(params.Diagnostics, s.toDiagnostic(f, d))
+		}
+		if err := s.conn.notify("textDocument/publishDiagnostics", params); err != nil {
+			return err
+		}
+		if len(params.Diagnostics) > 0 {
+			s.published[path] = true
+		} else {
+			delete(s.published, path)
+		}
+	}
+	return nil
+}
+
+func (s *Server) toDiagnostic(f *file, d *parser.Diagnostic) Diagnostic {
+	res := Diagnostic{
+		Severity: int(d.Severity) + 1, // LSP severities start from 1 in the same order
+		Code:     d.Code,
+		Source:   d.Source,
+		Message:  d.Message,
+	}
+	if f != nil {
+		res.Range = f.toRange(d.Location)
+	}
+	for _, r := range d.Related {
+		loc := Location{URI: pathToURI(r.Filename)}
+		if rf := s.snap.files[r.Filename]; rf != nil {
+			loc.Range = rf.toRange(r.Location)
+		}
+		res.RelatedInformation = append(res.RelatedInformation, DiagnosticRelatedInformation{
+			Location: loc, Message: r.Message,
+		})
+	}
+	return res
+}
+
+// fileOf returns the parsed file of the URI in the snapshot of open documents.
+func (s *Server) fileOf(uri string) (*snapshot, *file, error) {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return nil, nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	if s.snap == nil {
+		s.snap = s.newSnapshot()
+	}
+	f := s.snap.files[path]
+	if f == nil {
+		if f = s.snap.addRoot(path); f == nil {
+			return nil, nil, &rpcError{Code: codeInvalidParams, Message: "unknown document: " + uri}
+		}
+	}
+	return s.snap, f, nil
+}
+
+// references searches all IDLs in the workspace besides the open documents.
+func (s *Server) references(params *ReferenceParams) (interface{}, error) {
+	snap := s.newSnapshot(s.workspaceFiles()...)
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	f := snap.files[path]
+	if f == nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown document: " + params.TextDocument.URI}
+	}
+	return snap.references(f, params.Position, params.Context.IncludeDeclaration), nil
+}
+
+// workspaceFiles lists the thrift files in the workspace folders.
+func (s *Server) workspaceFiles() (res []string) {
+	for _, root := range s.roots {
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if path != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(d.Name(), ".thrift") {
+				res = append(res, path)
+			}
+			return nil
+		})
+	}
+	return
+}
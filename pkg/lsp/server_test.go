@@ -0,0 +1,236 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+const testBase = `namespace go base
+
+// Status of a request.
+enum Status {
+    OK = 0
+    FAILED = 1
+}
+
+struct Base {
+    1: string LogID
+}
+`
+
+const testMain = `include "base.thrift"
+
+struct Request {
+    // the status of the last call
+    1: base.Status status = base.Status.OK
+    2: optional base.Base Base (go.tag = "json:\"base\"")
+    3: Missing m
+}
+
+service S {
+    Request echo(1: Request req)
+}
+`
+
+type session struct {
+	in  bytes.Buffer
+	out []*message
+	id  int
+}
+
+func (s *session) send(method string, params interface{}, isRequest bool) int {
+	msg := &message{Method: method}
+	msg.Params, _ = json.Marshal(params)
+	if isRequest {
+		s.id++
+		id := json.RawMessage(fmtInt(s.id))
+		msg.ID = &id
+	}
+	c := newConn(nil, &s.in)
+	_ = c.write(msg)
+	return s.id
+}
+
+func fmtInt(i int) string {
+	bs, _ := json.Marshal(i)
+	return string(bs)
+}
+
+func (s *session) run(t *testing.T) {
+	var out bytes.Buffer
+	err := NewServer(Options{}).Serve(&s.in, &out)
+	test.Assert(t, err == nil, err)
+	c := newConn(&out, nil)
+	for {
+		msg, err := c.read()
+		if err == io.EOF {
+			break
+		}
+		test.Assert(t, err == nil, err)
+		s.out = append(s.out, msg)
+	}
+}
+
+func (s *session) result(t *testing.T, id int, v interface{}) {
+	for _, msg := range s.out {
+		if msg.ID != nil && string(*msg.ID) == fmtInt(id) {
+			test.Assert(t, msg.Error == nil, msg.Error)
+			test.Assert(t, json.Unmarshal(msg.Result, v) == nil)
+			return
+		}
+	}
+	t.Fatalf("no response for request %d", id)
+}
+
+func (s *session) diagnostics(uri string) (res []Diagnostic) {
+	for _, msg := range s.out {
+		if msg.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var params PublishDiagnosticsParams
+		_ = json.Unmarshal(msg.Params, &params)
+		if params.URI == uri {
+			res = params.Diagnostics
+		}
+	}
+	return
+}
+
+func at(uri string, line, character int) TextDocumentPositionParams {
+	return TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		Position:     Position{Line: line, Character: character},
+	}
+}
+
+func TestServer(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.thrift")
+	mainPath := filepath.Join(dir, "main.thrift")
+	test.Assert(t, os.WriteFile(basePath, []byte(testBase), 0o644) == nil)
+	test.Assert(t, os.WriteFile(mainPath, []byte("// placeholder\n"), 0o644) == nil)
+	baseURI, mainURI := pathToURI(basePath), pathToURI(mainPath)
+
+	var s session
+	s.send("initialize", &InitializeParams{RootURI: pathToURI(dir)}, true)
+	s.send("initialized", struct{}{}, false)
+	// the unsaved content takes precedence over the file on disk
+	s.send("textDocument/didOpen", &DidOpenTextDocumentParams{TextDocument: TextDocumentItem{
+		URI: mainURI, LanguageID: "thrift", Text: testMain,
+	}}, false)
+	defID := s.send("textDocument/definition", at(mainURI, 4, 13), true)
+	incID := s.send("textDocument/definition", at(mainURI, 4, 8), true)
+	valID := s.send("textDocument/definition", at(mainURI, 4, 41), true)
+	refs := &ReferenceParams{TextDocumentPositionParams: at(baseURI, 3, 6)}
+	refs.Context.IncludeDeclaration = true
+	refID := s.send("textDocument/references", refs, true)
+	hoverTypeID := s.send("textDocument/hover", at(mainURI, 4, 14), true)
+	hoverFieldID := s.send("textDocument/hover", at(mainURI, 4, 24), true)
+	typeID := s.send("textDocument/completion", at(mainURI, 6, 7), true)
+	selID := s.send("textDocument/completion", at(mainURI, 5, 21), true)
+	annoID := s.send("textDocument/completion", at(mainURI, 5, 34), true)
+	s.send("shutdown", nil, true)
+	s.send("exit", nil, false)
+	s.run(t)
+
+	var init InitializeResult
+	s.result(t, 1, &init)
+	test.Assert(t, init.Capabilities.DefinitionProvider && init.Capabilities.HoverProvider)
+
+	diags := s.diagnostics(mainURI)
+	test.Assert(t, len(diags) == 1, diags)
+	test.Assert(t, strings.Contains(diags[0].Message, `undefined type: "Missing"`), diags[0].Message)
+	test.Assert(t, diags[0].Range.Start.Line == 6, diags[0].Range)
+
+	var locs []Location
+	s.result(t, defID, &locs)
+	test.DeepEqual(t, locs, []Location{{URI: baseURI, Range: Range{Start: Position{3, 5}, End: Position{3, 11}}}})
+	s.result(t, incID, &locs)
+	test.DeepEqual(t, locs, []Location{{URI: baseURI}})
+	s.result(t, valID, &locs)
+	test.DeepEqual(t, locs, []Location{{URI: baseURI, Range: Range{Start: Position{4, 4}, End: Position{4, 6}}}})
+
+	s.result(t, refID, &locs)
+	test.Assert(t, len(locs) == 3, locs)
+	test.DeepEqual(t, locs[0], Location{URI: baseURI, Range: Range{Start: Position{3, 5}, End: Position{3, 11}}})
+	test.DeepEqual(t, locs[1], Location{URI: mainURI, Range: Range{Start: Position{4, 12}, End: Position{4, 18}}})
+	test.DeepEqual(t, locs[2], Location{URI: mainURI, Range: Range{Start: Position{4, 33}, End: Position{4, 39}}})
+
+	var hover Hover
+	s.result(t, hoverTypeID, &hover)
+	test.Assert(t, strings.Contains(hover.Contents.Value, "// Status of a request.\nenum Status {"), hover.Contents.Value)
+	s.result(t, hoverFieldID, &hover)
+	test.Assert(t, strings.Contains(hover.Contents.Value,
+		"// the status of the last call\n1: base.Status status (in struct Request)"), hover.Contents.Value)
+
+	labels := func(id int) map[string]bool {
+		var list CompletionList
+		s.result(t, id, &list)
+		res := make(map[string]bool)
+		for _, item := range list.Items {
+			res[item.Label] = true
+		}
+		return res
+	}
+	types := labels(typeID)
+	test.Assert(t, types["i32"] && types["Request"] && types["base.Base"] && types["base"], types)
+	sel := labels(selID)
+	test.Assert(t, sel["Base"] && sel["Status"] && !sel["i32"], sel)
+	annos := labels(annoID)
+	test.Assert(t, annos["go.tag"] && !annos["i32"], annos)
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	dir := t.TempDir()
+	uri := pathToURI(filepath.Join(dir, "a.thrift"))
+	var s session
+	s.send("initialize", &InitializeParams{}, true)
+	s.send("textDocument/didOpen", &DidOpenTextDocumentParams{TextDocument: TextDocumentItem{
+		URI: uri, Text: "include \"none.thrift\"\nstruct A {\n  1: i32\n}\nstruct B {}\n",
+	}}, false)
+	s.send("textDocument/didChange", &DidChangeTextDocumentParams{
+		TextDocument: TextDocumentIdentifier{URI: uri},
+		ContentChanges: []TextDocumentContentChangeEvent{{
+			Range: &Range{Start: Position{0, 0}, End: Position{1, 0}},
+			Text:  "",
+		}},
+	}, false)
+	s.send("shutdown", nil, true)
+	s.send("exit", nil, false)
+	s.run(t)
+
+	var published [][]Diagnostic
+	for _, msg := range s.out {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var params PublishDiagnosticsParams
+			test.Assert(t, json.Unmarshal(msg.Params, &params) == nil)
+			published = append(published, params.Diagnostics)
+		}
+	}
+	test.Assert(t, len(published) == 2, published)
+	test.Assert(t, len(published[0]) == 2, published[0])
+	test.Assert(t, published[0][0].Code == "syntax-error" && published[0][0].Range.Start.Line == 3, published[0][0])
+	test.Assert(t, published[0][1].Code == CodeIncludeNotFound, published[0][1])
+	test.Assert(t, len(published[1]) == 1 && published[1][0].Range.Start.Line == 2, published[1])
+}
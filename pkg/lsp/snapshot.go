@@ -0,0 +1,296 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+const diagnosticSource = "thriftls"
+
+// CodeIncludeNotFound is the code of diagnostics reported for includes that can not be found.
+const CodeIncludeNotFound = "include-not-found"
+
+// file is a parsed IDL in a snapshot.
+type file struct {
+	path  string
+	text  string
+	lines []string
+	ast   *parser.Thrift
+	diags parser.Diagnostics
+	toks  []token
+}
+
+// tokens returns the identifiers in the file.
+func (f *file) tokens() []token {
+	if f.toks == nil {
+		f.toks = tokenize(f.text)
+	}
+	return f.toks
+}
+
+// snapshot is the result of parsing a set of IDLs and their includes.
+// Open documents take precedence over the content on disk.
+type snapshot struct {
+	overlay     map[string]string
+	includeDirs []string
+	files       map[string]*file
+	roots       []*file
+}
+
+func newSnapshot(overlay map[string]string, includeDirs []string) *snapshot {
+	return &snapshot{
+		overlay:     overlay,
+		includeDirs: includeDirs,
+		files:       make(map[string]*file),
+	}
+}
+
+// addRoot parses the file and its includes recursively.
+func (s *snapshot) addRoot(path string) *file {
+	f := s.parse(path)
+	if f != nil {
+		s.roots = append(s.roots, f)
+	}
+	return f
+}
+
+// parse parses the file at the absolute path. Unlike parser.ParseFile, it
+// keeps going when an include can not be found.
+func (s *snapshot) parse(path string) *file {
+	if f, ok := s.files[path]; ok {
+		return f
+	}
+	text, ok := s.overlay[path]
+	if !ok {
+		bs, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		text = string(bs)
+	}
+	f := &file{path: path, text: text, lines: strings.Split(text, "\n")}
+	s.files[path] = f
+
+	ast, err := parser.ParseString(path, text)
+	f.diags = parser.DiagnosticsOf(err, "parser")
+	if ast == nil {
+		ast = &parser.Thrift{Filename: path}
+	}
+	f.ast = ast
+	for _, inc := range ast.Includes {
+		if p, ok := s.search(inc.Path, filepath.Dir(path)); ok {
+			if it := s.parse(p); it != nil {
+				inc.Reference = it.ast
+				continue
+			}
+		}
+		f.diags = append(f.diags, parser.NewDiagnosticf(parser.Severity_Error, diagnosticSource,
+			CodeIncludeNotFound, path, inc.Location, "include %q not found", inc.Path))
+	}
+	return f
+}
+
+// search looks for an included file in the same way as the parser does.
+func (s *snapshot) search(name, dir string) (string, bool) {
+	candidates := []string{filepath.Join(dir, name)}
+	if filepath.IsAbs(name) {
+		candidates = []string{name}
+	}
+	for _, inc := range s.includeDirs {
+		candidates = append(candidates, filepath.Join(inc, name))
+	}
+	for _, p := range candidates {
+		p = filepath.Clean(p)
+		if _, ok := s.overlay[p]; ok {
+			return p, true
+		}
+		if fi, err := os.Stat(p); err == nil && !fi.IsDir() {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// fileOf returns the file that the AST is parsed from.
+func (s *snapshot) fileOf(ast *parser.Thrift) *file {
+	if ast == nil {
+		return nil
+	}
+	return s.files[ast.Filename]
+}
+
+// diagnose runs the semantic checks and the symbol resolution on the roots
+// and returns the diagnostics of all files.
+func (s *snapshot) diagnose() map[string]parser.Diagnostics {
+	res := make(map[string]parser.Diagnostics)
+	seen := make(map[string]bool)
+	add := func(d *parser.Diagnostic) {
+		key := d.Error()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		res[d.Filename] = append(res[d.Filename], d)
+	}
+	for _, f := range s.files {
+		for _, d := range f.diags {
+			add(d)
+		}
+	}
+	checker := semantic.NewDiagnosticChecker(semantic.Options{})
+	for _, root := range s.roots {
+		if path := parser.CircleDetect(root.ast); len(path) > 0 {
+			add(parser.NewDiagnosticf(parser.Severity_Error, diagnosticSource, "include-circle",
+				root.path, nil, "found include circle:\n\t%s", path))
+			continue
+		}
+		for _, d := range checker.Diagnose(root.ast) {
+			add(d)
+		}
+		if !s.complete(root.ast) {
+			continue
+		}
+		if err := semantic.ResolveSymbols(root.ast); err != nil {
+			for _, d := range parser.DiagnosticsOf(err, "semantic") {
+				if d.Filename == "" {
+					d.Filename = root.path
+				}
+				add(d)
+			}
+		}
+	}
+	return res
+}
+
+// complete tells whether all includes of the AST have been parsed.
+func (s *snapshot) complete(ast *parser.Thrift) bool {
+	for t := range ast.DepthFirstSearch() {
+		for _, inc := range t.Includes {
+			if inc.Reference == nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lineText returns the text of the 1-based line.
+func (f *file) lineText(line int32) string {
+	if f == nil || line < 1 || int(line) > len(f.lines) {
+		return ""
+	}
+	return strings.TrimSuffix(f.lines[line-1], "\r")
+}
+
+// toPosition converts a 1-based line and rune column into an LSP position.
+func (f *file) toPosition(line, column int32) Position {
+	if line < 1 {
+		return Position{}
+	}
+	rs := []rune(f.lineText(line))
+	col := int(column) - 1
+	if col < 0 {
+		col = 0
+	}
+	if col > len(rs) {
+		col = len(rs)
+	}
+	return Position{Line: int(line) - 1, Character: len(utf16.Encode(rs[:col]))}
+}
+
+// fromPosition converts an LSP position into a 1-based line and rune column.
+func (f *file) fromPosition(pos Position) (line, column int32) {
+	rs := []rune(f.lineText(int32(pos.Line + 1)))
+	units := 0
+	col := 0
+	for col < len(rs) && units < pos.Character {
+		units += len(utf16.Encode(rs[col : col+1]))
+		col++
+	}
+	return int32(pos.Line + 1), int32(col + 1)
+}
+
+// toRange converts a location into an LSP range. A nil location is mapped to
+// the beginning of the file.
+func (f *file) toRange(loc *parser.Location) Range {
+	if loc == nil {
+		return Range{}
+	}
+	return Range{
+		Start: f.toPosition(loc.StartLine, loc.StartColumn),
+		End:   f.toPosition(loc.EndLine, loc.EndColumn),
+	}
+}
+
+// offset converts an LSP position into a byte offset in the text.
+func (f *file) offset(pos Position) int {
+	if pos.Line >= len(f.lines) {
+		return len(f.text)
+	}
+	off := 0
+	for i := 0; i < pos.Line; i++ {
+		off += len(f.lines[i]) + 1
+	}
+	_, col := f.fromPosition(pos)
+	return off + len(string([]rune(f.lines[pos.Line])[:col-1]))
+}
+
+// applyChange applies a content change to the text.
+func applyChange(text string, change TextDocumentContentChangeEvent) string {
+	if change.Range == nil {
+		return change.Text
+	}
+	f := &file{text: text, lines: strings.Split(text, "\n")}
+	begin, end := f.offset(change.Range.Start), f.offset(change.Range.End)
+	if begin > end {
+		begin, end = end, begin
+	}
+	return text[:begin] + change.Text + text[end:]
+}
+
+// uriToPath converts a file URI into an absolute path.
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme %q", u.Scheme)
+	}
+	p := u.Path
+	if runtime.GOOS == "windows" {
+		p = strings.TrimPrefix(p, "/")
+	}
+	return filepath.Clean(filepath.FromSlash(p)), nil
+}
+
+// pathToURI converts an absolute path into a file URI.
+func pathToURI(path string) string {
+	p := filepath.ToSlash(path)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lsp
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/thriftgo/parser"
+)
+
+// token is an identifier in an IDL, possibly with selectors like "base.Status.OK".
+// Line and column are 1-based and the column counts runes.
+type token struct {
+	text   string
+	line   int32
+	column int32
+}
+
+// segments splits the token by dots.
+func (t token) segments() []string {
+	return strings.Split(t.text, ".")
+}
+
+// segmentAt returns the index of the segment at the column.
+func (t token) segmentAt(column int32) int {
+	rs := []rune(t.text)
+	offset := int(column - t.column)
+	if offset > len(rs) {
+		offset = len(rs)
+	}
+	return strings.Count(string(rs[:offset]), ".")
+}
+
+// segmentLocation returns the location of the segment with the index.
+func (t token) segmentLocation(idx int) *parser.Location {
+	segs := t.segments()
+	begin := t.column
+	for _, s := range segs[:idx] {
+		begin += int32(len([]rune(s))) + 1
+	}
+	return &parser.Location{
+		StartLine: t.line, StartColumn: begin,
+		EndLine: t.line, EndColumn: begin + int32(len([]rune(segs[idx]))),
+	}
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// tokenize returns the identifiers in the text. Comments, literals and numbers are skipped.
+func tokenize(text string) (res []token) {
+	rs := []rune(text)
+	line, column := int32(1), int32(1)
+	advance := func(i int) int {
+		if rs[i] == '\n' {
+			line, column = line+1, 1
+		} else {
+			column++
+		}
+		return i + 1
+	}
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case r == '#' || r == '/' && i+1 < len(rs) && rs[i+1] == '/':
+			for i < len(rs) && rs[i] != '\n' {
+				i = advance(i)
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			i = advance(advance(i))
+			for i < len(rs) && !(rs[i] == '*' && i+1 < len(rs) && rs[i+1] == '/') {
+				i = advance(i)
+			}
+			if i < len(rs) {
+				i = advance(advance(i))
+			}
+		case r == '"' || r == '\'':
+			i = advance(i)
+			for i < len(rs) && rs[i] != r && rs[i] != '\n' {
+				i = advance(i)
+			}
+			if i < len(rs) && rs[i] == r {
+				i = advance(i)
+			}
+		case isIdentStart(r):
+			tok := token{line: line, column: column}
+			begin := i
+			for i < len(rs) && isIdentPart(rs[i]) {
+				i = advance(i)
+			}
+			tok.text = strings.TrimRight(string(rs[begin:i]), ".")
+			res = append(res, tok)
+		case unicode.IsDigit(r):
+			for i < len(rs) && isIdentPart(rs[i]) {
+				i = advance(i)
+			}
+		default:
+			i = advance(i)
+		}
+	}
+	return
+}
+
+// tokenAt returns the identifier at the 1-based line and column.
+// A position right after an identifier is considered a part of it.
+func tokenAt(toks []token, line, column int32) (token, bool) {
+	for _, t := range toks {
+		if t.line == line && t.column <= column && column <= t.column+int32(len([]rune(t.text))) {
+			return t, true
+		}
+	}
+	return token{}, false
+}
+
+// Kinds of symbols.
+const (
+	kindInclude   = "include"
+	kindTypedef   = "typedef"
+	kindConstant  = "const"
+	kindEnum      = "enum"
+	kindEnumValue = "enum value"
+	kindStruct    = "struct"
+	kindUnion     = "union"
+	kindException = "exception"
+	kindService   = "service"
+)
+
+// symbol is a definition that can be referenced by name in IDLs.
+type symbol struct {
+	ast  *parser.Thrift // the AST where the symbol is defined
+	kind string
+	name string // the name without include prefix, for example "Status" or "Status.OK"
+	node interface{}
+	loc  *parser.Location
+}
+
+// same tells whether the two symbols are the same definition.
+func (s *symbol) same(o *symbol) bool {
+	return s != nil && o != nil && s.ast.Filename == o.ast.Filename && s.kind == o.kind && s.name == o.name
+}
+
+// shortName is the last segment of the name.
+func (s *symbol) shortName() string {
+	if idx := strings.LastIndex(s.name, "."); idx >= 0 {
+		return s.name[idx+1:]
+	}
+	return s.name
+}
+
+// lookupLocal finds a definition in the AST without looking into includes.
+func lookupLocal(ast *parser.Thrift, segs []string) *symbol {
+	switch len(segs) {
+	case 1:
+		name := segs[0]
+		if v, ok := ast.GetTypedef(name); ok {
+			return &symbol{ast: ast, kind: kindTypedef, name: name, node: v, loc: v.Location}
+		}
+		if v, ok := ast.GetConstant(name); ok {
+			return &symbol{ast: ast, kind: kindConstant, name: name, node: v, loc: v.Location}
+		}
+		if v, ok := ast.GetEnum(name); ok {
+			return &symbol{ast: ast, kind: kindEnum, name: name, node: v, loc: v.Location}
+		}
+		for _, v := range ast.GetStructLikes() {
+			if v.Name == name {
+				return &symbol{ast: ast, kind: v.Category, name: name, node: v, loc: v.Location}
+			}
+		}
+		if v, ok := ast.GetService(name); ok {
+			return &symbol{ast: ast, kind: kindService, name: name, node: v, loc: v.Location}
+		}
+	case 2:
+		if e, ok := ast.GetEnum(segs[0]); ok {
+			for _, v := range e.Values {
+				if v.Name == segs[1] {
+					return &symbol{ast: ast, kind: kindEnumValue, name: e.Name + "." + v.Name, node: v, loc: v.Location}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolve finds the definition referred by the segments of an identifier in the AST.
+func resolve(ast *parser.Thrift, segs []string) *symbol {
+	ref, isInclude := ast.GetReference(segs[0])
+	if isInclude && len(segs) > 1 {
+		if s := lookupLocal(ref, segs[1:]); s != nil {
+			return s
+		}
+	}
+	if s := lookupLocal(ast, segs); s != nil {
+		return s
+	}
+	if isInclude && len(segs) == 1 {
+		return &symbol{ast: ref, kind: kindInclude, name: segs[0]}
+	}
+	return nil
+}
+
+// nameLocation returns the location of the name of the symbol in its file.
+func nameLocation(toks []token, s *symbol) *parser.Location {
+	if s.loc == nil {
+		return nil
+	}
+	name := s.shortName()
+	for _, t := range toks {
+		if t.text == name && s.loc.Contains(t.line, t.column) {
+			return t.segmentLocation(0)
+		}
+	}
+	return s.loc
+}
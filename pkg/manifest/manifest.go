@@ -0,0 +1,216 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package manifest records the files generated into an output directory, so
+// that the files no longer generated can be cleaned up.
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FileName is the name of the manifest in an output directory.
+const FileName = ".thriftgo-manifest.json"
+
+// Manifest records the files generated for each IDL and language. Paths are
+// relative to the directory of the manifest.
+type Manifest struct {
+	// Entries maps the keys returned by Key to the generated files.
+	Entries map[string][]string `json:"entries"`
+
+	dir string
+}
+
+// Root returns the directory to keep the manifest for an output path, which
+// is the part of the path before any placeholder like {namespace}.
+func Root(output string) string {
+	if i := strings.Index(output, "{"); i >= 0 {
+		output = output[:i]
+		if !strings.HasSuffix(output, string(filepath.Separator)) && !strings.HasSuffix(output, "/") {
+			output = filepath.Dir(output)
+		}
+	}
+	return filepath.Clean(output)
+}
+
+// Load reads the manifest in the directory. An empty manifest is returned if
+// it does not exist.
+func Load(dir string) (*Manifest, error) {
+	m := &Manifest{Entries: make(map[string][]string), dir: dir}
+	content, err := os.ReadFile(filepath.Join(dir, FileName))
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, fmt.Errorf("manifest: %s: %w", filepath.Join(dir, FileName), err)
+	}
+	if m.Entries == nil {
+		m.Entries = make(map[string][]string)
+	}
+	return m, nil
+}
+
+// Save writes the manifest into its directory.
+func (m *Manifest) Save() error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(m.dir, FileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(content, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Key identifies the files generated for the IDL in the language.
+func (m *Manifest) Key(lang, idl string) string {
+	return lang + ":" + filepath.ToSlash(m.rel(idl))
+}
+
+func (m *Manifest) rel(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		if root, err := filepath.Abs(m.dir); err == nil {
+			if rel, err := filepath.Rel(root, abs); err == nil {
+				return rel
+			}
+		}
+	}
+	return path
+}
+
+func (m *Manifest) abs(rel string) string {
+	rel = filepath.FromSlash(rel)
+	if filepath.IsAbs(rel) {
+		return rel
+	}
+	return filepath.Join(m.dir, rel)
+}
+
+// Update records the files generated for the key and returns the files
+// recorded previously for the key that are neither generated now nor
+// recorded for other keys. If keep is true, these stale files are kept in the
+// entry so that they can be cleaned later.
+func (m *Manifest) Update(key string, files []string, keep bool) (stale []string) {
+	now := make(map[string]bool, len(files))
+	var entry []string
+	for _, f := range files {
+		rel := filepath.ToSlash(m.rel(f))
+		if !now[rel] {
+			now[rel] = true
+			entry = append(entry, rel)
+		}
+	}
+	others := m.files(key)
+	for _, rel := range m.Entries[key] {
+		if now[rel] || others[rel] {
+			continue
+		}
+		stale = append(stale, m.abs(rel))
+		if keep {
+			entry = append(entry, rel)
+		}
+	}
+	sort.Strings(entry)
+	m.Entries[key] = entry
+	return stale
+}
+
+// Prune removes the entries of IDLs that no longer exist and returns their
+// files that are not recorded for other keys.
+func (m *Manifest) Prune() (stale []string) {
+	for key, files := range m.Entries {
+		idl := key[strings.Index(key, ":")+1:]
+		if _, err := os.Stat(m.abs(idl)); !os.IsNotExist(err) {
+			continue
+		}
+		delete(m.Entries, key)
+		others := m.files("")
+		for _, rel := range files {
+			if !others[rel] {
+				stale = append(stale, m.abs(rel))
+			}
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// files returns the files recorded for keys other than the given one.
+func (m *Manifest) files(except string) map[string]bool {
+	res := make(map[string]bool)
+	for key, files := range m.Entries {
+		if key != except {
+			for _, f := range files {
+				res[f] = true
+			}
+		}
+	}
+	return res
+}
+
+// generatedHeader matches the header written by thriftgo into generated files.
+var generatedHeader = regexp.MustCompile(`(?m)^// Code generated by thriftgo\b.*DO NOT EDIT\.`)
+
+// ErrNotGenerated is returned by Remove for files without the header of codes
+// generated by thriftgo.
+var ErrNotGenerated = errors.New("not generated by thriftgo")
+
+// Remove deletes a generated file and its parent directories that become
+// empty, up to the directory of the manifest. Files lacking the header of
+// codes generated by thriftgo are not deleted. Files that do not exist are
+// ignored.
+func (m *Manifest) Remove(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	head := make([]byte, 4096)
+	n, err := io.ReadFull(f, head)
+	f.Close()
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+	if !generatedHeader.Match(head[:n]) {
+		return fmt.Errorf("%s: %w", path, ErrNotGenerated)
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	root, _ := filepath.Abs(m.dir)
+	for dir, _ := filepath.Abs(filepath.Dir(path)); strings.HasPrefix(dir, root+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break // not empty
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestRoot(t *testing.T) {
+	test.Assert(t, Root("./gen-go") == "gen-go")
+	test.Assert(t, Root("gen/{namespace}/data") == "gen")
+	test.Assert(t, Root("gen/x_{namespaceUnderscore}") == "gen")
+	test.Assert(t, Root("{namespace}") == ".")
+}
+
+func TestUpdate(t *testing.T) {
+	dir := t.TempDir()
+	m, err := Load(dir)
+	test.Assert(t, err == nil && len(m.Entries) == 0, err)
+	path := func(name string) string { return filepath.Join(dir, name) }
+	a, b := m.Key("go", path("a.thrift")), m.Key("go", path("b.thrift"))
+	test.Assert(t, a == "go:a.thrift", a)
+
+	test.Assert(t, len(m.Update(a, []string{path("x/a.go"), path("x/shared.go"), path("x/old.go")}, false)) == 0)
+	test.Assert(t, len(m.Update(b, []string{path("x/shared.go")}, false)) == 0)
+	stale := m.Update(a, []string{path("x/a.go")}, true)
+	test.DeepEqual(t, stale, []string{path("x/old.go")})
+	test.DeepEqual(t, m.Entries[a], []string{"x/a.go", "x/old.go"})
+	test.Assert(t, m.Save() == nil)
+
+	m, err = Load(dir)
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, m.Update(a, []string{path("x/a.go")}, false), []string{path("x/old.go")})
+	test.DeepEqual(t, m.Entries[a], []string{"x/a.go"})
+
+	// b.thrift does not exist
+	test.Assert(t, os.WriteFile(path("a.thrift"), nil, 0o644) == nil)
+	test.DeepEqual(t, m.Prune(), []string{path("x/shared.go")})
+	_, ok := m.Entries[b]
+	test.Assert(t, !ok)
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	m, _ := Load(dir)
+	gen, own := filepath.Join(dir, "a", "b", "gen.go"), filepath.Join(dir, "own.go")
+	test.Assert(t, os.MkdirAll(filepath.Dir(gen), 0o755) == nil)
+	test.Assert(t, os.WriteFile(gen, []byte("// Code generated by thriftgo (0.4.1). DO NOT EDIT.\n\npackage b\n"), 0o644) == nil)
+	test.Assert(t, os.WriteFile(own, []byte("package a\n"), 0o644) == nil)
+
+	test.Assert(t, m.Remove(gen) == nil)
+	_, err := os.Stat(filepath.Join(dir, "a"))
+	test.Assert(t, os.IsNotExist(err), err)
+	_, err = os.Stat(dir)
+	test.Assert(t, err == nil, err)
+
+	test.Assert(t, errors.Is(m.Remove(own), ErrNotGenerated))
+	test.Assert(t, m.Remove(filepath.Join(dir, "none.go")) == nil)
+}
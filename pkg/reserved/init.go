@@ -14,17 +14,23 @@
 
 package reserved
 
-import "sync"
+import (
+	"sort"
+	"strings"
+	"sync"
+)
 
 var (
-	lock sync.RWMutex
-	all  = make(map[string][]string) // word => langs
+	lock  sync.RWMutex
+	all   = make(map[string][]string) // word => langs
+	langs = make(map[string]bool)
 )
 
 // Register adds reserved words to the global set for the given language.
 func Register(lang string, words ...string) {
 	lock.Lock()
 	defer lock.Unlock()
+	langs[lang] = true
 next:
 	for _, w := range words {
 		for _, l := range all[w] {
@@ -42,3 +48,42 @@ func Hit(word string) (res []string) {
 	defer lock.RUnlock()
 	return all[word] // XXX: make a copy to avoid modification?
 }
+
+// HitIn is like Hit but only returns the languages in the given list. The
+// names of languages are case-insensitive. An empty list means all languages.
+func HitIn(word string, languages []string) (res []string) {
+	if len(languages) == 0 {
+		return Hit(word)
+	}
+	for _, l := range Hit(word) {
+		for _, x := range languages {
+			if strings.EqualFold(l, x) {
+				res = append(res, l)
+				break
+			}
+		}
+	}
+	return
+}
+
+// Languages returns the names of the languages that have reserved words.
+func Languages() (res []string) {
+	lock.RLock()
+	defer lock.RUnlock()
+	for l := range langs {
+		res = append(res, l)
+	}
+	sort.Strings(res)
+	return
+}
+
+// Lookup returns the registered name of the language which equals to the
+// given name case-insensitively.
+func Lookup(lang string) (string, bool) {
+	for _, l := range Languages() {
+		if strings.EqualFold(l, lang) {
+			return l, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MapSink keeps generated files in memory. It is also an FS, so that the
+// files written earlier can be compared with the regenerated ones.
+type MapSink struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewMapSink creates an empty MapSink.
+func NewMapSink() *MapSink {
+	return &MapSink{files: make(map[string][]byte)}
+}
+
+// WriteFile implements Sink.
+func (m *MapSink) WriteFile(name string, content []byte) error {
+	m.mu.Lock()
+	m.files[filepath.Clean(name)] = append([]byte(nil), content...)
+	m.mu.Unlock()
+	return nil
+}
+
+// ReadFile implements FS.
+func (m *MapSink) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	content, ok := m.files[filepath.Clean(name)]
+	m.mu.Unlock()
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), content...), nil
+}
+
+// Stat implements FS.
+func (m *MapSink) Stat(name string) (fs.FileInfo, error) {
+	content, err := m.ReadFile(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return fileInfo{filepath.Base(name), int64(len(content))}, nil
+}
+
+// Files returns a copy of the files written, keyed by their names.
+func (m *MapSink) Files() map[string][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make(map[string][]byte, len(m.files))
+	for name, content := range m.files {
+		res[name] = content
+	}
+	return res
+}
+
+// Names returns the names of the files written in lexical order.
+func (m *MapSink) Names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make([]string, 0, len(m.files))
+	for name := range m.files {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Archive formats supported by NewArchive.
+const (
+	Zip   = "zip"
+	Tar   = "tar"
+	Txtar = "txtar"
+)
+
+// Archive collects generated files and writes them into an archive when it
+// is closed. The files are written in the lexical order of names, so that
+// the archive stays the same for the same files.
+type Archive struct {
+	MapSink
+	format string
+	w      io.Writer
+}
+
+// NewArchive creates an Archive that writes to w in the format, which is one
+// of Zip, Tar and Txtar. Txtar is the plain text format of
+// golang.org/x/tools/txtar, which suits an io.Writer like os.Stdout.
+func NewArchive(w io.Writer, format string) (*Archive, error) {
+	switch format {
+	case Zip, Tar, Txtar:
+	default:
+		return nil, fmt.Errorf("vfs: unknown archive format %q", format)
+	}
+	return &Archive{MapSink: MapSink{files: make(map[string][]byte)}, format: format, w: w}, nil
+}
+
+// Close writes the archive.
+func (a *Archive) Close() error {
+	files := a.Files()
+	names := a.Names()
+	switch a.format {
+	case Zip:
+		zw := zip.NewWriter(a.w)
+		for _, name := range names {
+			f, err := zw.CreateHeader(&zip.FileHeader{Name: archiveName(name), Method: zip.Deflate})
+			if err != nil {
+				return err
+			}
+			if _, err := f.Write(files[name]); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	case Tar:
+		tw := tar.NewWriter(a.w)
+		for _, name := range names {
+			hdr := &tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     archiveName(name),
+				Mode:     0o644,
+				Size:     int64(len(files[name])),
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := tw.Write(files[name]); err != nil {
+				return err
+			}
+		}
+		return tw.Close()
+	default:
+		for _, name := range names {
+			content := files[name]
+			if len(content) > 0 && content[len(content)-1] != '\n' {
+				content = append(content, '\n')
+			}
+			if _, err := fmt.Fprintf(a.w, "-- %s --\n%s", archiveName(name), content); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// archiveName converts an OS path into a relative slash-separated name.
+func archiveName(name string) string {
+	return strings.TrimLeft(path.Clean(filepath.ToSlash(name)), "/")
+}
+
+type fileInfo struct {
+	name string
+	size int64
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) Mode() fs.FileMode  { return 0o644 }
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return false }
+func (fi fileInfo) Sys() interface{}   { return nil }
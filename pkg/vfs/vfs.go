@@ -0,0 +1,128 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vfs abstracts the file systems that thriftgo reads IDLs from and
+// writes generated files to, so that it can run without touching the disk.
+package vfs
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FS provides the files to read. Names are paths in the form of the operating
+// system, like the ones given on the command line.
+type FS interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+}
+
+// Sink receives generated files. It must be safe for concurrent use.
+type Sink interface {
+	WriteFile(name string, content []byte) error
+}
+
+// OS is the file system of the operating system. It is both an FS and a
+// Sink, and creates the missing directories when writing files.
+var OS interface {
+	FS
+	Sink
+} = osFS{}
+
+type osFS struct{}
+
+func (osFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+func (osFS) WriteFile(name string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, content, 0o644)
+}
+
+// Dir is the file system of the operating system in which relative names are
+// resolved against the directory instead of the working directory of the
+// process. Unlike os.DirFS, names outside of the directory are accessible. It
+// is both an FS and a Sink, and the directory should be absolute.
+type Dir string
+
+func (d Dir) path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(string(d), name)
+}
+
+func (d Dir) Stat(name string) (fs.FileInfo, error) {
+	return OS.Stat(d.path(name))
+}
+
+func (d Dir) ReadFile(name string) ([]byte, error) {
+	return OS.ReadFile(d.path(name))
+}
+
+func (d Dir) WriteFile(name string, content []byte) error {
+	return OS.WriteFile(d.path(name), content)
+}
+
+// FromFS returns an FS that reads from fsys, such as an os.DirFS, embed.FS or
+// fstest.MapFS. Relative names are looked up from the root of fsys. Absolute
+// names and names outside of the root do not exist.
+func FromFS(fsys fs.FS) FS {
+	return ioFS{fsys}
+}
+
+type ioFS struct {
+	fsys fs.FS
+}
+
+func (f ioFS) Stat(name string) (fs.FileInfo, error) {
+	n, err := fsName("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.Stat(f.fsys, n)
+}
+
+func (f ioFS) ReadFile(name string) ([]byte, error) {
+	n, err := fsName("read", name)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(f.fsys, n)
+}
+
+// fsName converts an OS path into a name accepted by fs.FS.
+func fsName(op, name string) (string, error) {
+	n := path.Clean(filepath.ToSlash(name))
+	if filepath.IsAbs(name) || n == ".." || strings.HasPrefix(n, "../") || !fs.ValidPath(n) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return n, nil
+}
+
+// Exists tells whether name is a regular file in fsys.
+func Exists(fsys FS, name string) bool {
+	fi, err := fsys.Stat(name)
+	return err == nil && !fi.IsDir()
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestFromFS(t *testing.T) {
+	fsys := FromFS(fstest.MapFS{
+		"a/b.thrift": {Data: []byte("b")},
+	})
+	for _, name := range []string{"a/b.thrift", "./a/b.thrift", "a/../a/b.thrift", filepath.Join("a", "b.thrift")} {
+		content, err := fsys.ReadFile(name)
+		test.Assert(t, err == nil && string(content) == "b", name, err)
+		test.Assert(t, Exists(fsys, name), name)
+	}
+	for _, name := range []string{"/a/b.thrift", "../a/b.thrift", "a", "c.thrift"} {
+		test.Assert(t, !Exists(fsys, name), name)
+	}
+	_, err := fsys.ReadFile("../a/b.thrift")
+	test.Assert(t, errors.Is(err, fs.ErrNotExist), err)
+}
+
+func TestMapSink(t *testing.T) {
+	m := NewMapSink()
+	test.Assert(t, m.WriteFile("out/b.go", []byte("b")) == nil)
+	test.Assert(t, m.WriteFile("./out/a.go", []byte("a")) == nil)
+	test.DeepEqual(t, m.Names(), []string{filepath.Join("out", "a.go"), filepath.Join("out", "b.go")})
+	content, err := m.ReadFile("out/a.go")
+	test.Assert(t, err == nil && string(content) == "a", err)
+	test.Assert(t, Exists(m, "out/b.go") && !Exists(m, "out/c.go"))
+}
+
+func TestArchive(t *testing.T) {
+	files := map[string]string{"out/b.go": "package b\n", "out/a.go": "package a"}
+	archive := func(format string) []byte {
+		var buf bytes.Buffer
+		a, err := NewArchive(&buf, format)
+		test.Assert(t, err == nil, err)
+		for name, content := range files {
+			test.Assert(t, a.WriteFile(name, []byte(content)) == nil)
+		}
+		test.Assert(t, a.Close() == nil)
+		return buf.Bytes()
+	}
+
+	test.Assert(t, string(archive(Txtar)) == "-- out/a.go --\npackage a\n-- out/b.go --\npackage b\n")
+
+	data := archive(Zip)
+	test.Assert(t, bytes.Equal(data, archive(Zip)))
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(zr.File) == 2 && zr.File[0].Name == "out/a.go" && zr.File[1].Name == "out/b.go")
+	f, _ := zr.File[1].Open()
+	content, _ := io.ReadAll(f)
+	test.Assert(t, string(content) == "package b\n")
+
+	tr := tar.NewReader(bytes.NewReader(archive(Tar)))
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		test.Assert(t, err == nil, err)
+		names = append(names, hdr.Name)
+	}
+	test.DeepEqual(t, names, []string{"out/a.go", "out/b.go"})
+
+	_, err = NewArchive(io.Discard, "rar")
+	test.Assert(t, err != nil)
+}
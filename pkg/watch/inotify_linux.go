@@ -0,0 +1,113 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO
+
+type inotify struct {
+	fd   int
+	file *os.File // the non-blocking fd registered to the runtime poller
+	done chan struct{}
+
+	mu   sync.Mutex
+	wds  map[string]int
+	dirs map[int]string
+}
+
+func newNotifier(notify func(path string)) (notifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("watch: inotify: %w", err)
+	}
+	n := &inotify{
+		fd:   fd,
+		file: os.NewFile(uintptr(fd), "inotify"),
+		wds:  make(map[string]int),
+		dirs: make(map[int]string),
+		done: make(chan struct{}),
+	}
+	go n.read(notify)
+	return n, nil
+}
+
+func (n *inotify) add(dir string) error {
+	wd, err := syscall.InotifyAddWatch(n.fd, dir, inotifyMask)
+	if err != nil {
+		return fmt.Errorf("watch: %s: %w", dir, err)
+	}
+	n.mu.Lock()
+	n.wds[dir] = wd
+	n.dirs[wd] = dir
+	n.mu.Unlock()
+	return nil
+}
+
+func (n *inotify) remove(dir string) error {
+	n.mu.Lock()
+	wd, ok := n.wds[dir]
+	delete(n.wds, dir)
+	delete(n.dirs, wd)
+	n.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	// the watch is removed by the kernel if the directory has been deleted
+	if _, err := syscall.InotifyRmWatch(n.fd, uint32(wd)); err != nil && err != syscall.EINVAL {
+		return fmt.Errorf("watch: %s: %w", dir, err)
+	}
+	return nil
+}
+
+func (n *inotify) close() error {
+	err := n.file.Close()
+	<-n.done
+	return err
+}
+
+func (n *inotify) read(notify func(path string)) {
+	defer close(n.done)
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		size, err := n.file.Read(buf)
+		if err != nil {
+			return // closed
+		}
+		for off := 0; off+syscall.SizeofInotifyEvent <= size; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			name := buf[off+syscall.SizeofInotifyEvent : off+syscall.SizeofInotifyEvent+int(ev.Len)]
+			off += syscall.SizeofInotifyEvent + int(ev.Len)
+			if i := bytes.IndexByte(name, 0); i >= 0 {
+				name = name[:i]
+			}
+			n.mu.Lock()
+			dir, ok := n.dirs[int(ev.Wd)]
+			n.mu.Unlock()
+			if ok && len(name) > 0 {
+				notify(filepath.Join(dir, string(name)))
+			}
+		}
+	}
+}
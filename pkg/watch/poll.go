@@ -0,0 +1,129 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PollInterval is the interval to check the directories on platforms
+// without inotify.
+var PollInterval = 500 * time.Millisecond
+
+type stamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// poller checks the modification times of files in the directories
+// periodically.
+type poller struct {
+	mu   sync.Mutex
+	dirs map[string]map[string]stamp
+	done chan struct{}
+	once sync.Once
+	exit chan struct{} // closed when the loop returns
+}
+
+func newPoller(notify func(path string)) *poller {
+	p := &poller{
+		dirs: make(map[string]map[string]stamp),
+		done: make(chan struct{}),
+		exit: make(chan struct{}),
+	}
+	go p.loop(notify)
+	return p
+}
+
+func scan(dir string) map[string]stamp {
+	res := make(map[string]stamp)
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		if fi, err := e.Info(); err == nil && !fi.IsDir() {
+			res[filepath.Join(dir, e.Name())] = stamp{fi.Size(), fi.ModTime()}
+		}
+	}
+	return res
+}
+
+func (p *poller) add(dir string) error {
+	files := scan(dir)
+	p.mu.Lock()
+	p.dirs[dir] = files
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *poller) remove(dir string) error {
+	p.mu.Lock()
+	delete(p.dirs, dir)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *poller) close() error {
+	p.once.Do(func() { close(p.done) })
+	<-p.exit
+	return nil
+}
+
+func (p *poller) loop(notify func(path string)) {
+	defer close(p.exit)
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+		p.mu.Lock()
+		dirs := make([]string, 0, len(p.dirs))
+		for dir := range p.dirs {
+			dirs = append(dirs, dir)
+		}
+		p.mu.Unlock()
+
+		var changed []string
+		for _, dir := range dirs {
+			files := scan(dir)
+			p.mu.Lock()
+			old, ok := p.dirs[dir]
+			if ok {
+				p.dirs[dir] = files
+			}
+			p.mu.Unlock()
+			if !ok {
+				continue // removed meanwhile
+			}
+			for path, s := range files {
+				if o, ok := old[path]; !ok || o.size != s.size || !o.modTime.Equal(s.modTime) {
+					changed = append(changed, path)
+				}
+			}
+			for path := range old {
+				if _, ok := files[path]; !ok {
+					changed = append(changed, path)
+				}
+			}
+		}
+		for _, path := range changed {
+			notify(path)
+		}
+	}
+}
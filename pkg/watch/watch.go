@@ -0,0 +1,155 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watch reports changes of files. It uses inotify on Linux and polls
+// the modification times of files on other platforms.
+package watch
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// notifier reports every file created, written, removed or renamed in the
+// watched directories. Close stops reporting before it returns.
+type notifier interface {
+	add(dir string) error
+	remove(dir string) error
+	close() error
+}
+
+// Watcher watches a set of files. It watches their directories instead of the
+// files themselves, so that a file replaced by an editor through a rename is
+// still watched.
+type Watcher struct {
+	events chan struct{}
+	n      notifier
+
+	mu      sync.Mutex
+	files   map[string]bool
+	dirs    map[string]int // the number of files watched in each directory
+	changed map[string]bool
+}
+
+// New creates a watcher that watches nothing.
+func New() (*Watcher, error) {
+	w := newWatcher()
+	n, err := newNotifier(w.notify)
+	if err != nil {
+		return nil, err
+	}
+	w.n = n
+	return w, nil
+}
+
+func newWatcher() *Watcher {
+	return &Watcher{
+		events:  make(chan struct{}, 1),
+		files:   make(map[string]bool),
+		dirs:    make(map[string]int),
+		changed: make(map[string]bool),
+	}
+}
+
+// Events returns the channel that receives a value when some watched files
+// have changed. Changes are coalesced: the channel holds at most one value
+// until Changed is called.
+func (w *Watcher) Events() <-chan struct{} {
+	return w.events
+}
+
+// Changed returns the absolute paths of the files changed since the last call
+// in order.
+func (w *Watcher) Changed() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	res := make([]string, 0, len(w.changed))
+	for path := range w.changed {
+		res = append(res, path)
+	}
+	w.changed = make(map[string]bool)
+	sort.Strings(res)
+	return res
+}
+
+func (w *Watcher) notify(path string) {
+	w.mu.Lock()
+	ok := w.files[path]
+	if ok {
+		w.changed[path] = true
+	}
+	w.mu.Unlock()
+	if ok {
+		select {
+		case w.events <- struct{}{}:
+		default: // a value is pending already
+		}
+	}
+}
+
+// Set replaces the watched files.
+func (w *Watcher) Set(files []string) error {
+	want := make(map[string]bool, len(files))
+	for _, f := range files {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			return err
+		}
+		want[abs] = true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for f := range w.files {
+		if want[f] {
+			continue
+		}
+		delete(w.files, f)
+		dir := filepath.Dir(f)
+		if w.dirs[dir]--; w.dirs[dir] == 0 {
+			delete(w.dirs, dir)
+			if err := w.n.remove(dir); err != nil {
+				return err
+			}
+		}
+	}
+	for f := range want {
+		if w.files[f] {
+			continue
+		}
+		dir := filepath.Dir(f)
+		if w.dirs[dir] == 0 {
+			if err := w.n.add(dir); err != nil {
+				return err
+			}
+		}
+		w.files[f] = true
+		w.dirs[dir]++
+	}
+	return nil
+}
+
+// Len returns the number of watched files.
+func (w *Watcher) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.files)
+}
+
+// Close stops watching. No event is sent after it returns. The events channel
+// is not closed.
+func (w *Watcher) Close() error {
+	return w.n.close()
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+// expect waits until the path is reported as changed.
+func expect(t *testing.T, w *Watcher, path string) {
+	t.Helper()
+	for deadline := time.After(5 * time.Second); ; {
+		select {
+		case <-w.Events():
+			for _, got := range w.Changed() {
+				if got == path {
+					return
+				}
+			}
+		case <-deadline:
+			t.Fatalf("no event for %s", path)
+		}
+	}
+}
+
+func expectNone(t *testing.T, w *Watcher) {
+	t.Helper()
+	select {
+	case <-w.Events():
+		t.Fatalf("unexpected events for %v", w.Changed())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+// drain drops the pending events.
+func drain(w *Watcher) {
+	select {
+	case <-w.Events():
+	default:
+	}
+	w.Changed()
+}
+
+func testWatcher(t *testing.T, w *Watcher) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift")
+	test.Assert(t, os.WriteFile(a, []byte("a"), 0o644) == nil)
+	test.Assert(t, w.Set([]string{a}) == nil)
+	test.Assert(t, w.Len() == 1)
+
+	test.Assert(t, os.WriteFile(a, []byte("aa"), 0o644) == nil)
+	expect(t, w, a)
+	drain(w)
+	test.Assert(t, os.WriteFile(b, []byte("b"), 0o644) == nil)
+	expectNone(t, w)
+
+	// files replaced through renames are still watched
+	tmp := filepath.Join(dir, "a.thrift.tmp")
+	test.Assert(t, os.WriteFile(tmp, []byte("aaa"), 0o644) == nil)
+	test.Assert(t, os.Rename(tmp, a) == nil)
+	expect(t, w, a)
+
+	test.Assert(t, w.Set([]string{a, b}) == nil)
+	drain(w)
+	// events are coalesced without blocking the notifier
+	for i := 0; i < 200; i++ {
+		test.Assert(t, os.WriteFile(a, []byte{byte(i)}, 0o644) == nil)
+		test.Assert(t, os.WriteFile(b, []byte{byte(i)}, 0o644) == nil)
+	}
+	got := make(map[string]bool)
+	for deadline := time.After(5 * time.Second); !got[a] || !got[b]; {
+		select {
+		case <-w.Events():
+			for _, path := range w.Changed() {
+				got[path] = true
+			}
+		case <-deadline:
+			t.Fatalf("changes of %s and %s are not seen: %v", a, b, got)
+		}
+	}
+	test.Assert(t, len(w.Events()) <= 1)
+
+	test.Assert(t, w.Set([]string{b}) == nil)
+	time.Sleep(50 * time.Millisecond)
+	drain(w)
+	test.Assert(t, os.Remove(b) == nil)
+	expect(t, w, b)
+	test.Assert(t, os.WriteFile(a, []byte("a"), 0o644) == nil)
+	expectNone(t, w)
+
+	test.Assert(t, w.Close() == nil)
+	drain(w)
+	test.Assert(t, os.WriteFile(b, []byte("b"), 0o644) == nil)
+	expectNone(t, w)
+}
+
+func TestWatcher(t *testing.T) {
+	w, err := New()
+	test.Assert(t, err == nil, err)
+	testWatcher(t, w)
+}
+
+func TestPoller(t *testing.T) {
+	defer func(d time.Duration) { PollInterval = d }(PollInterval)
+	PollInterval = 10 * time.Millisecond
+	w := newWatcher()
+	w.n = newPoller(w.notify)
+	testWatcher(t, w)
+}
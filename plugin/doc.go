@@ -42,5 +42,25 @@
 //
 // All insertion points in the file will be erased before thriftgo finally writes out files.
 //
+// A plugin may declare what it needs from thriftgo in a manifest next to its
+// executable, so that thriftgo sends a smaller request and rejects versions
+// the plugin does not work with. It may also ask for Request.Scopes, the
+// identifiers generated by the backend, instead of deriving them from the AST.
+// See Manifest.
+//
+// The manifest also decides when a plugin runs. Besides adding contents after
+// the backend, a plugin may modify the AST before the backend, or replace the
+// generated files after the other plugins. See the Stage constants.
+//
+// A plugin may also be a WASI module whose name ends with ".wasm". It is run
+// by an embedded runtime without access to the host, except the directories
+// granted by a Sandbox.
+//
+// A plugin may use Serve to handle its input. Such a plugin also supports the
+// persistent mode, where thriftgo keeps it running and sends it many requests
+// as length-prefixed frames instead of starting it for each one. thriftgo
+// negotiates the mode with the data trailer of the first request, so plugins
+// that do not use Serve keep working as before. See ServerPool.
+//
 // Refer to protocol.thrift for more information.
 package plugin
@@ -1,4 +1,4 @@
-// Code generated by thriftgo (0.4.1) (fastgo). DO NOT EDIT.
+// Code generated by thriftgo (0.4.5) (fastgo). DO NOT EDIT.
 package plugin
 
 import (
@@ -54,6 +54,24 @@ func (p *Request) BLength() int {
 	// p.AST ID:7 thrift.STRUCT
 	off += 3
 	off += p.AST.BLength()
+
+	// p.Scopes ID:8 thrift.LIST
+	if p.Scopes != nil {
+		off += 3
+		off += 5
+		for _, v := range p.Scopes {
+			off += v.BLength()
+		}
+	}
+
+	// p.Contents ID:9 thrift.LIST
+	if p.Contents != nil {
+		off += 3
+		off += 5
+		for _, v := range p.Contents {
+			off += v.BLength()
+		}
+	}
 	return off + 1
 }
 
@@ -112,6 +130,24 @@ func (p *Request) FastAppend(b []byte) []byte {
 	b = append(b, 12, 0, 7)
 	b = p.AST.FastAppend(b)
 
+	// p.Scopes
+	if p.Scopes != nil {
+		b = append(b, 15, 0, 8)
+		b = x.AppendListBegin(b, thrift.STRUCT, len(p.Scopes))
+		for _, v := range p.Scopes {
+			b = v.FastAppend(b)
+		}
+	}
+
+	// p.Contents
+	if p.Contents != nil {
+		b = append(b, 15, 0, 9)
+		b = x.AppendListBegin(b, thrift.STRUCT, len(p.Contents))
+		for _, v := range p.Contents {
+			b = v.FastAppend(b)
+		}
+	}
+
 	return append(b, 0)
 }
 
@@ -199,6 +235,38 @@ func (p *Request) FastRead(b []byte) (off int, err error) {
 				goto ReadFieldError
 			}
 			isset |= 0x40
+		case 0x80f: // p.Scopes ID:8 thrift.LIST
+			var sz int
+			_, sz, l, err = x.ReadListBegin(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Scopes = make([]*Scope, sz)
+			for i := 0; i < sz; i++ {
+				p.Scopes[i] = NewScope()
+				l, err = p.Scopes[i].FastRead(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+			}
+		case 0x90f: // p.Contents ID:9 thrift.LIST
+			var sz int
+			_, sz, l, err = x.ReadListBegin(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Contents = make([]*Generated, sz)
+			for i := 0; i < sz; i++ {
+				p.Contents[i] = NewGenerated()
+				l, err = p.Contents[i].FastRead(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
@@ -251,6 +319,358 @@ RequiredFieldNotSetError:
 		fmt.Sprintf("required field %s is not set", fieldIDToName_Request[fid]))
 }
 
+func (p *Symbol) BLength() int {
+	if p == nil {
+		return 1
+	}
+	off := 0
+
+	// p.Kind ID:1 thrift.STRING
+	off += 3
+	off += 4 + len(p.Kind)
+
+	// p.Name ID:2 thrift.STRING
+	off += 3
+	off += 4 + len(p.Name)
+
+	// p.Identifier ID:3 thrift.STRING
+	off += 3
+	off += 4 + len(p.Identifier)
+
+	// p.Extra ID:4 thrift.MAP
+	if p.Extra != nil {
+		off += 3
+		off += 6
+		for k, v := range p.Extra {
+			off += 4 + len(k)
+			off += 4 + len(v)
+		}
+	}
+	return off + 1
+}
+
+func (p *Symbol) FastWrite(b []byte) int { return p.FastWriteNocopy(b, nil) }
+
+func (p *Symbol) FastWriteNocopy(b []byte, w thrift.NocopyWriter) (n int) {
+	if n = len(p.FastAppend(b[:0])); n > len(b) {
+		panic("buffer overflow. concurrency issue?")
+	}
+	return
+}
+
+func (p *Symbol) FastAppend(b []byte) []byte {
+	if p == nil {
+		return append(b, 0)
+	}
+	x := thrift.BinaryProtocol{}
+	_ = x
+
+	// p.Kind
+	b = append(b, 11, 0, 1)
+	b = x.AppendI32(b, int32(len(p.Kind)))
+	b = append(b, p.Kind...)
+
+	// p.Name
+	b = append(b, 11, 0, 2)
+	b = x.AppendI32(b, int32(len(p.Name)))
+	b = append(b, p.Name...)
+
+	// p.Identifier
+	b = append(b, 11, 0, 3)
+	b = x.AppendI32(b, int32(len(p.Identifier)))
+	b = append(b, p.Identifier...)
+
+	// p.Extra
+	if p.Extra != nil {
+		b = append(b, 13, 0, 4)
+		b = x.AppendMapBegin(b, thrift.STRING, thrift.STRING, len(p.Extra))
+		for k, v := range p.Extra {
+			b = x.AppendI32(b, int32(len(k)))
+			b = append(b, k...)
+			b = x.AppendI32(b, int32(len(v)))
+			b = append(b, v...)
+		}
+	}
+
+	return append(b, 0)
+}
+
+func (p *Symbol) FastRead(b []byte) (off int, err error) {
+	var ftyp thrift.TType
+	var fid int16
+	var l int
+	var isset uint8
+	x := thrift.BinaryProtocol{}
+	for {
+		ftyp, fid, l, err = x.ReadFieldBegin(b[off:])
+		off += l
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if ftyp == thrift.STOP {
+			break
+		}
+		switch uint32(fid)<<8 | uint32(ftyp) {
+		case 0x10b: // p.Kind ID:1 thrift.STRING
+			p.Kind, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			isset |= 0x1
+		case 0x20b: // p.Name ID:2 thrift.STRING
+			p.Name, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			isset |= 0x2
+		case 0x30b: // p.Identifier ID:3 thrift.STRING
+			p.Identifier, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			isset |= 0x4
+		case 0x40d: // p.Extra ID:4 thrift.MAP
+			var sz int
+			_, _, sz, l, err = x.ReadMapBegin(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Extra = make(map[string]string, sz)
+			for i := 0; i < sz; i++ {
+				var k string
+				var v string
+				k, l, err = x.ReadString(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+				v, l, err = x.ReadString(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+				p.Extra[k] = v
+			}
+		default:
+			l, err = x.Skip(b[off:], ftyp)
+			off += l
+			if err != nil {
+				goto SkipFieldError
+			}
+		}
+	}
+	if isset&0x1 == 0 {
+		fid = 1 // Kind
+		goto RequiredFieldNotSetError
+	}
+	if isset&0x2 == 0 {
+		fid = 2 // Name
+		goto RequiredFieldNotSetError
+	}
+	if isset&0x4 == 0 {
+		fid = 3 // Identifier
+		goto RequiredFieldNotSetError
+	}
+	return
+ReadFieldBeginError:
+	return off, thrift.PrependError(fmt.Sprintf("%T read field begin error: ", p), err)
+ReadFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T read field %d '%s' error: ", p, fid, fieldIDToName_Symbol[fid]), err)
+SkipFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
+RequiredFieldNotSetError:
+	return off, thrift.NewProtocolException(thrift.INVALID_DATA,
+		fmt.Sprintf("required field %s is not set", fieldIDToName_Symbol[fid]))
+}
+
+func (p *Scope) BLength() int {
+	if p == nil {
+		return 1
+	}
+	off := 0
+
+	// p.Filename ID:1 thrift.STRING
+	off += 3
+	off += 4 + len(p.Filename)
+
+	// p.Package ID:2 thrift.STRING
+	off += 3
+	off += 4 + len(p.Package)
+
+	// p.ImportPath ID:3 thrift.STRING
+	off += 3
+	off += 4 + len(p.ImportPath)
+
+	// p.File ID:4 thrift.STRING
+	off += 3
+	off += 4 + len(p.File)
+
+	// p.Symbols ID:5 thrift.LIST
+	off += 3
+	off += 5
+	for _, v := range p.Symbols {
+		off += v.BLength()
+	}
+	return off + 1
+}
+
+func (p *Scope) FastWrite(b []byte) int { return p.FastWriteNocopy(b, nil) }
+
+func (p *Scope) FastWriteNocopy(b []byte, w thrift.NocopyWriter) (n int) {
+	if n = len(p.FastAppend(b[:0])); n > len(b) {
+		panic("buffer overflow. concurrency issue?")
+	}
+	return
+}
+
+func (p *Scope) FastAppend(b []byte) []byte {
+	if p == nil {
+		return append(b, 0)
+	}
+	x := thrift.BinaryProtocol{}
+	_ = x
+
+	// p.Filename
+	b = append(b, 11, 0, 1)
+	b = x.AppendI32(b, int32(len(p.Filename)))
+	b = append(b, p.Filename...)
+
+	// p.Package
+	b = append(b, 11, 0, 2)
+	b = x.AppendI32(b, int32(len(p.Package)))
+	b = append(b, p.Package...)
+
+	// p.ImportPath
+	b = append(b, 11, 0, 3)
+	b = x.AppendI32(b, int32(len(p.ImportPath)))
+	b = append(b, p.ImportPath...)
+
+	// p.File
+	b = append(b, 11, 0, 4)
+	b = x.AppendI32(b, int32(len(p.File)))
+	b = append(b, p.File...)
+
+	// p.Symbols
+	b = append(b, 15, 0, 5)
+	b = x.AppendListBegin(b, thrift.STRUCT, len(p.Symbols))
+	for _, v := range p.Symbols {
+		b = v.FastAppend(b)
+	}
+
+	return append(b, 0)
+}
+
+func (p *Scope) FastRead(b []byte) (off int, err error) {
+	var ftyp thrift.TType
+	var fid int16
+	var l int
+	var isset uint8
+	x := thrift.BinaryProtocol{}
+	for {
+		ftyp, fid, l, err = x.ReadFieldBegin(b[off:])
+		off += l
+		if err != nil {
+			goto ReadFieldBeginError
+		}
+		if ftyp == thrift.STOP {
+			break
+		}
+		switch uint32(fid)<<8 | uint32(ftyp) {
+		case 0x10b: // p.Filename ID:1 thrift.STRING
+			p.Filename, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			isset |= 0x1
+		case 0x20b: // p.Package ID:2 thrift.STRING
+			p.Package, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			isset |= 0x2
+		case 0x30b: // p.ImportPath ID:3 thrift.STRING
+			p.ImportPath, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			isset |= 0x4
+		case 0x40b: // p.File ID:4 thrift.STRING
+			p.File, l, err = x.ReadString(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			isset |= 0x8
+		case 0x50f: // p.Symbols ID:5 thrift.LIST
+			var sz int
+			_, sz, l, err = x.ReadListBegin(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Symbols = make([]*Symbol, sz)
+			for i := 0; i < sz; i++ {
+				p.Symbols[i] = NewSymbol()
+				l, err = p.Symbols[i].FastRead(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+			}
+			isset |= 0x10
+		default:
+			l, err = x.Skip(b[off:], ftyp)
+			off += l
+			if err != nil {
+				goto SkipFieldError
+			}
+		}
+	}
+	if isset != 0x1f {
+		if isset&0x1 == 0 {
+			fid = 1 // Filename
+			goto RequiredFieldNotSetError
+		}
+		if isset&0x2 == 0 {
+			fid = 2 // Package
+			goto RequiredFieldNotSetError
+		}
+		if isset&0x4 == 0 {
+			fid = 3 // ImportPath
+			goto RequiredFieldNotSetError
+		}
+		if isset&0x8 == 0 {
+			fid = 4 // File
+			goto RequiredFieldNotSetError
+		}
+		if isset&0x10 == 0 {
+			fid = 5 // Symbols
+			goto RequiredFieldNotSetError
+		}
+	}
+	return
+ReadFieldBeginError:
+	return off, thrift.PrependError(fmt.Sprintf("%T read field begin error: ", p), err)
+ReadFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T read field %d '%s' error: ", p, fid, fieldIDToName_Scope[fid]), err)
+SkipFieldError:
+	return off, thrift.PrependError(
+		fmt.Sprintf("%T skip field %d type %d error: ", p, fid, ftyp), err)
+RequiredFieldNotSetError:
+	return off, thrift.NewProtocolException(thrift.INVALID_DATA,
+		fmt.Sprintf("required field %s is not set", fieldIDToName_Scope[fid]))
+}
+
 func (p *Generated) BLength() int {
 	if p == nil {
 		return 1
@@ -409,6 +829,21 @@ func (p *Response) BLength() int {
 			off += 4 + len(v)
 		}
 	}
+
+	// p.Diagnostics ID:4 thrift.LIST
+	if p.Diagnostics != nil {
+		off += 3
+		off += 5
+		for _, v := range p.Diagnostics {
+			off += v.BLength()
+		}
+	}
+
+	// p.AST ID:5 thrift.STRUCT
+	if p.AST != nil {
+		off += 3
+		off += p.AST.BLength()
+	}
 	return off + 1
 }
 
@@ -454,6 +889,21 @@ func (p *Response) FastAppend(b []byte) []byte {
 		}
 	}
 
+	// p.Diagnostics
+	if p.Diagnostics != nil {
+		b = append(b, 15, 0, 4)
+		b = x.AppendListBegin(b, thrift.STRUCT, len(p.Diagnostics))
+		for _, v := range p.Diagnostics {
+			b = v.FastAppend(b)
+		}
+	}
+
+	// p.AST
+	if p.AST != nil {
+		b = append(b, 12, 0, 5)
+		b = p.AST.FastAppend(b)
+	}
+
 	return append(b, 0)
 }
 
@@ -512,6 +962,29 @@ func (p *Response) FastRead(b []byte) (off int, err error) {
 					goto ReadFieldError
 				}
 			}
+		case 0x40f: // p.Diagnostics ID:4 thrift.LIST
+			var sz int
+			_, sz, l, err = x.ReadListBegin(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
+			p.Diagnostics = make([]*parser.Diagnostic, sz)
+			for i := 0; i < sz; i++ {
+				p.Diagnostics[i] = parser.NewDiagnostic()
+				l, err = p.Diagnostics[i].FastRead(b[off:])
+				off += l
+				if err != nil {
+					goto ReadFieldError
+				}
+			}
+		case 0x50c: // p.AST ID:5 thrift.STRUCT
+			p.AST = parser.NewThrift()
+			l, err = p.AST.FastRead(b[off:])
+			off += l
+			if err != nil {
+				goto ReadFieldError
+			}
 		default:
 			l, err = x.Skip(b[off:], ftyp)
 			off += l
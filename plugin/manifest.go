@@ -0,0 +1,306 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/version"
+)
+
+// ManifestSuffix is appended to the path of a plugin executable to find its
+// manifest, like "thrift-gen-validator.thriftgo.json".
+const ManifestSuffix = ".thriftgo.json"
+
+// The parts of the AST that a plugin can ask for in Manifest.Needs.
+const (
+	NeedComments    = "comments"
+	NeedAnnotations = "annotations"
+	NeedIncludes    = "includes"
+	NeedTypes       = "types"
+	NeedServices    = "services"
+)
+
+var allNeeds = []string{NeedComments, NeedAnnotations, NeedIncludes, NeedTypes, NeedServices}
+
+// The stages of plugins. The plugins at a stage run after those at the
+// stages before it.
+const (
+	// StageTransform plugins run before the backend. They may return a
+	// modified AST in Response.AST, which replaces the one in the request.
+	StageTransform = "transform"
+	// StageGenerate plugins run after the backend and add contents. It is the
+	// stage of plugins without manifests.
+	StageGenerate = "generate"
+	// StagePostProcess plugins run after the others. They receive the
+	// generated files in Request.Contents and replace those of the same
+	// names with the contents they return.
+	StagePostProcess = "post_process"
+)
+
+var allStages = []string{StageTransform, StageGenerate, StagePostProcess}
+
+// GeneratedParameter is the name of the plugin parameters that hold the files
+// generated by the backend, for plugins declaring Manifest.GeneratedFiles.
+const GeneratedParameter = "thriftgo.generated"
+
+// Manifest declares the capabilities of an external plugin. Without one, a
+// plugin receives the full AST and the version of thriftgo it is built with
+// is read from its build info.
+type Manifest struct {
+	// ThriftgoVersion is the version of thriftgo the plugin is built with,
+	// which decides the features of the protocol that can be used.
+	ThriftgoVersion string `json:"thriftgo_version,omitempty"`
+	// MinVersion is the lowest version of thriftgo that can run the plugin.
+	MinVersion string `json:"min_thriftgo_version,omitempty"`
+	// Needs lists the parts of the AST the plugin reads. The others are
+	// removed from its requests. The AST is sent in full if it is empty.
+	Needs []string `json:"needs,omitempty"`
+	// InsertionPoints tells that the plugin may insert contents at insertion
+	// points. Such contents are rejected if it is false.
+	InsertionPoints bool `json:"insertion_points,omitempty"`
+	// GeneratedFiles asks for the names of the files generated by the
+	// backend, which are appended to the plugin parameters in the form of
+	// GeneratedParameter=name.
+	GeneratedFiles bool `json:"generated_files,omitempty"`
+	// Scopes asks for the identifiers generated by the backend for the
+	// definitions in the IDLs, which are set to Request.Scopes. Only
+	// backends implementing backend.ScopeReporter provide them.
+	Scopes bool `json:"scopes,omitempty"`
+	// Stage decides when the plugin runs. It defaults to StageGenerate.
+	Stage string `json:"stage,omitempty"`
+	// After lists the names of the plugins that must run before this one if
+	// they are used. They must not be at a later stage.
+	After []string `json:"after,omitempty"`
+}
+
+// ReadManifest reads the manifest of a plugin executable. It returns nil if
+// the plugin has none.
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path + ManifestSuffix)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := new(Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("invalid manifest '%s': %w", path+ManifestSuffix, err)
+	}
+	return m, nil
+}
+
+// Check reports whether the plugin can be run by this version of thriftgo.
+func (m *Manifest) Check() error {
+	for _, n := range m.Needs {
+		if !contains(allNeeds, n) {
+			return fmt.Errorf("unknown need %q, expecting one of: %s", n, strings.Join(allNeeds, ", "))
+		}
+	}
+	if m.Stage != "" && !contains(allStages, m.Stage) {
+		return fmt.Errorf("unknown stage %q, expecting one of: %s", m.Stage, strings.Join(allStages, ", "))
+	}
+	if m.Stage == StageTransform && len(m.Needs) > 0 {
+		return errors.New("plugins at the transform stage receive the full AST and cannot declare needs")
+	}
+	if m.MinVersion == "" {
+		return nil
+	}
+	c, err := compareVersions(version.ThriftgoVersion, m.MinVersion)
+	if err != nil {
+		return err
+	}
+	if c < 0 {
+		return fmt.Errorf("thriftgo %s or later is required, but the version is %s", m.MinVersion, version.ThriftgoVersion)
+	}
+	return nil
+}
+
+// ManifestOf returns the manifest of a plugin, or nil if it has none.
+func ManifestOf(p Plugin) *Manifest {
+	if mp, ok := p.(interface{ Manifest() *Manifest }); ok {
+		return mp.Manifest()
+	}
+	return nil
+}
+
+// StageOf returns the stage of a plugin.
+func StageOf(p Plugin) string {
+	if m := ManifestOf(p); m != nil && m.Stage != "" {
+		return m.Stage
+	}
+	return StageGenerate
+}
+
+// StageIndex returns the position of a stage in the order that plugins run,
+// or -1 if the stage is unknown.
+func StageIndex(stage string) int {
+	for i, s := range allStages {
+		if s == stage {
+			return i
+		}
+	}
+	return -1
+}
+
+// compareVersions compares two versions like "v0.4.5" or "0.4.5". Pre-release
+// suffixes are ignored.
+func compareVersions(a, b string) (int, error) {
+	x, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			if x[i] < y[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseVersion(v string) (n [3]int, err error) {
+	s, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), "-")
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return n, fmt.Errorf("invalid version %q", v)
+	}
+	for i, p := range parts {
+		if n[i], err = strconv.Atoi(p); err != nil {
+			return n, fmt.Errorf("invalid version %q", v)
+		}
+	}
+	return n, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// slimRequest returns a copy of the request whose AST only has the parts in
+// needs. The request is not modified.
+func slimRequest(req *Request, needs []string) (*Request, error) {
+	has := make(map[string]bool)
+	for _, n := range needs {
+		has[n] = true
+	}
+	r := *req
+	r.AST = slimAST(req.AST, has, make(map[*parser.Thrift]*parser.Thrift))
+	if has[NeedComments] && has[NeedAnnotations] {
+		return &r, nil
+	}
+	// comments and annotations are kept by nested nodes, so they are
+	// removed from a deep copy
+	clone := NewRequest()
+	if _, err := clone.FastRead(r.FastAppend(nil)); err != nil {
+		return nil, err
+	}
+	stripAST(clone.AST, has, make(map[*parser.Thrift]bool))
+	return clone, nil
+}
+
+// slimAST copies the AST without the definitions and includes that are not needed.
+func slimAST(t *parser.Thrift, has map[string]bool, seen map[*parser.Thrift]*parser.Thrift) *parser.Thrift {
+	if s, ok := seen[t]; ok {
+		return s
+	}
+	s := *t
+	seen[t] = &s
+	s.Includes = nil
+	for _, inc := range t.Includes {
+		i := *inc
+		if has[NeedIncludes] && inc.Reference != nil {
+			i.Reference = slimAST(inc.Reference, has, seen)
+		} else {
+			i.Reference = nil
+		}
+		s.Includes = append(s.Includes, &i)
+	}
+	if !has[NeedTypes] {
+		s.Typedefs, s.Constants, s.Enums = nil, nil, nil
+		s.Structs, s.Unions, s.Exceptions = nil, nil, nil
+	}
+	if !has[NeedServices] {
+		s.Services = nil
+	}
+	return &s
+}
+
+// stripAST removes the comments and annotations that are not needed in place.
+func stripAST(t *parser.Thrift, has map[string]bool, seen map[*parser.Thrift]bool) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+	strip := func(comments *string, annos *parser.Annotations) {
+		if !has[NeedComments] {
+			*comments = ""
+		}
+		if !has[NeedAnnotations] {
+			*annos = nil
+		}
+	}
+	for _, v := range t.Typedefs {
+		strip(&v.ReservedComments, &v.Annotations)
+	}
+	for _, v := range t.Constants {
+		strip(&v.ReservedComments, &v.Annotations)
+	}
+	for _, e := range t.Enums {
+		strip(&e.ReservedComments, &e.Annotations)
+		for _, v := range e.Values {
+			strip(&v.ReservedComments, &v.Annotations)
+		}
+	}
+	for _, s := range t.GetStructLikes() {
+		strip(&s.ReservedComments, &s.Annotations)
+		for _, f := range s.Fields {
+			strip(&f.ReservedComments, &f.Annotations)
+		}
+	}
+	for _, s := range t.Services {
+		strip(&s.ReservedComments, &s.Annotations)
+		for _, fn := range s.Functions {
+			strip(&fn.ReservedComments, &fn.Annotations)
+			for _, f := range append(append([]*parser.Field{}, fn.Arguments...), fn.Throws...) {
+				strip(&f.ReservedComments, &f.Annotations)
+			}
+		}
+	}
+	for _, inc := range t.Includes {
+		if inc.Reference != nil {
+			stripAST(inc.Reference, has, seen)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestCompareVersions(t *testing.T) {
+	for _, c := range []struct {
+		a, b string
+		want int
+	}{
+		{"0.4.5", "v0.4.5", 0},
+		{"v0.4.5-rc1", "0.4.5", 0},
+		{"0.4.5", "v0.10.0", -1},
+		{"v1.0.0", "0.99.99", 1},
+	} {
+		got, err := compareVersions(c.a, c.b)
+		test.Assert(t, err == nil && got == c.want, c.a, c.b, got, err)
+	}
+	_, err := compareVersions("0.4", "0.4.5")
+	test.Assert(t, err != nil)
+}
+
+func TestManifestCheck(t *testing.T) {
+	test.Assert(t, (&Manifest{MinVersion: "v0.1.0", Needs: []string{NeedServices}}).Check() == nil)
+	test.Assert(t, (&Manifest{MinVersion: "v99.0.0"}).Check() != nil)
+	test.Assert(t, (&Manifest{MinVersion: "latest"}).Check() != nil)
+	test.Assert(t, (&Manifest{Needs: []string{"everything"}}).Check() != nil)
+	test.Assert(t, (&Manifest{Stage: StagePostProcess, Needs: []string{NeedTypes}}).Check() == nil)
+	test.Assert(t, (&Manifest{Stage: StageTransform, Needs: []string{NeedTypes}}).Check() != nil)
+	test.Assert(t, (&Manifest{Stage: "prepare"}).Check() != nil)
+}
+
+func TestSlimRequest(t *testing.T) {
+	base, err := parser.ParseString("base.thrift", "struct Base {}")
+	test.Assert(t, err == nil, err)
+	ast, err := parser.ParseString("a.thrift", `include "base.thrift"
+// A is a struct.
+struct A { 1: i32 a (k = "v") }
+// S is a service.
+service S { void f() }
+`)
+	test.Assert(t, err == nil, err)
+	ast.Includes[0].Reference = base
+	req := NewRequest()
+	req.AST = ast
+
+	slim, err := slimRequest(req, []string{NeedServices})
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(slim.AST.Structs) == 0 && len(slim.AST.Services) == 1)
+	test.Assert(t, slim.AST.Includes[0].Path == "base.thrift" && slim.AST.Includes[0].Reference == nil)
+	test.Assert(t, slim.AST.Services[0].ReservedComments == "")
+
+	slim, err = slimRequest(req, []string{NeedTypes, NeedIncludes, NeedComments})
+	test.Assert(t, err == nil, err)
+	test.Assert(t, len(slim.AST.Structs) == 1 && len(slim.AST.Services) == 0)
+	test.Assert(t, slim.AST.Includes[0].Reference.Filename == "base.thrift")
+	test.Assert(t, slim.AST.Structs[0].ReservedComments != "" && len(slim.AST.Structs[0].Fields[0].Annotations) == 0)
+
+	// the request is not modified
+	test.Assert(t, len(ast.Structs) == 1 && len(ast.Services) == 1 && ast.Includes[0].Reference == base)
+	test.Assert(t, ast.Services[0].ReservedComments != "" && len(ast.Structs[0].Fields[0].Annotations) == 1)
+}
+
+func TestManifestPlugin(t *testing.T) {
+	t.Setenv("THRIFTGO_TEST_PLUGIN", "1")
+	path := filepath.Join(t.TempDir(), "plugin")
+	test.Assert(t, os.Symlink(os.Args[0], path) == nil)
+	lookup := func(manifest string) (ContextPlugin, error) {
+		test.Assert(t, os.WriteFile(path+ManifestSuffix, []byte(manifest), 0o644) == nil)
+		p, err := Lookup("pid=" + path)
+		if err != nil {
+			return nil, err
+		}
+		test.Assert(t, ManifestOf(p) != nil)
+		return p.(ContextPlugin), nil
+	}
+
+	_, err := lookup(`{"min_thriftgo_version": "v99.0.0"}`)
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "v99.0.0"), err)
+	_, err = lookup(`{"needs": [`)
+	test.Assert(t, err != nil)
+
+	req := newTestRequest()
+	ast, err := parser.ParseString("a.thrift", "struct A {}\n// S is a service.\nservice S {}")
+	test.Assert(t, err == nil, err)
+	req.AST = ast
+	p, err := lookup(`{"needs": ["services"]}`)
+	test.Assert(t, err == nil, err)
+	res := p.ExecuteContext(context.Background(), req)
+	test.Assert(t, res.Error == nil, res.GetError())
+	test.Assert(t, res.Contents[1].Content == `structs=0 services=1 includes=0 comments="" params=[]`, res.Contents[1].Content)
+
+	req.PluginParameters = []string{"insert"}
+	res = p.ExecuteContext(context.Background(), req)
+	test.Assert(t, res.Error != nil && strings.Contains(*res.Error, "insertion_points"), res.GetError())
+
+	p, err = lookup(`{"insertion_points": true}`)
+	test.Assert(t, err == nil, err)
+	res = p.ExecuteContext(context.Background(), req)
+	test.Assert(t, res.Error == nil, res.GetError())
+	test.Assert(t, strings.HasPrefix(res.Contents[1].Content, `structs=1 services=1 includes=0 comments="// S is a service."`), res.Contents[1].Content)
+}
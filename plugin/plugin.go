@@ -20,6 +20,7 @@ import (
 	"debug/buildinfo"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strconv"
@@ -108,31 +109,85 @@ type Plugin interface {
 	Execute(req *Request) (res *Response)
 }
 
+// ContextPlugin is a Plugin whose execution can be cancelled.
+type ContextPlugin interface {
+	Plugin
+
+	// ExecuteContext is like Execute, and stops the plugin when ctx is done.
+	// MaxExecutionTime is not applied.
+	ExecuteContext(ctx context.Context, req *Request) (res *Response)
+}
+
 // Lookup searches for PATH to find a plugin that match the description.
 func Lookup(arg string) (Plugin, error) {
+	name, full, path, wasm, err := lookPath(arg)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ReadManifest(path)
+	if err == nil && m != nil {
+		err = m.Check()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("plugin '%s': %w", name, err)
+	}
+	return &external{name: name, full: full, path: path, manifest: m, wasm: wasm}, nil
+}
+
+// InDir returns the plugin running in dir if it is an external program, or
+// the plugin itself otherwise. An empty dir is the working directory of the
+// process. WebAssembly plugins only see the directories of their sandbox, so
+// they are not affected.
+func InDir(p Plugin, dir string) Plugin {
+	e, ok := p.(*external)
+	if !ok || e.wasm || dir == "" {
+		return p
+	}
+	c := *e
+	c.dir = dir
+	return &c
+}
+
+// LookPath returns the path of the executable or the WebAssembly module of
+// the plugin that match the description.
+func LookPath(arg string) (string, error) {
+	_, _, path, _, err := lookPath(arg)
+	return path, err
+}
+
+func lookPath(arg string) (name, full, path string, wasm bool, err error) {
 	parts := strings.SplitN(arg, "=", 2)
 
-	var name, full string
 	switch len(parts) {
 	case 0:
-		return nil, fmt.Errorf("invalid plugin name: %s", arg)
+		return "", "", "", false, fmt.Errorf("invalid plugin name: %s", arg)
 	case 1:
 		name, full = arg, "thrift-gen-"+arg
 	case 2:
 		name, full = parts[0], parts[1]
 	}
 
-	path, err := exec.LookPath(full)
-	if err != nil {
-		return nil, err
+	wasm = strings.HasSuffix(full, WasmSuffix)
+	if wasm {
+		path, err = lookWasm(full)
+	} else if path, err = exec.LookPath(full); err != nil && len(parts) == 1 {
+		// fall back to a WebAssembly plugin of the same name
+		if p, werr := lookWasm(full + WasmSuffix); werr == nil {
+			path, err, wasm = p, nil, true
+		}
 	}
-	return &external{name: name, full: full, path: path}, nil
+	return name, full, path, wasm, err
 }
 
 type external struct {
-	name string
-	full string
-	path string
+	name     string
+	full     string
+	path     string
+	pool     *ServerPool
+	manifest *Manifest
+	wasm     bool
+	sandbox  *Sandbox
+	dir      string
 }
 
 // Name implements the Plugin interface.
@@ -142,8 +197,63 @@ func (e *external) Name() string {
 
 // Execute implements the Plugin interface.
 func (e *external) Execute(req *Request) (res *Response) {
-	trailer := supportDataTrailer(readPluginThriftGoVersion(e.path))
-	if trailer && enableCompressThriftInclude {
+	ctx := context.Background()
+	if MaxExecutionTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, MaxExecutionTime)
+		defer cancel()
+	}
+	return e.ExecuteContext(ctx, req)
+}
+
+// Manifest returns the manifest of the plugin, or nil if it has none.
+func (e *external) Manifest() *Manifest {
+	return e.manifest
+}
+
+// ExecuteContext implements the ContextPlugin interface.
+func (e *external) ExecuteContext(ctx context.Context, req *Request) (res *Response) {
+	m := e.manifest
+	if m != nil && len(m.Needs) > 0 {
+		var err error
+		if req, err = slimRequest(req, m.Needs); err != nil {
+			err = fmt.Errorf("failed to slim request: %w", err)
+			return BuildErrorResponse(err.Error())
+		}
+	}
+	res = e.run(ctx, req)
+	if m != nil && !m.InsertionPoints {
+		for _, c := range res.Contents {
+			if c.IsSetInsertionPoint() {
+				err := fmt.Errorf("plugin '%s' inserts contents at '%s' without declaring insertion_points in its manifest",
+					e.Name(), c.GetInsertionPoint())
+				return BuildErrorResponse(err.Error(), res.Warnings...)
+			}
+		}
+	}
+	return res
+}
+
+// version returns the version of thriftgo that the plugin is built with.
+func (e *external) version() string {
+	if e.manifest != nil {
+		return e.manifest.ThriftgoVersion
+	}
+	return pluginVersion(e.path)
+}
+
+// run encodes the request and executes the plugin.
+func (e *external) run(ctx context.Context, req *Request) (res *Response) {
+	var features uint8
+	if supportDataTrailer(e.version()) {
+		if enableCompressThriftInclude {
+			features |= featureCompressInclude
+		}
+		if e.pool != nil && !e.wasm {
+			features |= featureServer
+		}
+	}
+	if features&featureCompressInclude != 0 {
 		m := map[string]*parser.Thrift{}
 		compressThriftInclude(req.AST, m)
 		defer decompressThriftInclude(req.AST, m) // revert
@@ -153,25 +263,18 @@ func (e *external) Execute(req *Request) (res *Response) {
 		err = fmt.Errorf("failed to marshal request: %w", err)
 		return BuildErrorResponse(err.Error())
 	}
-	if trailer && enableCompressThriftInclude {
-		data = appendDataTrailer(data, featureCompressInclude)
+	if features != 0 {
+		data = appendDataTrailer(data, features)
 	}
 
-	ctx := context.Background()
-
-	if MaxExecutionTime > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, MaxExecutionTime)
-		defer cancel()
+	if e.pool.isServable(e.path) {
+		if res, ok := e.execute(ctx, data); ok {
+			return res
+		}
 	}
 
-	stdin := bytes.NewReader(data)
 	var stdout, stderr bytes.Buffer
-
-	cmd := exec.CommandContext(ctx, e.path)
-	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, &stdout, &stderr
-
-	if err = cmd.Run(); err != nil {
+	if err = e.runOnce(ctx, bytes.NewReader(data), &stdout, &stderr); err != nil {
 		warns := []string{
 			"stdout:\n" + stdout.String(),
 			"stderr:\n" + stderr.String(),
@@ -190,9 +293,23 @@ func (e *external) Execute(req *Request) (res *Response) {
 	if warn := stderr.String(); len(warn) > 0 {
 		res.Warnings = append(res.Warnings, e.Name()+" stderr:\n"+warn)
 	}
+	if e.pool != nil && hasDataTrailerFeature(stdout.Bytes(), featureServer) {
+		e.pool.setServable(e.path, true)
+	}
 	return res
 }
 
+// runOnce runs the plugin for a single request.
+func (e *external) runOnce(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer) error {
+	if e.wasm {
+		return e.runWasm(ctx, stdin, stdout, stderr)
+	}
+	cmd := exec.CommandContext(ctx, e.path)
+	cmd.Dir = e.dir
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+	return cmd.Run()
+}
+
 type SDKPlugin interface {
 	Invoke(req *Request) (res *Response)
 	GetName() string
@@ -201,6 +318,9 @@ type SDKPlugin interface {
 
 const thriftgoPackage = "github.com/cloudwego/thriftgo"
 
+// pluginVersion reads the version of thriftgo that a plugin is built with.
+var pluginVersion = readPluginThriftGoVersion
+
 func readPluginThriftGoVersion(name string) string {
 	bi, err := buildinfo.ReadFile(name)
 	if err != nil {
@@ -271,6 +391,9 @@ func compressThriftInclude(p *parser.Thrift, m map[string]*parser.Thrift) {
 		m = map[string]*parser.Thrift{}
 	}
 	for _, incl := range p.Includes {
+		if incl.Reference == nil {
+			continue
+		}
 		if m[incl.Reference.Filename] != nil {
 			// visited, only keep the filename for mapping
 			incl.Reference = &parser.Thrift{Filename: refFilenamePrefix + incl.Reference.Filename}
@@ -288,6 +411,9 @@ func decompressThriftInclude(p *parser.Thrift, m map[string]*parser.Thrift) {
 		collectThriftInclude(p, m)
 	}
 	for _, incl := range p.Includes {
+		if incl.Reference == nil {
+			continue
+		}
 		if fn := strings.TrimPrefix(incl.Reference.Filename, refFilenamePrefix); fn != incl.Reference.Filename {
 			incl.Reference = m[fn]
 			if incl.Reference == nil {
@@ -300,7 +426,7 @@ func decompressThriftInclude(p *parser.Thrift, m map[string]*parser.Thrift) {
 
 func collectThriftInclude(p *parser.Thrift, m map[string]*parser.Thrift) {
 	for _, incl := range p.Includes {
-		if !strings.HasPrefix(incl.Reference.Filename, refFilenamePrefix) {
+		if incl.Reference != nil && !strings.HasPrefix(incl.Reference.Filename, refFilenamePrefix) {
 			m[incl.Reference.Filename] = incl.Reference
 			collectThriftInclude(incl.Reference, m)
 		}
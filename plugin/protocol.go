@@ -1,4 +1,4 @@
-// Code generated by thriftgo (0.4.1). DO NOT EDIT.
+// Code generated by thriftgo (0.4.5). DO NOT EDIT.
 
 package plugin
 
@@ -16,13 +16,15 @@ type Request struct {
 	OutputPath          string         `thrift:"OutputPath,5,required" frugal:"5,required,string" json:"OutputPath"`
 	Recursive           bool           `thrift:"Recursive,6,required" frugal:"6,required,bool" json:"Recursive"`
 	AST                 *parser.Thrift `thrift:"AST,7,required" frugal:"7,required,parser.Thrift" json:"AST"`
+	Scopes              []*Scope       `thrift:"Scopes,8,optional" frugal:"8,optional,list<Scope>" json:"Scopes,omitempty"`
+	Contents            []*Generated   `thrift:"Contents,9,optional" frugal:"9,optional,list<Generated>" json:"Contents,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewRequest, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x7, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0xb, 0x0,
 		0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0,
-		0x0, 0x0, 0x7, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x7, 0x56,
+		0x0, 0x0, 0x9, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x7, 0x56,
 		0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4,
 		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0,
 		0x2, 0x0, 0x0, 0x0, 0x13, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x61,
@@ -41,7 +43,13 @@ func init() {
 		0x72, 0x73, 0x69, 0x76, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4, 0x8,
 		0x0, 0x1, 0x0, 0x0, 0x0, 0x2, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x7, 0xb, 0x0, 0x2,
 		0x0, 0x0, 0x0, 0x3, 0x41, 0x53, 0x54, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0,
-		0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0,
+		0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x8, 0xb,
+		0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x8, 0x0, 0x3, 0x0,
+		0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0xc, 0x0, 0x3,
+		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x9, 0xb,
+		0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x8, 0x0,
+		0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0xc,
+		0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -85,10 +93,36 @@ func (p *Request) GetAST() (v *parser.Thrift) {
 	return p.AST
 }
 
+var Request_Scopes_DEFAULT []*Scope
+
+func (p *Request) GetScopes() (v []*Scope) {
+	if !p.IsSetScopes() {
+		return Request_Scopes_DEFAULT
+	}
+	return p.Scopes
+}
+
+var Request_Contents_DEFAULT []*Generated
+
+func (p *Request) GetContents() (v []*Generated) {
+	if !p.IsSetContents() {
+		return Request_Contents_DEFAULT
+	}
+	return p.Contents
+}
+
 func (p *Request) IsSetAST() bool {
 	return p.AST != nil
 }
 
+func (p *Request) IsSetScopes() bool {
+	return p.Scopes != nil
+}
+
+func (p *Request) IsSetContents() bool {
+	return p.Contents != nil
+}
+
 func (p *Request) String() string {
 	if p == nil {
 		return "<nil>"
@@ -104,6 +138,149 @@ var fieldIDToName_Request = map[int16]string{
 	5: "OutputPath",
 	6: "Recursive",
 	7: "AST",
+	8: "Scopes",
+	9: "Contents",
+}
+
+type Symbol struct {
+	Kind       string            `thrift:"Kind,1,required" frugal:"1,required,string" json:"Kind"`
+	Name       string            `thrift:"Name,2,required" frugal:"2,required,string" json:"Name"`
+	Identifier string            `thrift:"Identifier,3,required" frugal:"3,required,string" json:"Identifier"`
+	Extra      map[string]string `thrift:"Extra,4,optional" frugal:"4,optional,map<string:string>" json:"Extra,omitempty"`
+}
+
+func init() {
+	meta.RegisterStruct(NewSymbol, []byte{
+		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x6, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0xb, 0x0, 0x2,
+		0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0,
+		0x0, 0x4, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x4, 0x4b, 0x69,
+		0x6e, 0x64, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0,
+		0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0,
+		0x4, 0x4e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4, 0x8,
+		0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x3, 0xb, 0x0, 0x2,
+		0x0, 0x0, 0x0, 0xa, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x8, 0x0,
+		0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0,
+		0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x5, 0x45, 0x78, 0x74,
+		0x72, 0x61, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0,
+		0x0, 0x0, 0xd, 0xc, 0x0, 0x2, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0xc, 0x0,
+		0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0, 0x0,
+	})
+}
+
+func NewSymbol() *Symbol {
+	return &Symbol{}
+}
+
+func (p *Symbol) InitDefault() {
+}
+
+func (p *Symbol) GetKind() (v string) {
+	return p.Kind
+}
+
+func (p *Symbol) GetName() (v string) {
+	return p.Name
+}
+
+func (p *Symbol) GetIdentifier() (v string) {
+	return p.Identifier
+}
+
+var Symbol_Extra_DEFAULT map[string]string
+
+func (p *Symbol) GetExtra() (v map[string]string) {
+	if !p.IsSetExtra() {
+		return Symbol_Extra_DEFAULT
+	}
+	return p.Extra
+}
+
+func (p *Symbol) IsSetExtra() bool {
+	return p.Extra != nil
+}
+
+func (p *Symbol) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Symbol(%+v)", *p)
+}
+
+var fieldIDToName_Symbol = map[int16]string{
+	1: "Kind",
+	2: "Name",
+	3: "Identifier",
+	4: "Extra",
+}
+
+type Scope struct {
+	Filename   string    `thrift:"Filename,1,required" frugal:"1,required,string" json:"Filename"`
+	Package    string    `thrift:"Package,2,required" frugal:"2,required,string" json:"Package"`
+	ImportPath string    `thrift:"ImportPath,3,required" frugal:"3,required,string" json:"ImportPath"`
+	File       string    `thrift:"File,4,required" frugal:"4,required,string" json:"File"`
+	Symbols    []*Symbol `thrift:"Symbols,5,required" frugal:"5,required,list<Symbol>" json:"Symbols"`
+}
+
+func init() {
+	meta.RegisterStruct(NewScope, []byte{
+		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x5, 0x53, 0x63, 0x6f, 0x70, 0x65, 0xb, 0x0, 0x2, 0x0,
+		0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc, 0x0, 0x0, 0x0,
+		0x5, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x46, 0x69, 0x6c,
+		0x65, 0x6e, 0x61, 0x6d, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4, 0x8,
+		0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2,
+		0x0, 0x0, 0x0, 0x7, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0,
+		0x0, 0x1, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0,
+		0x1, 0x0, 0x3, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0xa, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+		0x50, 0x61, 0x74, 0x68, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4, 0x8, 0x0,
+		0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x4, 0xb, 0x0, 0x2, 0x0,
+		0x0, 0x0, 0x4, 0x46, 0x69, 0x6c, 0x65, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x1, 0xc, 0x0,
+		0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x5, 0xb,
+		0x0, 0x2, 0x0, 0x0, 0x0, 0x7, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x8, 0x0, 0x3,
+		0x0, 0x0, 0x0, 0x1, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0xc, 0x0,
+		0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x0,
+	})
+}
+
+func NewScope() *Scope {
+	return &Scope{}
+}
+
+func (p *Scope) InitDefault() {
+}
+
+func (p *Scope) GetFilename() (v string) {
+	return p.Filename
+}
+
+func (p *Scope) GetPackage() (v string) {
+	return p.Package
+}
+
+func (p *Scope) GetImportPath() (v string) {
+	return p.ImportPath
+}
+
+func (p *Scope) GetFile() (v string) {
+	return p.File
+}
+
+func (p *Scope) GetSymbols() (v []*Symbol) {
+	return p.Symbols
+}
+
+func (p *Scope) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Scope(%+v)", *p)
+}
+
+var fieldIDToName_Scope = map[int16]string{
+	1: "Filename",
+	2: "Package",
+	3: "ImportPath",
+	4: "File",
+	5: "Symbols",
 }
 
 type Generated struct {
@@ -178,16 +355,18 @@ var fieldIDToName_Generated = map[int16]string{
 }
 
 type Response struct {
-	Error    *string      `thrift:"Error,1,optional" frugal:"1,optional,string" json:"Error,omitempty"`
-	Contents []*Generated `thrift:"Contents,2,optional" frugal:"2,optional,list<Generated>" json:"Contents,omitempty"`
-	Warnings []string     `thrift:"Warnings,3,optional" frugal:"3,optional,list<string>" json:"Warnings,omitempty"`
+	Error       *string              `thrift:"Error,1,optional" frugal:"1,optional,string" json:"Error,omitempty"`
+	Contents    []*Generated         `thrift:"Contents,2,optional" frugal:"2,optional,list<Generated>" json:"Contents,omitempty"`
+	Warnings    []string             `thrift:"Warnings,3,optional" frugal:"3,optional,list<string>" json:"Warnings,omitempty"`
+	Diagnostics []*parser.Diagnostic `thrift:"Diagnostics,4,optional" frugal:"4,optional,list<parser.Diagnostic>" json:"Diagnostics,omitempty"`
+	AST         *parser.Thrift       `thrift:"AST,5,optional" frugal:"5,optional,parser.Thrift" json:"AST,omitempty"`
 }
 
 func init() {
 	meta.RegisterStruct(NewResponse, []byte{
 		0xb, 0x0, 0x1, 0x0, 0x0, 0x0, 0x8, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0xb,
 		0x0, 0x2, 0x0, 0x0, 0x0, 0x6, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0xf, 0x0, 0x3, 0xc,
-		0x0, 0x0, 0x0, 0x3, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x5,
+		0x0, 0x0, 0x0, 0x5, 0x6, 0x0, 0x1, 0x0, 0x1, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x5,
 		0x45, 0x72, 0x72, 0x6f, 0x72, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8,
 		0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x2, 0xb, 0x0, 0x2,
 		0x0, 0x0, 0x0, 0x8, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x8, 0x0, 0x3, 0x0,
@@ -195,7 +374,13 @@ func init() {
 		0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0, 0x3, 0xb,
 		0x0, 0x2, 0x0, 0x0, 0x0, 0x8, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x8, 0x0,
 		0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xf, 0xc,
-		0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0, 0x0,
+		0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xb, 0x0, 0x0, 0x0, 0x6, 0x0, 0x1, 0x0,
+		0x4, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0xb, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+		0x69, 0x63, 0x73, 0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1,
+		0x0, 0x0, 0x0, 0xf, 0xc, 0x0, 0x3, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0, 0xc, 0x0, 0x0,
+		0x0, 0x6, 0x0, 0x1, 0x0, 0x5, 0xb, 0x0, 0x2, 0x0, 0x0, 0x0, 0x3, 0x41, 0x53, 0x54,
+		0x8, 0x0, 0x3, 0x0, 0x0, 0x0, 0x2, 0xc, 0x0, 0x4, 0x8, 0x0, 0x1, 0x0, 0x0, 0x0,
+		0xc, 0x0, 0x0, 0x0,
 	})
 }
 
@@ -233,6 +418,24 @@ func (p *Response) GetWarnings() (v []string) {
 	return p.Warnings
 }
 
+var Response_Diagnostics_DEFAULT []*parser.Diagnostic
+
+func (p *Response) GetDiagnostics() (v []*parser.Diagnostic) {
+	if !p.IsSetDiagnostics() {
+		return Response_Diagnostics_DEFAULT
+	}
+	return p.Diagnostics
+}
+
+var Response_AST_DEFAULT *parser.Thrift
+
+func (p *Response) GetAST() (v *parser.Thrift) {
+	if !p.IsSetAST() {
+		return Response_AST_DEFAULT
+	}
+	return p.AST
+}
+
 func (p *Response) IsSetError() bool {
 	return p.Error != nil
 }
@@ -245,6 +448,14 @@ func (p *Response) IsSetWarnings() bool {
 	return p.Warnings != nil
 }
 
+func (p *Response) IsSetDiagnostics() bool {
+	return p.Diagnostics != nil
+}
+
+func (p *Response) IsSetAST() bool {
+	return p.AST != nil
+}
+
 func (p *Response) String() string {
 	if p == nil {
 		return "<nil>"
@@ -256,4 +467,6 @@ var fieldIDToName_Response = map[int16]string{
 	1: "Error",
 	2: "Contents",
 	3: "Warnings",
+	4: "Diagnostics",
+	5: "AST",
 }
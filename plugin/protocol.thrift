@@ -38,6 +38,43 @@ struct Request {
 
     // The abstract syntax trees of the parsed thrift IDL.
     7: required AST.Thrift AST,
+
+    // The identifiers that the backend generates for the definitions in
+    // each IDL. Only set for plugins that ask for them in their manifests.
+    8: optional list<Scope> Scopes,
+
+    // The files generated by the backend and the earlier plugins. Only set
+    // for plugins at the post_process stage.
+    9: optional list<Generated> Contents,
+}
+
+// Symbol maps a definition in an IDL to its identifier in the generated codes.
+struct Symbol {
+    // The kind of the definition: typedef, constant, enum, enum_value, struct,
+    // union, exception, field, service or function.
+    1: required string Kind,
+    // The name in the IDL. Members are qualified by their parents, like
+    // "Struct.field", "Enum.VALUE" or "Service.method".
+    2: required string Name,
+    // The identifier in the generated codes.
+    3: required string Identifier,
+    // Other identifiers derived from the definition, like "getter", "setter"
+    // and "is_set" of fields, "constructor" of structs, or "args" and
+    // "result" of functions.
+    4: optional map<string, string> Extra,
+}
+
+// Scope describes the codes generated by the backend for an IDL.
+struct Scope {
+    // The Filename of the AST of the IDL.
+    1: required string Filename,
+    // The name of the package of the generated codes.
+    2: required string Package,
+    // The import path of the package, with package_prefix and use_package applied.
+    3: required string ImportPath,
+    // The path of the generated file holding the definitions.
+    4: required string File,
+    5: required list<Symbol> Symbols,
 }
 
 struct Generated {
@@ -63,4 +100,13 @@ struct Response {
 
     // Warnings produces during execution.
     3: optional list<string> Warnings,
+
+    // Structured diagnostics produced during execution. Unlike Warnings, they
+    // may carry a severity, a position in the IDL and suggested fixes.
+    4: optional list<AST.Diagnostic> Diagnostics,
+
+    // The modified abstract syntax tree. Only accepted from plugins at the
+    // transform stage, and it replaces the AST for the backend and the later
+    // plugins.
+    5: optional AST.Thrift AST,
 }
@@ -0,0 +1,83 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Serve runs a plugin with the handler. It answers a single request from the
+// standard input like a plain plugin, and serves many requests in a process
+// when thriftgo starts the plugin in the persistent mode.
+func Serve(handler func(req *Request) (res *Response)) error {
+	return serve(os.Stdin, os.Stdout, os.Getenv(serverEnv) != "", handler)
+}
+
+func serve(r io.Reader, w io.Writer, persistent bool, handler func(req *Request) *Response) error {
+	if !persistent {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		out, err := answer(data, handler)
+		if err != nil {
+			return err
+		}
+		if hasDataTrailerFeature(data, featureServer) {
+			out = appendDataTrailer(out, featureServer)
+		}
+		_, err = w.Write(out)
+		return err
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		kind, payload, err := readFrame(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case frameHello:
+			err = writeFrame(w, frameHello, []byte(serverProtocol))
+		case frameRequest:
+			var out []byte
+			if out, err = answer(payload, handler); err == nil {
+				err = writeFrame(w, frameResponse, out)
+			}
+		case frameShutdown:
+			return nil
+		default:
+			err = fmt.Errorf("unknown frame kind: %d", kind)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// answer decodes a request, runs the handler and encodes its response.
+func answer(data []byte, handler func(req *Request) *Response) ([]byte, error) {
+	req, err := UnmarshalRequest(data)
+	if err != nil {
+		return MarshalResponse(BuildErrorResponse(fmt.Sprintf("failed to unmarshal request: %s", err)))
+	}
+	return MarshalResponse(handler(req))
+}
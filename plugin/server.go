@@ -0,0 +1,364 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// The persistent mode of plugins.
+//
+// A plugin supporting it advertises featureServer in the data trailer of its
+// response to a request carrying the same feature. thriftgo may then start it
+// with serverEnv set to serverProtocol and exchange frames over the standard
+// input and output:
+//
+//	frame := length(uint32, big endian) kind(uint8) payload
+//
+// where length counts the kind and the payload. thriftgo sends a hello frame
+// with serverProtocol as the payload, and the plugin answers with the version
+// it speaks. Each request frame holds a request encoded like the input of a
+// plain plugin, and is answered by a response frame. A shutdown frame or the
+// end of the input stops the plugin.
+const (
+	featureServer uint8 = 1 << 1
+
+	serverEnv      = "THRIFTGO_PLUGIN_SERVER"
+	serverProtocol = "thriftgo-plugin-server/1"
+)
+
+// The kinds of frames.
+const (
+	frameHello uint8 = iota + 1
+	frameRequest
+	frameResponse
+	frameShutdown
+)
+
+const (
+	// handshakeTimeout limits the time for a started plugin to answer the hello frame.
+	handshakeTimeout = 5 * time.Second
+	// shutdownTimeout limits the time for a plugin to exit after a shutdown frame.
+	shutdownTimeout = 3 * time.Second
+)
+
+// maxFrameSize limits the length of a frame, so that a broken plugin can not
+// make thriftgo allocate arbitrary memory.
+var maxFrameSize uint32 = 256 << 20
+
+func writeFrame(w io.Writer, kind uint8, payload []byte) error {
+	if uint64(len(payload)) >= uint64(maxFrameSize) {
+		return fmt.Errorf("frame too large: %d bytes", 1+len(payload))
+	}
+	buf := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(buf, uint32(1+len(payload)))
+	buf[4] = kind
+	_, err := w.Write(append(buf, payload...))
+	return err
+}
+
+func readFrame(r io.Reader) (kind uint8, payload []byte, err error) {
+	var head [5]byte
+	if _, err = io.ReadFull(r, head[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = errors.New("truncated frame")
+		}
+		return 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(head[:4])
+	if n == 0 {
+		return 0, nil, errors.New("invalid frame length: 0")
+	}
+	if n > maxFrameSize {
+		return 0, nil, fmt.Errorf("frame too large: %d bytes", n)
+	}
+	payload = make([]byte, n-1)
+	if _, err = io.ReadFull(r, payload); err != nil {
+		return 0, nil, fmt.Errorf("truncated frame: %w", err)
+	}
+	return head[4], payload, nil
+}
+
+// ServerPool keeps external plugins that support the persistent mode running
+// between executions, so that a plugin is not started again for each IDL in
+// watch mode or batch generation. Plugins are executed one-shot until they
+// advertise the support. A pool can be shared by concurrent generations, and
+// starts another process of a plugin when all of them are busy.
+type ServerPool struct {
+	mu       sync.Mutex
+	servable map[string]bool
+	idle     map[serverKey][]*server
+	closed   bool
+}
+
+// serverKey identifies the servers that can execute a plugin.
+type serverKey struct {
+	path string
+	dir  string
+}
+
+// NewServerPool creates an empty ServerPool.
+func NewServerPool() *ServerPool {
+	return &ServerPool{
+		servable: make(map[string]bool),
+		idle:     make(map[serverKey][]*server),
+	}
+}
+
+// Lookup is like the Lookup function, and the returned plugin is executed by
+// the pool.
+func (p *ServerPool) Lookup(arg string) (Plugin, error) {
+	pl, err := Lookup(arg)
+	if err != nil {
+		return nil, err
+	}
+	pl.(*external).pool = p
+	return pl, nil
+}
+
+// Close stops the running plugins. Plugins that are executing stop when
+// they finish.
+func (p *ServerPool) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	var idle []*server
+	for _, ss := range p.idle {
+		idle = append(idle, ss...)
+	}
+	p.idle = make(map[serverKey][]*server)
+	p.mu.Unlock()
+
+	var errs []error
+	for _, s := range idle {
+		if err := s.shutdown(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *ServerPool) isServable(path string) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.servable[path] && !p.closed
+}
+
+func (p *ServerPool) setServable(path string, ok bool) {
+	p.mu.Lock()
+	p.servable[path] = ok
+	p.mu.Unlock()
+}
+
+// get returns an idle server of the plugin running in dir or starts a new one.
+func (p *ServerPool) get(ctx context.Context, path, dir string) (*server, error) {
+	key := serverKey{path, dir}
+	p.mu.Lock()
+	if ss := p.idle[key]; len(ss) > 0 {
+		s := ss[len(ss)-1]
+		p.idle[key] = ss[:len(ss)-1]
+		p.mu.Unlock()
+		return s, nil
+	}
+	p.mu.Unlock()
+	return startServer(ctx, key)
+}
+
+// put returns a server to the pool after an execution.
+func (p *ServerPool) put(s *server) {
+	p.mu.Lock()
+	if !p.closed {
+		p.idle[s.key] = append(p.idle[s.key], s)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	s.shutdown()
+}
+
+// server is a running plugin in the persistent mode.
+type server struct {
+	key    serverKey
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	pipe   *os.File // the read end of the standard output
+	stderr *syncBuffer
+	exited chan struct{}
+	err    error // the result of cmd.Wait, set before exited is closed
+}
+
+func startServer(ctx context.Context, key serverKey) (*server, error) {
+	s := &server{key: key, stderr: new(syncBuffer), exited: make(chan struct{})}
+	s.cmd = exec.Command(key.path)
+	s.cmd.Dir = key.dir
+	s.cmd.Env = append(os.Environ(), serverEnv+"="+serverProtocol)
+	s.cmd.Stderr = s.stderr
+	stdin, err := s.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	// the pipe is owned by the server instead of cmd, since Wait closes
+	// the pipe of StdoutPipe while the last frame may be unread
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		stdin.Close()
+		return nil, err
+	}
+	s.cmd.Stdout = pw
+	s.stdin, s.stdout, s.pipe = stdin, bufio.NewReader(pr), pr
+	err = s.cmd.Start()
+	pw.Close()
+	if err != nil {
+		stdin.Close()
+		pr.Close()
+		return nil, err
+	}
+	go func() {
+		s.err = s.cmd.Wait()
+		close(s.exited)
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, handshakeTimeout)
+	defer cancel()
+	kind, payload, err := s.call(ctx, frameHello, []byte(serverProtocol))
+	if err == nil && (kind != frameHello || string(payload) != serverProtocol) {
+		err = fmt.Errorf("unsupported protocol: %q", payload)
+	}
+	if err != nil {
+		s.kill()
+		return nil, fmt.Errorf("handshake with plugin '%s' failed: %w", key.path, err)
+	}
+	return s, nil
+}
+
+// call sends a frame and waits for the answer. The server is killed if ctx is
+// done before the answer arrives.
+func (s *server) call(ctx context.Context, kind uint8, payload []byte) (uint8, []byte, error) {
+	type result struct {
+		kind    uint8
+		payload []byte
+		err     error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		var r result
+		if r.err = writeFrame(s.stdin, kind, payload); r.err == nil {
+			r.kind, r.payload, r.err = readFrame(s.stdout)
+		}
+		ch <- r
+	}()
+	select {
+	case r := <-ch:
+		return r.kind, r.payload, r.err
+	case <-ctx.Done():
+		s.kill()
+		<-ch
+		return 0, nil, ctx.Err()
+	}
+}
+
+// shutdown asks the server to exit and kills it if it does not in time.
+func (s *server) shutdown() error {
+	writeFrame(s.stdin, frameShutdown, nil)
+	s.stdin.Close()
+	select {
+	case <-s.exited:
+		s.pipe.Close()
+		return s.err
+	case <-time.After(shutdownTimeout):
+		s.kill()
+		return fmt.Errorf("plugin '%s' did not exit in %s", s.key.path, shutdownTimeout)
+	}
+}
+
+func (s *server) kill() {
+	s.cmd.Process.Kill()
+	s.stdin.Close()
+	<-s.exited
+	s.pipe.Close()
+}
+
+// execute runs the plugin with the encoded request in the persistent mode. It
+// returns false if no server can be started, so that the plugin is executed
+// one-shot instead.
+func (e *external) execute(ctx context.Context, data []byte) (*Response, bool) {
+	if err := ctx.Err(); err != nil {
+		err = fmt.Errorf("execute plugin '%s' failed: %w", e.Name(), err)
+		return BuildErrorResponse(err.Error()), true
+	}
+	s, err := e.pool.get(ctx, e.path, e.dir)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("execute plugin '%s' failed: %w", e.Name(), ctx.Err())
+			return BuildErrorResponse(err.Error()), true
+		}
+		e.pool.setServable(e.path, false)
+		return nil, false
+	}
+	kind, payload, err := s.call(ctx, frameRequest, data)
+	if err == nil && kind != frameResponse {
+		err = fmt.Errorf("unexpected frame kind: %d", kind)
+	}
+	if err != nil {
+		s.kill()
+		err = fmt.Errorf("execute plugin '%s' failed: %w", e.Name(), err)
+		return BuildErrorResponse(err.Error(), "stderr:\n"+s.stderr.drain()), true
+	}
+	res, err := UnmarshalResponse(payload)
+	if err != nil {
+		s.kill()
+		err = fmt.Errorf("failed to unmarshal plugin response: %w\nstderr:\n%s", err, s.stderr.drain())
+		return BuildErrorResponse(err.Error()), true
+	}
+	if warn := s.stderr.drain(); len(warn) > 0 {
+		res.Warnings = append(res.Warnings, e.Name()+" stderr:\n"+warn)
+	}
+	e.pool.put(s)
+	return res, true
+}
+
+// syncBuffer collects the standard error of a server.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// drain returns and clears the collected content.
+func (b *syncBuffer) drain() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.buf.String()
+	b.buf.Reset()
+	return s
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv("THRIFTGO_TEST_PLUGIN") != "" {
+		if err := Serve(pid); err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// pid is a plugin that generates a file holding its process ID, followed by
+// a file describing the request. With the "insert" parameter, it also inserts
+// a content at an insertion point, and with "wd", it reports the working
+// directory in the last file.
+func pid(req *Request) *Response {
+	name, info := req.OutputPath, "request.txt"
+	res := &Response{Contents: []*Generated{
+		{Name: &name, Content: strconv.Itoa(os.Getpid())},
+		{Name: &info, Content: describeRequest(req)},
+	}}
+	for _, p := range req.PluginParameters {
+		if p == "insert" {
+			point := "imports"
+			res.Contents = append(res.Contents, &Generated{Name: &name, InsertionPoint: &point})
+		}
+		if p == "wd" {
+			wd, _ := os.Getwd()
+			res.Contents = append(res.Contents, &Generated{Name: &info, Content: wd})
+		}
+	}
+	return res
+}
+
+func describeRequest(req *Request) string {
+	ast, refs, comments := req.AST, 0, ""
+	for _, inc := range ast.Includes {
+		if inc.Reference != nil {
+			refs++
+		}
+	}
+	for _, s := range ast.Services {
+		comments += s.ReservedComments
+	}
+	return fmt.Sprintf("structs=%d services=%d includes=%d comments=%q params=%v",
+		len(ast.Structs), len(ast.Services), refs, comments, req.PluginParameters)
+}
+
+func newTestRequest() *Request {
+	req := NewRequest()
+	req.OutputPath = "pid.txt"
+	req.AST = &parser.Thrift{Filename: "a.thrift"}
+	return req
+}
+
+func TestFrame(t *testing.T) {
+	var buf bytes.Buffer
+	test.Assert(t, writeFrame(&buf, frameRequest, []byte("abc")) == nil)
+	test.Assert(t, writeFrame(&buf, frameShutdown, nil) == nil)
+	kind, payload, err := readFrame(&buf)
+	test.Assert(t, err == nil && kind == frameRequest && string(payload) == "abc", err)
+	kind, payload, err = readFrame(&buf)
+	test.Assert(t, err == nil && kind == frameShutdown && len(payload) == 0, err)
+
+	test.Assert(t, writeFrame(&buf, frameRequest, []byte("abc")) == nil)
+	buf.Truncate(buf.Len() - 1)
+	_, _, err = readFrame(&buf)
+	test.Assert(t, err != nil)
+
+	// lengths above the limit are rejected before allocating the payload
+	buf.Reset()
+	buf.Write([]byte{0xff, 0xff, 0xff, 0xff, frameResponse})
+	_, _, err = readFrame(&buf)
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "frame too large"), err)
+	defer func(n uint32) { maxFrameSize = n }(maxFrameSize)
+	maxFrameSize = 4
+	test.Assert(t, writeFrame(&buf, frameRequest, []byte("abcd")) != nil)
+}
+
+func TestServe(t *testing.T) {
+	data, err := MarshalRequest(newTestRequest())
+	test.Assert(t, err == nil, err)
+
+	// one-shot, advertising the persistent mode when asked
+	for _, features := range []uint8{0, featureServer} {
+		in := data
+		if features != 0 {
+			in = appendDataTrailer(append([]byte{}, data...), features)
+		}
+		var out bytes.Buffer
+		test.Assert(t, serve(bytes.NewReader(in), &out, false, pid) == nil)
+		res, err := UnmarshalResponse(out.Bytes())
+		test.Assert(t, err == nil && len(res.Contents) == 2, err)
+		test.Assert(t, hasDataTrailerFeature(out.Bytes(), featureServer) == (features != 0))
+	}
+
+	// persistent
+	var in, out bytes.Buffer
+	writeFrame(&in, frameHello, []byte(serverProtocol))
+	writeFrame(&in, frameRequest, data)
+	writeFrame(&in, frameRequest, data)
+	writeFrame(&in, frameShutdown, nil)
+	writeFrame(&in, frameRequest, data)
+	test.Assert(t, serve(&in, &out, true, pid) == nil)
+	kind, payload, err := readFrame(&out)
+	test.Assert(t, err == nil && kind == frameHello && string(payload) == serverProtocol, err)
+	for i := 0; i < 2; i++ {
+		kind, payload, err = readFrame(&out)
+		test.Assert(t, err == nil && kind == frameResponse, err)
+		res, err := UnmarshalResponse(payload)
+		test.Assert(t, err == nil && *res.Contents[0].Name == "pid.txt", err)
+	}
+	test.Assert(t, out.Len() == 0)
+}
+
+func TestServerPool(t *testing.T) {
+	t.Setenv("THRIFTGO_TEST_PLUGIN", "1")
+	pluginVersion = func(string) string { return "v0.4.5" }
+	defer func() { pluginVersion = readPluginThriftGoVersion }()
+
+	pool := NewServerPool()
+	p, err := pool.Lookup("pid=" + os.Args[0])
+	test.Assert(t, err == nil, err)
+	cp := p.(ContextPlugin)
+	run := func(ctx context.Context) string {
+		res := cp.ExecuteContext(ctx, newTestRequest())
+		if res.Error != nil {
+			return *res.Error
+		}
+		return res.Contents[0].Content
+	}
+
+	// the first execution is one-shot, the others are served by one process
+	ctx := context.Background()
+	first, second, third := run(ctx), run(ctx), run(ctx)
+	test.Assert(t, first != second && second == third, first, second, third)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	test.Assert(t, run(cancelled) != second)
+	test.Assert(t, run(ctx) == second)
+
+	test.Assert(t, pool.Close() == nil)
+	test.Assert(t, !pool.isServable(os.Args[0]))
+
+	// plugins built with old versions are always executed one-shot
+	pluginVersion = func(string) string { return "v0.4.1" }
+	pool = NewServerPool()
+	p, err = pool.Lookup("pid=" + os.Args[0])
+	test.Assert(t, err == nil, err)
+	cp = p.(ContextPlugin)
+	test.Assert(t, run(ctx) != run(ctx))
+	test.Assert(t, pool.Close() == nil)
+}
+
+func TestInDir(t *testing.T) {
+	t.Setenv("THRIFTGO_TEST_PLUGIN", "1")
+	pluginVersion = func(string) string { return "v0.4.5" }
+	defer func() { pluginVersion = readPluginThriftGoVersion }()
+
+	pool := NewServerPool()
+	defer pool.Close()
+	p, err := pool.Lookup("pid=" + os.Args[0])
+	test.Assert(t, err == nil, err)
+	dirs := []string{t.TempDir(), t.TempDir()}
+	run := func(dir string) (pid, wd string) {
+		req := newTestRequest()
+		req.PluginParameters = []string{"wd"}
+		res := InDir(p, dir).(ContextPlugin).ExecuteContext(context.Background(), req)
+		test.Assert(t, res.Error == nil, res.Error)
+		return res.Contents[0].Content, res.Contents[len(res.Contents)-1].Content
+	}
+
+	// one-shot at first, and then by the servers running in each directory
+	pids := make(map[string]string)
+	for i := 0; i < 3; i++ {
+		for _, dir := range dirs {
+			pid, wd := run(dir)
+			real, err := filepath.EvalSymlinks(dir)
+			test.Assert(t, err == nil, err)
+			test.Assert(t, wd == dir || wd == real, wd, dir)
+			if i > 0 {
+				test.Assert(t, pids[pid] == "" || pids[pid] == dir, pid, dir)
+				pids[pid] = dir
+			}
+		}
+	}
+	test.Assert(t, len(pids) == 2, pids)
+}
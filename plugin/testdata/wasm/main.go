@@ -0,0 +1,48 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command wasm is a plugin for the tests of WebAssembly plugins. It is built
+// with GOOS=wasip1 GOARCH=wasm.
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/cloudwego/thriftgo/plugin"
+)
+
+func main() {
+	err := plugin.Serve(func(req *plugin.Request) *plugin.Response {
+		content := strings.Join(req.PluginParameters, ",")
+		for _, p := range req.PluginParameters {
+			switch k, v, _ := strings.Cut(p, "="); k {
+			case "read":
+				data, err := os.ReadFile(v)
+				if err != nil {
+					return plugin.BuildErrorResponse(err.Error())
+				}
+				content = string(data)
+			case "loop":
+				for {
+				}
+			}
+		}
+		name := "wasm.txt"
+		return &plugin.Response{Contents: []*plugin.Generated{{Name: &name, Content: content}}}
+	})
+	if err != nil {
+		os.Exit(1)
+	}
+}
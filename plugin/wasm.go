@@ -0,0 +1,135 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// WasmSuffix is the suffix of WebAssembly plugins. Such a plugin is a WASI
+// module that reads a request from stdin and writes a response to stdout like
+// other plugins, and it is run by an embedded runtime instead of the system.
+const WasmSuffix = ".wasm"
+
+// Sandbox lists the host resources that WebAssembly plugins can access. The
+// zero value grants nothing: the plugins have no access to the file system,
+// the environment variables or the network.
+type Sandbox struct {
+	// Dirs are the host directories that the plugins can read. Each one is
+	// mounted at the same path in the plugins.
+	Dirs []string
+}
+
+// Grant returns the plugin with the access of the sandbox if it is a
+// WebAssembly plugin, or the plugin itself otherwise.
+func (sb *Sandbox) Grant(p Plugin) Plugin {
+	e, ok := p.(*external)
+	if !ok || !e.wasm || sb == nil {
+		return p
+	}
+	c := *e
+	c.sandbox = sb
+	return &c
+}
+
+// wasmCache keeps the modules compiled by all runtimes so that a plugin is
+// compiled only once in a process.
+var wasmCache = wazero.NewCompilationCache()
+
+// lookWasm searches for a WebAssembly plugin. Like exec.LookPath, a name
+// without separators is searched in the directories of PATH.
+func lookWasm(file string) (string, error) {
+	if strings.ContainsRune(file, filepath.Separator) || strings.Contains(file, "/") {
+		if _, err := os.Stat(file); err != nil {
+			return "", err
+		}
+		return file, nil
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		path := filepath.Join(dir, file)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%s: WebAssembly plugin not found in $PATH", file)
+}
+
+// runWasm runs a WebAssembly plugin. It stops the plugin when ctx is done.
+func (e *external) runWasm(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer) error {
+	code, err := os.ReadFile(e.path)
+	if err != nil {
+		return err
+	}
+	rc := wazero.NewRuntimeConfig().
+		WithCompilationCache(wasmCache).
+		WithCloseOnContextDone(true)
+	r := wazero.NewRuntimeWithConfig(ctx, rc)
+	defer r.Close(context.Background())
+
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		return err
+	}
+	mod, err := r.CompileModule(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	fsc := wazero.NewFSConfig()
+	if e.sandbox != nil {
+		for _, dir := range e.sandbox.Dirs {
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				return err
+			}
+			fsc = fsc.WithReadOnlyDirMount(abs, filepath.ToSlash(abs))
+		}
+	}
+	mc := wazero.NewModuleConfig().
+		WithName(e.name).
+		WithArgs(e.full).
+		WithStdin(stdin).
+		WithStdout(stdout).
+		WithStderr(stderr).
+		WithFSConfig(fsc).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithSysNanosleep().
+		WithRandSource(rand.Reader)
+	_, err = r.InstantiateModule(ctx, mod, mc)
+	var exit *sys.ExitError
+	if errors.As(err, &exit) {
+		if exit.ExitCode() == 0 {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return err
+}
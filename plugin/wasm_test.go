@@ -0,0 +1,80 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+// buildWasm builds the test plugin in testdata/wasm.
+func buildWasm(t *testing.T, path string) {
+	if testing.Short() {
+		t.Skip("building a WebAssembly plugin takes long")
+	}
+	cmd := exec.Command("go", "build", "-o", path, "./testdata/wasm")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Skipf("wasip1 is not supported by the toolchain: %v\n%s", err, out)
+	}
+}
+
+func TestWasm(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "thrift-gen-wasmtest.wasm")
+	buildWasm(t, path)
+	secret := filepath.Join(dir, "secret.txt")
+	test.Assert(t, os.WriteFile(secret, []byte("secret"), 0o644) == nil)
+
+	execute := func(p Plugin, params ...string) *Response {
+		req := newTestRequest()
+		req.PluginParameters = params
+		return p.Execute(req)
+	}
+
+	p, err := Lookup("wasm=" + path)
+	test.Assert(t, err == nil, err)
+	res := execute(p, "a=b")
+	test.Assert(t, res.GetError() == "", res.GetError())
+	test.Assert(t, len(res.Contents) == 1 && res.Contents[0].Content == "a=b", res.Contents)
+
+	// looked up in PATH by the name
+	t.Setenv("PATH", dir)
+	q, err := Lookup("wasmtest")
+	test.Assert(t, err == nil, err)
+	test.Assert(t, q.(*external).wasm && q.(*external).path == path)
+
+	// no access to the file system unless granted
+	res = execute(p, "read="+secret)
+	test.Assert(t, res.GetError() != "")
+	res = execute((&Sandbox{Dirs: []string{dir}}).Grant(p), "read="+secret)
+	test.Assert(t, res.GetError() == "", res.GetError())
+	test.Assert(t, res.Contents[0].Content == "secret", res.Contents)
+
+	// stopped by the time limit
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req := newTestRequest()
+	req.PluginParameters = []string{"loop"}
+	res = p.(ContextPlugin).ExecuteContext(ctx, req)
+	test.Assert(t, strings.Contains(res.GetError(), context.DeadlineExceeded.Error()), res.GetError())
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	targs "github.com/cloudwego/thriftgo/args"
+	"github.com/cloudwego/thriftgo/config"
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/cache"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+	"github.com/cloudwego/thriftgo/plugin"
+	"github.com/cloudwego/thriftgo/utils/dir_utils"
+	"github.com/cloudwego/thriftgo/version"
+)
+
+// openCache opens the cache directory of the arguments and returns the key of
+// the generation in it.
+func openCache(a *targs.Arguments) (*cache.Cache, string, error) {
+	dir := a.CacheDir
+	if dir == "" {
+		var err error
+		if dir, err = cache.DefaultDir(); err != nil {
+			return nil, "", err
+		}
+	}
+	c, err := cache.Open(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := cacheKey(a)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, key, nil
+}
+
+// cacheKey identifies a generation by the version of thriftgo, the working
+// directory, the arguments, the idl ref config and the plugin executables.
+// The content of IDLs is checked with the inputs recorded in the entry.
+func cacheKey(a *targs.Arguments) (string, error) {
+	wd, err := dir_utils.ToAbsolute(".")
+	if err != nil {
+		return "", err
+	}
+	b := *a
+	// flags that do not affect the generated codes
+	b.Verbose, b.Quiet, b.DiagnosticsFormat = false, false, ""
+	b.Cache, b.CacheDir = false, ""
+	parts := []string{version.ThriftgoVersion, wd, fmt.Sprintf("%+v", b)}
+
+	path, err := config.ConfigFile()
+	if err != nil {
+		return "", err
+	}
+	if path != "" {
+		hash, err := cache.HashFile(path)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, hash)
+	}
+
+	descs, err := a.UsedPlugins()
+	if err != nil {
+		return "", err
+	}
+	for _, d := range descs {
+		parts = append(parts, pluginStamp(d.Name))
+	}
+	return cache.Hash(parts...), nil
+}
+
+// addAbsent records the paths tried before each include of the AST was found,
+// so that creating a file that would win the search invalidates the entry.
+func addAbsent(e *cache.Entry, ast *parser.Thrift, includeDirs []string) {
+	for t := range ast.DepthFirstSearch() {
+		dir := filepath.Dir(t.Filename)
+		for _, inc := range t.Includes {
+			for _, p := range parser.SearchPaths(inc.Path, dir, includeDirs) {
+				if vfs.Exists(vfs.OS, p) {
+					break
+				}
+				e.AddAbsent(p)
+			}
+		}
+	}
+}
+
+// pluginStamp describes the executable of a plugin and its manifest by their
+// sizes and modification times, which is much cheaper than hashing them.
+func pluginStamp(name string) string {
+	path, err := plugin.LookPath(name)
+	if err != nil {
+		// built-in plugins of backends have no executables
+		return name
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return path
+	}
+	stamp := fmt.Sprintf("%s %d %d", path, fi.Size(), fi.ModTime().UnixNano())
+	if fi, err = os.Stat(path + plugin.ManifestSuffix); err == nil {
+		stamp += fmt.Sprintf(" %d %d", fi.Size(), fi.ModTime().UnixNano())
+	}
+	return stamp
+}
@@ -0,0 +1,286 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudwego/thriftgo/config"
+	"github.com/cloudwego/thriftgo/generator"
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+	"github.com/cloudwego/thriftgo/plugin"
+	"github.com/cloudwego/thriftgo/semantic"
+	"github.com/cloudwego/thriftgo/version"
+)
+
+// Target describes the codes to generate for a language.
+type Target struct {
+	// Language is the name of the backend, like "go".
+	Language string
+	// Options are the options of the backend, like the ones in -g go:k=v.
+	Options []plugin.Option
+	// TypedOptions are the typed options of the backend, like
+	// golang.Options. They are validated by NewCompiler and packed ahead
+	// of Options.
+	TypedOptions backend.Options
+	// OutputPath is the output directory, which defaults to "gen-" + Language.
+	OutputPath string
+	// Plugins are the external plugins to run, like the ones given by -p.
+	Plugins []*plugin.Desc
+}
+
+// Options configures a Compiler.
+type Options struct {
+	// WorkDir is the directory to resolve relative paths against when
+	// reading from or writing to the disk, and where external plugins run.
+	// The names of IDLs in the ASTs and diagnostics are relative to it. It
+	// defaults to the working directory of the process, which is never
+	// changed or consulted by a compilation otherwise.
+	WorkDir string
+	// IDL is the main IDL file.
+	IDL string
+	// Includes are the directories to search for included IDLs.
+	Includes []string
+	// Recursive generates codes for the included IDLs as well.
+	Recursive bool
+	// Targets are the languages to generate codes for.
+	Targets []Target
+
+	// Backends creates the backends for each compilation, since backends
+	// keep the state of a generation. It defaults to DefaultBackends.
+	Backends func() []backend.Backend
+	// SDKPlugins are run in the process for each target.
+	SDKPlugins []plugin.SDKPlugin
+	// PluginTimeLimit limits the execution time of each external plugin.
+	PluginTimeLimit time.Duration
+	// PluginServers keeps the external plugins that support the persistent
+	// mode running between compilations. It is owned by the caller.
+	PluginServers *plugin.ServerPool
+	// PluginSandbox grants WebAssembly plugins the access to host resources.
+	PluginSandbox *plugin.Sandbox
+
+	// Config is the idl ref config. If it is nil, the config in WorkDir is
+	// loaded from FS.
+	Config *config.Config
+	// Log receives the logs. The missing functions discard the logs.
+	Log backend.LogFunc
+
+	// FS is where IDLs are read from. It defaults to the disk, and vfs.OS is
+	// replaced with vfs.Dir(WorkDir) as well. Relative paths in other file
+	// systems are resolved from their roots.
+	FS vfs.FS
+	// Output receives the generated files. It defaults to the disk like FS.
+	Output vfs.Sink
+}
+
+// Result is the outcome of a compilation.
+type Result struct {
+	// Files are the paths of the generated files, including the unchanged
+	// ones.
+	Files []string
+	// Diagnostics are the warnings and errors reported.
+	Diagnostics parser.Diagnostics
+}
+
+// Compiler generates codes with explicit options. Unlike InvokeThriftgo, it
+// keeps no global state, so that compilations may run concurrently, with one
+// or many compilers.
+type Compiler struct {
+	opts Options
+}
+
+// NewCompiler validates the options and creates a Compiler.
+func NewCompiler(opts Options) (*Compiler, error) {
+	if opts.IDL == "" {
+		return nil, errors.New("no IDL specified")
+	}
+	if len(opts.Targets) == 0 {
+		return nil, errors.New("no targets specified")
+	}
+	for i, t := range opts.Targets {
+		if t.Language == "" {
+			return nil, fmt.Errorf("targets[%d]: language is required", i)
+		}
+		if t.TypedOptions != nil {
+			if err := t.TypedOptions.Validate(); err != nil {
+				return nil, fmt.Errorf("targets[%d]: %w", i, err)
+			}
+		}
+	}
+	if opts.Backends == nil {
+		opts.Backends = DefaultBackends
+	}
+	if opts.WorkDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		opts.WorkDir = wd
+	}
+	wd, err := filepath.Abs(opts.WorkDir)
+	if err != nil {
+		return nil, err
+	}
+	opts.WorkDir = wd
+	// the disk is accessed from the working directory without touching the
+	// one of the process
+	if opts.FS == nil || opts.FS == vfs.FS(vfs.OS) {
+		opts.FS = vfs.Dir(wd)
+	}
+	if opts.Output == nil || opts.Output == vfs.Sink(vfs.OS) {
+		opts.Output = vfs.Dir(wd)
+	}
+	if opts.Config == nil {
+		dir := "."
+		if _, ok := opts.FS.(vfs.Dir); ok {
+			dir = wd
+		}
+		c, err := config.Load(opts.FS, dir)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			c = &config.Config{} // no refs instead of the global config
+		}
+		opts.Config = c
+	}
+	if _, ok := opts.FS.(vfs.Dir); ok {
+		opts.Config = opts.Config.WithDir(wd)
+	}
+	opts.Log = fillLogFunc(opts.Log)
+	return &Compiler{opts: opts}, nil
+}
+
+func fillLogFunc(log backend.LogFunc) backend.LogFunc {
+	dummy := backend.DummyLogFunc()
+	if log.Info == nil {
+		log.Info = dummy.Info
+	}
+	if log.Infof == nil {
+		log.Infof = dummy.Infof
+	}
+	if log.Warn == nil {
+		log.Warn = dummy.Warn
+	}
+	if log.Warnf == nil {
+		log.Warnf = dummy.Warnf
+	}
+	if log.MultiWarn == nil {
+		log.MultiWarn = dummy.MultiWarn
+	}
+	return log
+}
+
+// output resolves an output path against the working directory if the files
+// are written to the disk.
+func (c *Compiler) output(path string) string {
+	if d, ok := c.opts.Output.(vfs.Dir); ok && !filepath.IsAbs(path) {
+		return filepath.Join(string(d), path)
+	}
+	return path
+}
+
+// Compile parses and checks the IDL, then generates and writes the codes of
+// every target. The result is returned even if it fails. It stops when ctx
+// is done, and running plugins are killed.
+func (c *Compiler) Compile(ctx context.Context) (*Result, error) {
+	res := new(Result)
+	opts, log := &c.opts, c.opts.Log
+	if err := ctx.Err(); err != nil {
+		return res, err
+	}
+
+	ast, err := parser.ParseFileFS(opts.FS, opts.IDL, opts.Includes, make(map[string]*parser.Thrift))
+	res.Diagnostics = append(res.Diagnostics, parser.DiagnosticsOf(err, "parser")...)
+	if err != nil {
+		return res, err
+	}
+	if path := parser.CircleDetect(ast); len(path) > 0 {
+		return res, fmt.Errorf("found include circle:\n\t%s", path)
+	}
+
+	checker := semantic.NewDiagnosticChecker(semantic.Options{FixWarnings: true})
+	ds := checker.Diagnose(ast)
+	res.Diagnostics = append(res.Diagnostics, ds...)
+	log.MultiWarn(ds.Filter(parser.Severity_Warning).Strings())
+	if err = ds.Err(); err != nil {
+		return res, err
+	}
+	if err = semantic.ResolveSymbols(ast); err != nil {
+		return res, err
+	}
+
+	g, err := newGenerator(opts.Backends(), opts.Config)
+	if err != nil {
+		return res, err
+	}
+	for _, t := range opts.Targets {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+		out := t.OutputPath
+		if out == "" {
+			out = "gen-" + t.Language
+		}
+		req := &plugin.Request{
+			Version:    version.ThriftgoVersion,
+			Language:   t.Language,
+			OutputPath: c.output(out),
+			Recursive:  opts.Recursive,
+			AST:        ast,
+		}
+		arg := &generator.Arguments{
+			Out: &generator.LangSpec{
+				Language:     t.Language,
+				Options:      t.Options,
+				TypedOptions: t.TypedOptions,
+				UsedPlugins:  t.Plugins,
+				SDKPlugins:   opts.SDKPlugins,
+			},
+			Req:             req,
+			Log:             log,
+			Context:         ctx,
+			PluginTimeLimit: opts.PluginTimeLimit,
+			PluginServers:   opts.PluginServers,
+			PluginSandbox:   opts.PluginSandbox,
+			WorkDir:         opts.WorkDir,
+		}
+		gr := g.Generate(arg)
+		res.Diagnostics = append(res.Diagnostics, gr.Diagnostics...)
+		if gr.GetError() != "" {
+			if err := ctx.Err(); err != nil {
+				return res, err
+			}
+			if err = parser.Diagnostics(gr.Diagnostics).Err(); err != nil {
+				return res, err
+			}
+			return res, errors.New(gr.GetError())
+		}
+		if _, err = g.PersistWithOptions(gr, &generator.PersistOptions{Output: opts.Output}); err != nil {
+			return res, err
+		}
+		for _, f := range gr.Contents {
+			res.Files = append(res.Files, f.GetName())
+		}
+	}
+	return res, nil
+}
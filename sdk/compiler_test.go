@@ -0,0 +1,184 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/thriftgo/generator/golang"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+	"github.com/cloudwego/thriftgo/plugin"
+)
+
+func TestCompiler(t *testing.T) {
+	const n = 8
+	dirs := make([]string, n)
+	for i := range dirs {
+		dirs[i] = t.TempDir()
+		idl := fmt.Sprintf("namespace go p%d\ninclude \"b.thrift\"\nstruct A { 1: b.B b }\n", i)
+		test.Assert(t, os.WriteFile(filepath.Join(dirs[i], "a.thrift"), []byte(idl), 0o644) == nil)
+		test.Assert(t, os.MkdirAll(filepath.Join(dirs[i], "inc"), 0o755) == nil)
+		idl = "namespace go b\nstruct B {}\n"
+		test.Assert(t, os.WriteFile(filepath.Join(dirs[i], "inc", "b.thrift"), []byte(idl), 0o644) == nil)
+	}
+	// IDLs referenced by the idl ref config are generated as references
+	ref := "ref:\n  a.thrift: example.com/ref\n"
+	test.Assert(t, os.WriteFile(filepath.Join(dirs[0], "idl-ref.yaml"), []byte(ref), 0o644) == nil)
+
+	var wg sync.WaitGroup
+	results := make([]*Result, n)
+	errs := make([]error, n)
+	typed, err := golang.NewOptionsBuilder().Enable("code_ref").Build()
+	test.Assert(t, err == nil, err)
+	for i := range dirs {
+		c, err := NewCompiler(Options{
+			WorkDir:   dirs[i],
+			IDL:       "a.thrift",
+			Includes:  []string{"inc"},
+			Recursive: true,
+			Targets: []Target{{
+				Language:     "go",
+				Options:      []plugin.Option{{Name: "package_prefix", Desc: "x"}},
+				TypedOptions: typed,
+				OutputPath:   "out",
+			}},
+		})
+		test.Assert(t, err == nil, err)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.Compile(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	for i, dir := range dirs {
+		test.Assert(t, errs[i] == nil, errs[i])
+		want := []string{filepath.Join(dir, "out", "b", "b.go"), filepath.Join(dir, "out", fmt.Sprintf("p%d", i), "a.go")}
+		if i == 0 {
+			want[1] = filepath.Join(dir, "out", "p0", "a-ref.go")
+		}
+		test.DeepEqual(t, results[i].Files, want)
+		for _, f := range want {
+			_, err := os.Stat(f)
+			test.Assert(t, err == nil, err)
+		}
+	}
+}
+
+func TestCompilerWithSDK(t *testing.T) {
+	write := func(dir string, files map[string]string) {
+		for name, content := range files {
+			test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+		}
+	}
+	// the working directory and the idl ref config set by RunThriftgoAsSDK
+	// must not leak into the compilers
+	sdkDir := t.TempDir()
+	write(sdkDir, map[string]string{
+		"a.thrift":     "namespace go s\nstruct S {}\n",
+		"idl-ref.yaml": fmt.Sprintf("ref:\n  %q: example.com/ref\n", filepath.Join(sdkDir, "a.thrift")),
+	})
+	dirs := make([]string, 4)
+	for i := range dirs {
+		dirs[i] = t.TempDir()
+		write(dirs[i], map[string]string{"a.thrift": "namespace go p\nunion U { 1: required i32 a }\n"})
+	}
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	var sdkErr error
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if sdkErr = RunThriftgoAsSDK(sdkDir, nil, "-g", "go:code_ref", "-o", filepath.Join(sdkDir, "out"), filepath.Join(sdkDir, "a.thrift")); sdkErr != nil {
+				return
+			}
+		}
+	}()
+
+	for round := 0; round < 5; round++ {
+		var wg sync.WaitGroup
+		errs := make([]error, len(dirs))
+		results := make([]*Result, len(dirs))
+		for i, dir := range dirs {
+			c, err := NewCompiler(Options{
+				WorkDir: dir,
+				IDL:     "a.thrift",
+				Targets: []Target{{Language: "go", OutputPath: "out"}},
+			})
+			test.Assert(t, err == nil, err)
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = c.Compile(context.Background())
+			}(i)
+		}
+		wg.Wait()
+		for i, dir := range dirs {
+			test.Assert(t, errs[i] == nil, errs[i])
+			test.DeepEqual(t, results[i].Files, []string{filepath.Join(dir, "out", "p", "a.go")})
+			// the names of IDLs are relative to the working directory
+			ds := results[i].Diagnostics
+			test.Assert(t, len(ds) == 1 && ds[0].Filename == "a.thrift", ds)
+		}
+	}
+	close(stop)
+	<-done
+	test.Assert(t, sdkErr == nil, sdkErr)
+	_, err := os.Stat(filepath.Join(sdkDir, "out", "s", "a-ref.go"))
+	test.Assert(t, err == nil, err)
+}
+
+func TestCompilerFS(t *testing.T) {
+	out := vfs.NewMapSink()
+	c, err := NewCompiler(Options{
+		IDL: "a.thrift",
+		FS: vfs.FromFS(fstest.MapFS{
+			"a.thrift": {Data: []byte("namespace go a\nstruct A { 1: i32 a = }\n")},
+		}),
+		Output:  out,
+		Targets: []Target{{Language: "go"}},
+	})
+	test.Assert(t, err == nil, err)
+	res, err := c.Compile(context.Background())
+	test.Assert(t, err != nil && len(res.Diagnostics) == 1, err, res.Diagnostics)
+	test.Assert(t, len(out.Names()) == 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.Compile(ctx)
+	test.Assert(t, errors.Is(err, context.Canceled), err)
+
+	_, err = NewCompiler(Options{IDL: "a.thrift"})
+	test.Assert(t, err != nil && err.Error() == "no targets specified", err)
+	_, err = NewCompiler(Options{IDL: "a.thrift", Targets: []Target{{}}})
+	test.Assert(t, err != nil && err.Error() == "targets[0]: language is required", err)
+	typed := &golang.Options{Features: map[string]bool{"with_field_mask": true}}
+	_, err = NewCompiler(Options{IDL: "a.thrift", Targets: []Target{{Language: "go", TypedOptions: typed}}})
+	test.Assert(t, err != nil && err.Error() == "targets[0]: with_field_mask requires with_reflection", err)
+}
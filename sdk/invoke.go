@@ -15,68 +15,224 @@
 package sdk
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	targs "github.com/cloudwego/thriftgo/args"
+	"github.com/cloudwego/thriftgo/config"
 	"github.com/cloudwego/thriftgo/generator"
+	"github.com/cloudwego/thriftgo/generator/backend"
 	"github.com/cloudwego/thriftgo/generator/fastgo"
 	"github.com/cloudwego/thriftgo/generator/golang"
 	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/cache"
+	"github.com/cloudwego/thriftgo/pkg/diagnostic"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
 	"github.com/cloudwego/thriftgo/plugin"
 	"github.com/cloudwego/thriftgo/semantic"
 	"github.com/cloudwego/thriftgo/version"
 )
 
-func init() {
-	_ = g.RegisterBackend(new(golang.GoBackend))
-	_ = g.RegisterBackend(new(fastgo.FastGoBackend))
+// DefaultBackends creates the backends built in thriftgo.
+func DefaultBackends() []backend.Backend {
+	return []backend.Backend{new(golang.GoBackend), new(fastgo.FastGoBackend)}
 }
 
-var (
-	g generator.Generator
-)
+// newGenerator creates a generator with the backends. Backends are stateful,
+// so each generation needs its own. The idl ref config is set to the backends
+// that accept one.
+func newGenerator(backends []backend.Backend, refConfig *config.Config) (*generator.Generator, error) {
+	g := new(generator.Generator)
+	for _, b := range backends {
+		if r, ok := b.(interface{ SetRefConfig(*config.Config) }); ok {
+			r.SetRefConfig(refConfig)
+		}
+		if err := g.RegisterBackend(b); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
 
 // InvokeThriftgo is the core logic of thriftgo, from parse idl to generate code.
-func InvokeThriftgo(SDKPlugins []plugin.SDKPlugin, args ...string) (err error) {
+func InvokeThriftgo(SDKPlugins []plugin.SDKPlugin, args ...string) error {
+	a, err := parseArguments(args)
+	if a == nil {
+		return err
+	}
+
+	if a.Watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return Watch(ctx, []*targs.Arguments{a}, SDKPlugins)
+	}
+
+	s := NewSession()
+	defer s.Close()
+	return s.Invoke(a, SDKPlugins)
+}
 
+// parseArguments parses the command line. It returns nil arguments if there
+// is nothing to generate, like when help or the version is asked.
+func parseArguments(args []string) (*targs.Arguments, error) {
 	var a targs.Arguments
 
-	err = a.Parse(args)
-	if err != nil {
+	if err := a.Parse(args); err != nil {
 		if err.Error() == "flag: help requested" {
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
 
 	if a.AskVersion {
 		println("thriftgo", version.ThriftgoVersion)
+		return nil, nil
+	}
+	return &a, nil
+}
+
+// Session shares parsed IDLs between invocations, so that the files included
+// by several IDLs are parsed and checked only once. The invocations in a
+// session are expected to use the same include paths and checking options.
+type Session struct {
+	// FS is where IDLs are read from. It defaults to vfs.OS.
+	FS vfs.FS
+	// Output receives the generated files. It defaults to vfs.OS. The cache
+	// and the manifest of output directories are only used when both FS and
+	// Output are the disk.
+	Output vfs.Sink
+	// Servers keeps the plugins running between invocations that enable
+	// --plugin-server. It is created by the first of them if nil, and
+	// closed by Close.
+	Servers *plugin.ServerPool
+	// Config is the idl ref config. The global one is used if it is nil.
+	Config *config.Config
+	// Stdout receives the outputs of --dry-run and --diff and the
+	// diagnostics in a format other than text. It defaults to os.Stdout.
+	Stdout io.Writer
+
+	files   map[string]*parser.Thrift
+	checked map[string]bool
+}
+
+// NewSession creates an empty session.
+func NewSession() *Session {
+	return &Session{
+		files:   make(map[string]*parser.Thrift),
+		checked: make(map[string]bool),
+	}
+}
+
+// Close stops the plugins kept running by the session.
+func (s *Session) Close() error {
+	if s.Servers == nil {
 		return nil
 	}
+	return s.Servers.Close()
+}
+
+// Invoke generates codes with the parsed arguments. Diagnostics of the files
+// that are checked by previous invocations in the session are not reported
+// again.
+func (s *Session) Invoke(a *targs.Arguments, SDKPlugins []plugin.SDKPlugin) error {
+	_, err := s.invoke(a, SDKPlugins)
+	return err
+}
 
+// invoke is like Invoke and returns the IDL files that are read, which are
+// available even if the generation fails after parsing.
+func (s *Session) invoke(a *targs.Arguments, SDKPlugins []plugin.SDKPlugin) (files []string, err error) {
 	// todo check log
 	log := a.MakeLogFunc()
 
-	ast, err := parser.ParseFile(a.IDL, a.Includes, true)
+	var diags parser.Diagnostics
+	if a.DiagnosticsFormat != diagnostic.FormatText {
+		defer func() {
+			err = reportDiagnostics(s.Stdout, a.DiagnosticsFormat, diags, err)
+		}()
+	}
+
+	var c *cache.Cache
+	var key string
+	var prev, next *cache.Entry
+	if a.Cache && s.onDisk() {
+		if c, key, err = openCache(a); err != nil {
+			return files, err
+		}
+		prev = c.Load(key)
+		if len(SDKPlugins) == 0 && prev.UpToDate() {
+			log.Info("Up to date:", a.IDL)
+			for f := range prev.Inputs {
+				files = append(files, f)
+			}
+			return files, nil
+		}
+		next = cache.NewEntry()
+	}
+
+	fsys := s.FS
+	if fsys == nil {
+		fsys = vfs.OS
+	}
+	ast, err := parser.ParseFileFS(fsys, a.IDL, a.Includes, s.files)
+	if ast != nil {
+		for t := range ast.DepthFirstSearch() {
+			files = append(files, t.Filename)
+		}
+	}
 	if err != nil {
-		return err
+		return files, err
 	}
 
 	if path := parser.CircleDetect(ast); len(path) > 0 {
-		return fmt.Errorf("found include circle:\n\t%s", path)
+		return files, fmt.Errorf("found include circle:\n\t%s", path)
 	}
 
-	checker := semantic.NewChecker(semantic.Options{FixWarnings: true})
-	// todo no warnings when sdk?
-	warns, err := checker.CheckAll(ast)
-	log.MultiWarn(warns)
-	if err != nil {
-		return err
+	if !s.checked[ast.Filename] {
+		checker := semantic.NewDiagnosticChecker(semantic.Options{FixWarnings: true})
+		// todo no warnings when sdk?
+		ds := s.unchecked(checker.Diagnose(ast))
+		diags = append(diags, ds...)
+		log.MultiWarn(ds.Filter(parser.Severity_Warning).Strings())
+		if err = ds.Err(); err != nil {
+			return files, err
+		}
+
+		if a.RenameKeywords {
+			renamed, err := parser.RenameKeywords(ast, a.KeywordLangs, "_")
+			if err != nil {
+				return files, err
+			}
+			for _, msg := range renamed {
+				log.Info(msg)
+			}
+		}
+		if a.CheckKeyword {
+			severity := parser.Severity_Warning
+			if a.KeywordSeverity == "error" {
+				severity = parser.Severity_Error
+			}
+			ds := s.unchecked(parser.DiagnoseKeywords(ast, a.KeywordLangs, severity))
+			diags = append(diags, ds...)
+			log.MultiWarn(ds.Filter(parser.Severity_Warning).Strings())
+			if err = ds.Err(); err != nil {
+				return files, err
+			}
+		}
+		for t := range ast.DepthFirstSearch() {
+			s.checked[t.Filename] = true
+		}
 	}
 
 	err = semantic.ResolveSymbols(ast)
 	if err != nil {
-		return err
+		return files, err
 	}
 
 	req := &plugin.Request{
@@ -86,34 +242,154 @@ func InvokeThriftgo(SDKPlugins []plugin.SDKPlugin, args ...string) (err error) {
 		AST:        ast,
 	}
 
-	plugin.MaxExecutionTime = a.PluginTimeLimit
+	refConfig := s.Config
+	if refConfig == nil {
+		if refConfig, err = config.Global(); err != nil {
+			return files, err
+		}
+	}
+	g, err := newGenerator(DefaultBackends(), refConfig)
+	if err != nil {
+		return files, err
+	}
+
 	plugins, err := a.UsedPlugins()
 	if err != nil {
-		return err
+		return files, err
 	}
 
 	langs, err := a.Targets()
 	if err != nil {
-		return err
+		return files, err
 	}
 
 	if len(langs) == 0 {
-		return fmt.Errorf("No output language(s) specified")
+		return files, fmt.Errorf("No output language(s) specified")
 	}
 
+	var stale []string
 	for _, out := range langs {
 		out.UsedPlugins = plugins
 		out.SDKPlugins = SDKPlugins
 		req.Language = out.Language
 		req.OutputPath = a.Output(out.Language)
 
-		arg := &generator.Arguments{Out: out, Req: req, Log: log}
+		arg := &generator.Arguments{
+			Out:             out,
+			Req:             req,
+			Log:             log,
+			Context:         context.Background(),
+			PluginTimeLimit: a.PluginTimeLimit,
+			PluginServers:   s.servers(a),
+			PluginSandbox:   sandbox(a),
+		}
 		res := g.Generate(arg)
+		diags = append(diags, res.Diagnostics...)
+		if res.GetError() != "" {
+			if err = parser.Diagnostics(res.Diagnostics).Err(); err != nil {
+				return files, err
+			}
+			return files, errors.New(res.GetError())
+		}
 
-		err = g.Persist(res)
+		opts := &generator.PersistOptions{
+			Prev:    prev,
+			Next:    next,
+			DryRun:  a.DryRun,
+			Diff:    a.Diff,
+			NoWrite: a.Check,
+			Output:  s.Output,
+			Stdout:  s.Stdout,
+		}
+		ss, err := g.PersistWithOptions(res, opts)
 		if err != nil {
-			return err
+			return files, err
+		}
+		stale = append(stale, ss...)
+
+		if !a.DryRun && !a.Diff && !a.Check && s.onDisk() {
+			paths, err := generator.FilePaths(res)
+			if err != nil {
+				return files, err
+			}
+			if err = updateManifest(a, out.Language, paths, log); err != nil {
+				return files, err
+			}
+		}
+	}
+
+	if a.Check && len(stale) > 0 {
+		return files, fmt.Errorf("%d generated files are stale:\n\t%s", len(stale), strings.Join(stale, "\n\t"))
+	}
+	if c == nil || len(SDKPlugins) > 0 || a.DryRun || a.Diff || a.Check {
+		return files, nil
+	}
+	for _, f := range files {
+		if err = next.AddInput(f); err != nil {
+			return files, err
+		}
+	}
+	addAbsent(next, ast, a.Includes)
+	return files, c.Store(key, next)
+}
+
+// servers returns the plugin servers if the arguments enable them.
+func (s *Session) servers(a *targs.Arguments) *plugin.ServerPool {
+	if !a.PluginServer {
+		return nil
+	}
+	if s.Servers == nil {
+		s.Servers = plugin.NewServerPool()
+	}
+	return s.Servers
+}
+
+// sandbox returns the access granted to WebAssembly plugins by the arguments.
+func sandbox(a *targs.Arguments) *plugin.Sandbox {
+	if len(a.PluginDirs) == 0 {
+		return nil
+	}
+	return &plugin.Sandbox{Dirs: a.PluginDirs}
+}
+
+// onDisk tells whether the session reads and writes the disk.
+func (s *Session) onDisk() bool {
+	return (s.FS == nil || s.FS == vfs.OS) && (s.Output == nil || s.Output == vfs.OS)
+}
+
+// unchecked drops the diagnostics of files checked in the session.
+func (s *Session) unchecked(ds parser.Diagnostics) (res parser.Diagnostics) {
+	for _, d := range ds {
+		if !s.checked[d.Filename] {
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+// reportDiagnostics writes all diagnostics to w, or stdout if it is nil, in
+// the given format. The error that terminates the invocation is also reported
+// if it is not one of the collected diagnostics.
+func reportDiagnostics(w io.Writer, format string, diags parser.Diagnostics, err error) error {
+	for _, d := range parser.DiagnosticsOf(err, "thriftgo") {
+		if !containsDiagnostic(diags, d) {
+			diags = append(diags, d)
+		}
+	}
+	if w == nil {
+		w = os.Stdout
+	}
+	if e := diagnostic.Write(w, format, diags); e != nil && err == nil {
+		return e
+	}
+	return err
+}
+
+func containsDiagnostic(diags parser.Diagnostics, d *parser.Diagnostic) bool {
+	for _, x := range diags {
+		if x == d {
+			return true
 		}
 	}
-	return nil
+	return false
 }
@@ -0,0 +1,115 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/pkg/manifest"
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	idl, out := filepath.Join(dir, "a.thrift"), filepath.Join(dir, "out")
+	generated := filepath.Join(out, "a", "a.go")
+	var stdout bytes.Buffer
+	invoke := func(flags ...string) error {
+		args := append([]string{"thriftgo", "-q", "-o", out, "-g", "go:package_prefix=x"}, flags...)
+		a, err := parseArguments(append(args, idl))
+		test.Assert(t, err == nil, err)
+		s := NewSession()
+		s.Stdout = &stdout
+		return s.Invoke(a, nil)
+	}
+	test.Assert(t, os.WriteFile(idl, []byte("namespace go a\nstruct A {}\n"), 0o644) == nil)
+
+	test.Assert(t, invoke("--dry-run", "--diff") == nil)
+	_, err := os.Stat(generated)
+	test.Assert(t, os.IsNotExist(err), err)
+	lines := strings.Split(stdout.String(), "\n")
+	test.Assert(t, len(lines) > 3 && lines[0] == generated, stdout.String())
+	test.Assert(t, lines[1] == "--- "+generated && lines[2] == "+++ "+generated, stdout.String())
+	test.Assert(t, strings.HasPrefix(lines[3], "@@ -0,0 +1,"), stdout.String())
+	test.Assert(t, strings.Contains(stdout.String(), "\n+type A struct {\n"), stdout.String())
+
+	err = invoke("--check")
+	test.Assert(t, err != nil && strings.Contains(err.Error(), "1 generated files are stale"), err)
+
+	test.Assert(t, invoke() == nil)
+	test.Assert(t, invoke("--check") == nil)
+
+	test.Assert(t, os.WriteFile(idl, []byte("namespace go a\nstruct A {}\nstruct B {}\n"), 0o644) == nil)
+	err = invoke("--check")
+	test.Assert(t, err != nil && strings.Contains(err.Error(), generated), err)
+	content, _ := os.ReadFile(generated)
+	test.Assert(t, !strings.Contains(string(content), "type B struct"))
+
+	stdout.Reset()
+	test.Assert(t, invoke("--diff") == nil)
+	diff := stdout.String()
+	test.Assert(t, strings.HasPrefix(diff, "--- "+generated+"\n+++ "+generated+"\n@@ -"), diff)
+	test.Assert(t, strings.Contains(diff, "\n+type B struct {\n") && !strings.Contains(diff, "\n+type A struct {\n"), diff)
+	content, _ = os.ReadFile(generated)
+	test.Assert(t, !strings.Contains(string(content), "type B struct"))
+}
+
+func TestClean(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	write := func(name, content string) {
+		test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(out, name))
+		return err == nil
+	}
+	invoke := func(idl string, flags ...string) {
+		args := append([]string{"thriftgo", "-q", "-r", "-o", out, "-g", "go:package_prefix=x"}, flags...)
+		test.Assert(t, InvokeThriftgo(nil, append(args, filepath.Join(dir, idl))...) == nil)
+	}
+	write("a.thrift", "namespace go a\ninclude \"b.thrift\"\ninclude \"c.thrift\"\n")
+	write("b.thrift", "namespace go b\nstruct B {}\n")
+	write("c.thrift", "namespace go c\nstruct C {}\n")
+	write("d.thrift", "namespace go d\nstruct D {}\n")
+	invoke("a.thrift")
+	invoke("d.thrift")
+	test.Assert(t, exists("b/b.go") && exists("c/c.go") && exists("d/d.go") && exists(manifest.FileName))
+
+	// files no longer generated are kept without --clean
+	write("a.thrift", "namespace go a\ninclude \"c.thrift\"\n")
+	invoke("a.thrift")
+	test.Assert(t, exists("b/b.go"))
+
+	// files without the header are never removed
+	test.Assert(t, os.WriteFile(filepath.Join(out, "c/c.go"), []byte("package c\n"), 0o644) == nil)
+	write("a.thrift", "namespace go a\n")
+	invoke("a.thrift", "--clean")
+	test.Assert(t, !exists("b/b.go") && !exists("b"), "b should be removed")
+	test.Assert(t, exists("c/c.go") && exists("a/a.go") && exists("d/d.go"))
+
+	// files of IDLs removed are cleaned by any invocation
+	test.Assert(t, os.Remove(filepath.Join(dir, "d.thrift")) == nil)
+	invoke("a.thrift", "--clean")
+	test.Assert(t, !exists("d/d.go") && exists("a/a.go"))
+
+	m, err := manifest.Load(out)
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, m.Entries, map[string][]string{"go:../a.thrift": {"a/a.go"}})
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"errors"
+
+	targs "github.com/cloudwego/thriftgo/args"
+	"github.com/cloudwego/thriftgo/generator/backend"
+	"github.com/cloudwego/thriftgo/pkg/manifest"
+)
+
+// updateManifest records the files generated for the language in the
+// manifest of its output path. With --clean, the files recorded before but no
+// longer generated are removed, as well as the files of IDLs that no longer
+// exist.
+func updateManifest(a *targs.Arguments, lang string, paths []string, log backend.LogFunc) error {
+	m, err := manifest.Load(manifest.Root(a.Output(lang)))
+	if err != nil {
+		return err
+	}
+	stale := m.Update(m.Key(lang, a.IDL), paths, !a.Clean)
+	if a.Clean {
+		stale = append(stale, m.Prune()...)
+		for _, path := range stale {
+			err := m.Remove(path)
+			if errors.Is(err, manifest.ErrNotGenerated) {
+				log.Warn(err.Error() + ", not removed")
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			log.Info("Remove", path)
+		}
+	}
+	return m.Save()
+}
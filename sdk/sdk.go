@@ -15,9 +15,13 @@
 package sdk
 
 import (
+	"errors"
+	"os"
+
 	"github.com/cloudwego/thriftgo/utils/dir_utils"
 
 	"github.com/cloudwego/thriftgo/config"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
 
 	"github.com/cloudwego/thriftgo/plugin"
 )
@@ -35,3 +39,39 @@ func RunThriftgoAsSDK(wd string, SDKPlugins []plugin.SDKPlugin, args ...string)
 
 	return InvokeThriftgo(SDKPlugins, append([]string{"thriftgo"}, args...)...)
 }
+
+// RunThriftgoFS is like RunThriftgoAsSDK, but reads the IDLs and the idl ref
+// config from fsys and writes the generated files to out instead of the disk.
+// Relative paths in the arguments are resolved from the root of fsys. Unlike
+// RunThriftgoAsSDK, the global working directory and idl ref config are left
+// untouched.
+func RunThriftgoFS(fsys vfs.FS, out vfs.Sink, SDKPlugins []plugin.SDKPlugin, args ...string) error {
+	c, err := config.Load(fsys, ".")
+	if err != nil {
+		return err
+	}
+	if c == nil {
+		c = &config.Config{} // no refs instead of the global config
+	}
+	if out == vfs.Sink(vfs.OS) {
+		// relative names are not joined with the global working directory
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		out = vfs.Dir(wd)
+	}
+
+	a, err := parseArguments(append([]string{"thriftgo"}, args...))
+	if a == nil {
+		return err
+	}
+	if a.Watch {
+		return errors.New("--watch is not supported on a virtual file system")
+	}
+
+	s := NewSession()
+	defer s.Close()
+	s.FS, s.Output, s.Config = fsys, out, c
+	return s.Invoke(a, SDKPlugins)
+}
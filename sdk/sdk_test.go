@@ -0,0 +1,63 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/thriftgo/config"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/pkg/vfs"
+	"github.com/cloudwego/thriftgo/utils/dir_utils"
+)
+
+func TestRunThriftgoFS(t *testing.T) {
+	fsys := vfs.FromFS(fstest.MapFS{
+		"idl/a.thrift": {Data: []byte("namespace go a\ninclude \"b.thrift\"\nstruct A { 1: b.B b }\n")},
+		"idl/b.thrift": {Data: []byte("namespace go b\nstruct B {}\n")},
+	})
+	out := vfs.NewMapSink()
+	err := RunThriftgoFS(fsys, out, nil, "-q", "-r", "-o", "out", "-g", "go:package_prefix=x", "idl/a.thrift")
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, out.Names(), []string{filepath.FromSlash("out/a/a.go"), filepath.FromSlash("out/b/b.go")})
+	content, _ := out.ReadFile("out/a/a.go")
+	test.Assert(t, strings.Contains(string(content), "type A struct"))
+	_, err = os.Stat("out")
+	test.Assert(t, os.IsNotExist(err), err)
+
+	// the files on disk are not visible
+	err = RunThriftgoFS(fsys, out, nil, "-q", "-o", "out", "sdk_test.go")
+	test.Assert(t, err != nil && os.IsNotExist(err), err)
+
+	// the idl ref config is read from fsys, and the global state is untouched
+	wd := t.TempDir()
+	dir_utils.SetGlobalwd(wd)
+	defer dir_utils.SetGlobalwd("")
+	fsys = vfs.FromFS(fstest.MapFS{
+		"idl/a.thrift": {Data: []byte("namespace go a\ninclude \"b.thrift\"\nstruct A { 1: b.B b }\n")},
+		"idl/b.thrift": {Data: []byte("namespace go b\nstruct B {}\n")},
+		"idl-ref.yaml": {Data: []byte("ref:\n  idl/b.thrift: example.com/b\n")},
+	})
+	out = vfs.NewMapSink()
+	err = RunThriftgoFS(fsys, out, nil, "-q", "-r", "-o", "out", "-g", "go:package_prefix=x,code_ref", "idl/a.thrift")
+	test.Assert(t, err == nil, err)
+	test.DeepEqual(t, out.Names(), []string{filepath.FromSlash("out/a/a.go"), filepath.FromSlash("out/b/b-ref.go")})
+	test.Assert(t, dir_utils.HasGlobalWd())
+	test.Assert(t, config.GetRef("idl/b.thrift") == nil)
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	targs "github.com/cloudwego/thriftgo/args"
+	"github.com/cloudwego/thriftgo/pkg/watch"
+	"github.com/cloudwego/thriftgo/plugin"
+)
+
+// settleTime is how long to wait for more changes after one is seen, since
+// editors usually touch a file several times when saving it.
+var settleTime = 100 * time.Millisecond
+
+// mtimeMargin is subtracted from the start of a round when finding the files
+// changed before they were watched, since file systems may record coarse
+// modification times.
+var mtimeMargin = time.Second
+
+// afterRound is called when the watched files are updated after a round.
+var afterRound = func() {}
+
+// Watch invokes thriftgo with each of the arguments, and then watches the IDLs
+// they read. When some IDLs change, the invocations that read them are run
+// again along with the failed ones, and the watched IDLs are updated. Errors
+// are logged instead of returned. It returns when ctx is done.
+func Watch(ctx context.Context, args []*targs.Arguments, SDKPlugins []plugin.SDKPlugin) error {
+	w, err := watch.New()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	servers := plugin.NewServerPool()
+	defer servers.Close()
+
+	var out io.Writer = os.Stderr
+	if len(args) > 0 && args[0].Quiet {
+		out = io.Discard
+	}
+	logger := log.New(out, "[WATCH] ", 0)
+
+	inputs := make([]map[string]bool, len(args))
+	for i, a := range args {
+		inputs[i] = absSet(nil, a.IDL)
+	}
+	failed := make([]bool, len(args))
+	watched := func() map[string]bool {
+		all := make(map[string]bool)
+		for _, files := range inputs {
+			for f := range files {
+				all[f] = true
+			}
+		}
+		return all
+	}
+	setWatched := func(files map[string]bool) error {
+		var all []string
+		for f := range files {
+			all = append(all, f)
+		}
+		return w.Set(all)
+	}
+	// run invokes the arguments and returns the files that were read by them
+	// for the first time and may have changed before they were watched.
+	run := func(which []int) ([]string, error) {
+		// the known inputs are watched before invoking, so that changes
+		// made during the round are seen
+		prev := watched()
+		if err := setWatched(prev); err != nil {
+			return nil, err
+		}
+		start := time.Now().Add(-mtimeMargin)
+
+		// a new session for each round, since the ASTs of unchanged files
+		// may reference the changed ones
+		s := NewSession()
+		s.Servers = servers
+		for _, i := range which {
+			files, err := s.invoke(args[i], SDKPlugins)
+			if err != nil {
+				logger.Println("Error:", err)
+			}
+			failed[i] = err != nil
+			if len(files) > 0 {
+				inputs[i] = absSet(files, args[i].IDL)
+			}
+		}
+		all := watched()
+		if err := setWatched(all); err != nil {
+			return nil, err
+		}
+		var changed []string
+		for f := range all {
+			if prev[f] {
+				continue
+			}
+			if fi, err := os.Stat(f); err != nil || !fi.ModTime().Before(start) {
+				changed = append(changed, f)
+			}
+		}
+		logger.Printf("Watching %d files for changes", w.Len())
+		afterRound()
+		return changed, nil
+	}
+
+	all := make([]int, len(args))
+	for i := range args {
+		all[i] = i
+	}
+	changed, err := run(all)
+	if err != nil {
+		return err
+	}
+	for ctx.Err() == nil {
+		if len(changed) == 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-w.Events():
+			}
+			for settled := false; !settled; {
+				select {
+				case <-w.Events():
+				case <-time.After(settleTime):
+					settled = true
+				}
+			}
+		}
+		changed = append(changed, w.Changed()...)
+		if len(changed) == 0 {
+			continue
+		}
+		var affected []int
+		for i, files := range inputs {
+			if failed[i] || intersects(files, changed) {
+				affected = append(affected, i)
+			}
+		}
+		for _, path := range changed {
+			logger.Println("Changed:", path)
+		}
+		if changed, err = run(affected); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// absSet returns the absolute paths of the files and the IDL.
+func absSet(files []string, idl string) map[string]bool {
+	res := make(map[string]bool)
+	for _, f := range append(files, idl) {
+		if abs, err := filepath.Abs(f); err == nil {
+			res[abs] = true
+		}
+	}
+	return res
+}
+
+func intersects(files map[string]bool, paths []string) bool {
+	for _, p := range paths {
+		if files[p] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sdk
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	targs "github.com/cloudwego/thriftgo/args"
+	"github.com/cloudwego/thriftgo/pkg/test"
+)
+
+// waitFor waits until the file contains the text.
+func waitFor(t *testing.T, path, text string) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		if content, err := os.ReadFile(path); err == nil && strings.Contains(string(content), text) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("%s does not contain %q", path, text)
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		test.Assert(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644) == nil)
+	}
+	write("a.thrift", "namespace go a\ninclude \"b.thrift\"\nstruct A { 1: b.B b }\n")
+	write("b.thrift", "namespace go b\nstruct B {}\n")
+	write("c.thrift", "namespace go c\nstruct C {}\n")
+
+	var a targs.Arguments
+	out := filepath.Join(dir, "out")
+	err := a.Parse([]string{"thriftgo", "-q", "-r", "-o", out, "-g", "go:package_prefix=x", filepath.Join(dir, "a.thrift")})
+	test.Assert(t, err == nil, err)
+
+	rounds := make(chan struct{}, 1)
+	defer func(f func()) { afterRound = f }(afterRound)
+	afterRound = func() {
+		select {
+		case rounds <- struct{}{}:
+		default:
+		}
+	}
+	// nextRound waits until the watcher is armed after a round.
+	nextRound := func() {
+		t.Helper()
+		select {
+		case <-rounds:
+		case <-time.After(10 * time.Second):
+			t.Fatal("no round is finished")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- Watch(ctx, []*targs.Arguments{&a}, nil) }()
+
+	nextRound()
+	waitFor(t, filepath.Join(out, "b", "b.go"), "type B struct")
+	write("b.thrift", "namespace go b\nstruct B {}\nstruct B2 {}\n")
+	waitFor(t, filepath.Join(out, "b", "b.go"), "type B2 struct")
+
+	// errors do not stop watching
+	nextRound()
+	write("a.thrift", "namespace go a\ninclude \"b.thrift\"\nstruct A { 1: b.Missing b }\n")
+	nextRound()
+
+	// new includes are watched
+	write("a.thrift", "namespace go a\ninclude \"b.thrift\"\ninclude \"c.thrift\"\nstruct A { 1: c.C c }\n")
+	waitFor(t, filepath.Join(out, "c", "c.go"), "type C struct")
+	write("c.thrift", "namespace go c\nstruct C {}\nstruct C2 {}\n")
+	waitFor(t, filepath.Join(out, "c", "c.go"), "type C2 struct")
+
+	cancel()
+	test.Assert(t, <-done == nil)
+}
@@ -27,6 +27,34 @@ type Checker interface {
 	CheckAll(t *parser.Thrift) (warns []string, err error)
 }
 
+// DiagnosticChecker is a Checker that reports its findings as diagnostics.
+type DiagnosticChecker interface {
+	Checker
+
+	// Diagnose runs all checks over the AST and its includes and collects
+	// every problem found instead of stopping at the first error.
+	Diagnose(t *parser.Thrift) parser.Diagnostics
+}
+
+// Codes of the diagnostics reported by the checker.
+const (
+	CodeDuplicatedName        = "duplicated-name"
+	CodeDuplicatedEnumValue   = "duplicated-enum-value"
+	CodeEnumOverflow          = "enum-overflow"
+	CodeDuplicatedFieldID     = "duplicated-field-id"
+	CodeDuplicatedFieldName   = "duplicated-field-name"
+	CodeNonPositiveID         = "non-positive-id"
+	CodeUnionRequiredness     = "union-requiredness"
+	CodeUnionMultipleDefaults = "union-multiple-defaults"
+	CodeDuplicatedFunction    = "duplicated-function"
+	CodeOnewayNotVoid         = "oneway-not-void"
+	CodeOnewayThrows          = "oneway-throws"
+	CodeOptionalArgument      = "optional-argument"
+	CodeExceptionRequiredness = "exception-requiredness"
+)
+
+const diagnosticSource = "semantic"
+
 // Options controls the behavior of the default checker.
 type Options struct {
 	FixWarnings bool
@@ -41,6 +69,11 @@ func NewChecker(opt Options) Checker {
 	return &checker{opt}
 }
 
+// NewDiagnosticChecker creates a checker that reports diagnostics.
+func NewDiagnosticChecker(opt Options) DiagnosticChecker {
+	return &checker{opt}
+}
+
 // ResolveSymbols is the global function with the same name.
 func (c *checker) ResolveSymbols(t *parser.Thrift) error {
 	return ResolveSymbols(t)
@@ -48,7 +81,19 @@ func (c *checker) ResolveSymbols(t *parser.Thrift) error {
 
 // CheckAll implements the Checker interface.
 func (c *checker) CheckAll(t *parser.Thrift) (warns []string, err error) {
-	checks := []func(t *parser.Thrift) ([]string, error){
+	for _, d := range c.Diagnose(t) {
+		if d.Severity != parser.Severity_Error {
+			warns = append(warns, d.Error())
+		} else if err == nil {
+			err = fmt.Errorf("[IDL grammar error] %w", d)
+		}
+	}
+	return warns, err
+}
+
+// Diagnose implements the DiagnosticChecker interface.
+func (c *checker) Diagnose(t *parser.Thrift) (diags parser.Diagnostics) {
+	checks := []func(t *parser.Thrift) parser.Diagnostics{
 		c.CheckGlobals,
 		c.CheckEnums,
 		c.CheckStructLikes,
@@ -57,101 +102,104 @@ func (c *checker) CheckAll(t *parser.Thrift) (warns []string, err error) {
 	}
 	for tt := range t.DepthFirstSearch() {
 		for _, f := range checks {
-			ws, err := f(tt)
-			warns = append(warns, ws...)
-			if err != nil {
-				return warns, err
-			}
+			diags = append(diags, f(tt)...)
 		}
 	}
-	return warns, nil
+	return diags
 }
 
-func (c *checker) CheckGlobals(t *parser.Thrift) (warns []string, err error) {
-	defer func() {
-		if e := recover(); e != nil {
-			err = fmt.Errorf("[IDL grammar error] duplicated names in global scope: %s from file %s", e, t.Filename)
-		}
-	}()
-	globals := make(map[string]bool)
-	check := func(s string) {
-		if globals[s] {
-			panic(s)
+func errorf(t *parser.Thrift, loc *parser.Location, code, format string, args ...interface{}) *parser.Diagnostic {
+	return parser.NewDiagnosticf(parser.Severity_Error, diagnosticSource, code, t.Filename, loc, format, args...)
+}
+
+func warnf(t *parser.Thrift, loc *parser.Location, code, format string, args ...interface{}) *parser.Diagnostic {
+	return parser.NewDiagnosticf(parser.Severity_Warning, diagnosticSource, code, t.Filename, loc, format, args...)
+}
+
+func (c *checker) CheckGlobals(t *parser.Thrift) (diags parser.Diagnostics) {
+	globals := make(map[string]*parser.Location)
+	check := func(s string, loc *parser.Location) {
+		if prev, ok := globals[s]; ok {
+			d := errorf(t, loc, CodeDuplicatedName, "duplicated names in global scope: %s", s)
+			diags = append(diags, d.AddRelated(t.Filename, prev, "previous definition of "+s))
+			return
 		}
-		globals[s] = true
+		globals[s] = loc
 	}
 	for _, v := range t.Typedefs {
-		check(v.Alias)
+		check(v.Alias, v.Location)
 	}
 	for _, v := range t.Constants {
-		check(v.Name)
+		check(v.Name, v.Location)
 	}
 	for _, v := range t.GetStructLikes() {
-		check(v.Name)
+		check(v.Name, v.Location)
 	}
 	for _, v := range t.Services {
-		check(v.Name)
+		check(v.Name, v.Location)
 	}
 	return
 }
 
-func (c *checker) CheckEnums(t *parser.Thrift) (warns []string, err error) {
+func (c *checker) CheckEnums(t *parser.Thrift) (diags parser.Diagnostics) {
 	for _, e := range t.Enums {
-		exist := make(map[string]bool)
-		v2n := make(map[int64]string)
+		exist := make(map[string]*parser.Location)
+		v2n := make(map[int64]*parser.EnumValue)
 		for _, v := range e.Values {
-			if exist[v.Name] {
-				err = fmt.Errorf("[IDL grammar error] enum %s has duplicated value: %s from file %s", e.Name, v.Name, t.Filename)
-			}
-			exist[v.Name] = true
-			if n, ok := v2n[v.Value]; ok && n != v.Name {
-				err = fmt.Errorf(
-					"[IDL grammar error] enum %s: duplicate value %d between '%s' and '%s' from file %s",
-					e.Name, v.Value, n, v.Name, t.Filename,
-				)
+			if prev, ok := exist[v.Name]; ok {
+				d := errorf(t, v.Location, CodeDuplicatedEnumValue, "enum %s has duplicated value: %s", e.Name, v.Name)
+				diags = append(diags, d.AddRelated(t.Filename, prev, "previous definition of "+v.Name))
+			} else {
+				exist[v.Name] = v.Location
 			}
-			v2n[v.Value] = v.Name
-			if err != nil {
-				return
+			if prev, ok := v2n[v.Value]; ok && prev.Name != v.Name {
+				d := errorf(t, v.Location, CodeDuplicatedEnumValue,
+					"enum %s: duplicate value %d between '%s' and '%s'", e.Name, v.Value, prev.Name, v.Name)
+				diags = append(diags, d.AddRelated(t.Filename, prev.Location, "previous use of value "+fmt.Sprint(v.Value)))
 			}
+			v2n[v.Value] = v
 			// check if enum value can be safely converted to int 32
 			if v.Value < math.MinInt32 || v.Value > math.MaxInt32 {
-				return nil, fmt.Errorf(
+				d := errorf(t, v.Location, CodeEnumOverflow,
 					"enum overflow: the value (%d) of enum '%s %s' exceeds the range of int32.\n"+
 						"Due to legacy implementation, thriftgo generates int64 for enums in Go code. \n"+
 						"However, during network, values undergo int64->int32->int64 conversion. Values outside int32 will overflow.\n"+
 						"Please adjust the enum value to fit within the int32 range [-2147483648, 2147483647].\n"+
 						"If you just want to define a very big constant, please use 'const i64 MyConst = xxx' instead.\n",
 					v.Value,
-					t.Filename,
+					e.Name,
 					v.Name,
 				)
+				diags = append(diags, d.WithFix("use 'const i64' for values outside the int32 range"))
 			}
 		}
 	}
 	return
 }
 
-func (c *checker) CheckStructLikes(t *parser.Thrift) (warns []string, err error) {
+func (c *checker) CheckStructLikes(t *parser.Thrift) (diags parser.Diagnostics) {
 	for _, s := range t.GetStructLikes() {
-		fieldIDs := make(map[int32]bool)
-		names := make(map[string]bool)
+		fieldIDs := make(map[int32]*parser.Field)
+		names := make(map[string]*parser.Field)
 		for _, f := range s.Fields {
-			if fieldIDs[f.ID] {
-				err = fmt.Errorf("[IDL grammar error] duplicated field ID %d in %s %q from file %s",
-					f.ID, s.Category, s.Name, t.Filename)
-				return
+			if prev, ok := fieldIDs[f.ID]; ok {
+				d := errorf(t, f.Location, CodeDuplicatedFieldID,
+					"duplicated field ID %d in %s %q", f.ID, s.Category, s.Name)
+				diags = append(diags, d.AddRelated(t.Filename, prev.Location, "field "+prev.Name+" uses the same ID"))
+				continue
 			}
-			if names[f.Name] {
-				err = fmt.Errorf("[IDL grammar error] duplicated field name %q in %s %q from file %s",
-					f.Name, s.Category, s.Name, t.Filename)
-				return
+			if prev, ok := names[f.Name]; ok {
+				d := errorf(t, f.Location, CodeDuplicatedFieldName,
+					"duplicated field name %q in %s %q", f.Name, s.Category, s.Name)
+				diags = append(diags, d.AddRelated(t.Filename, prev.Location, "previous definition of "+f.Name))
+				continue
 			}
-			fieldIDs[f.ID] = true
-			names[f.Name] = true
+			fieldIDs[f.ID] = f
+			names[f.Name] = f
 			if f.ID <= 0 {
-				warns = append(warns, fmt.Sprintf("non-positive ID %d of field %q in %q  from file %s",
-					f.ID, f.Name, s.Name, t.Filename))
+				d := warnf(t, f.Location, CodeNonPositiveID,
+					"non-positive ID %d of field %q in %q", f.ID, f.Name, s.Name)
+				diags = append(diags, d.WithFix("assign a positive ID to the field"))
 			}
 		}
 	}
@@ -159,21 +207,21 @@ func (c *checker) CheckStructLikes(t *parser.Thrift) (warns []string, err error)
 }
 
 // CheckUnions checks the semantics of union nodes.
-func (c *checker) CheckUnions(t *parser.Thrift) (warns []string, err error) {
+func (c *checker) CheckUnions(t *parser.Thrift) (diags parser.Diagnostics) {
 	for _, u := range t.Unions {
 		var hasDefault bool
 		for _, f := range u.Fields {
 			if f.Requiredness == parser.FieldType_Required {
-				msg := fmt.Sprintf(
+				d := warnf(t, f.Location, CodeUnionRequiredness,
 					"union %s field %s: union members must be optional, ignoring specified requiredness.",
 					u.Name, f.Name)
-				warns = append(warns, msg)
+				diags = append(diags, d.WithFix("remove the 'required' keyword"))
 			}
 
 			if f.GetDefault() != nil {
 				if hasDefault {
-					err = fmt.Errorf("[IDL grammar error] field %s provides another default value for union %s from file %s", f.Name, u.Name, t.Filename)
-					return warns, err
+					diags = append(diags, errorf(t, f.Location, CodeUnionMultipleDefaults,
+						"field %s provides another default value for union %s", f.Name, u.Name))
 				}
 			}
 
@@ -186,41 +234,47 @@ func (c *checker) CheckUnions(t *parser.Thrift) (warns []string, err error) {
 }
 
 // CheckFunctions checks the semantics of service functions.
-func (c *checker) CheckFunctions(t *parser.Thrift) (warns []string, err error) {
-	var argOpt string
+func (c *checker) CheckFunctions(t *parser.Thrift) (diags parser.Diagnostics) {
+	var argOpt *parser.Diagnostic
 	for _, svc := range t.Services {
-		defined := make(map[string]bool)
+		defined := make(map[string]*parser.Function)
 		for _, f := range svc.Functions {
-			if defined[f.Name] {
-				err = fmt.Errorf("[IDL grammar error] duplicated function name in %q: %q from file %s", svc.Name, f.Name, t.Filename)
-				return
+			if prev, ok := defined[f.Name]; ok {
+				d := errorf(t, f.Location, CodeDuplicatedFunction,
+					"duplicated function name in %q: %q", svc.Name, f.Name)
+				diags = append(diags, d.AddRelated(t.Filename, prev.Location, "previous definition of "+f.Name))
+			} else {
+				defined[f.Name] = f
 			}
-			defined[f.Name] = true
 
 			if f.Oneway && !f.Void {
-				err = fmt.Errorf("[IDL grammar error] %s.%s: oneway function must be void type from file %s", svc.Name, f.Name, t.Filename)
-				return
+				d := errorf(t, f.Location, CodeOnewayNotVoid,
+					"%s.%s: oneway function must be void type", svc.Name, f.Name)
+				diags = append(diags, d.WithFix("change the return type to void or remove the 'oneway' keyword"))
 			}
 			if f.Oneway && len(f.Throws) > 0 {
-				err = fmt.Errorf("[IDL grammar error] %s.%s: oneway methods can't throw exceptions from file %s", svc.Name, f.Name, t.Filename)
-				return
+				d := errorf(t, f.Location, CodeOnewayThrows,
+					"%s.%s: oneway methods can't throw exceptions", svc.Name, f.Name)
+				diags = append(diags, d.WithFix("remove the throws clause or the 'oneway' keyword"))
 			}
 			for _, a := range f.Arguments {
 				if a.Requiredness == parser.FieldType_Optional {
-					argOpt = t.Filename + ": optional keyword is ignored in argument lists."
+					argOpt = warnf(t, a.Location, CodeOptionalArgument, "optional keyword is ignored in argument lists.")
 					if c.FixWarnings {
 						a.Requiredness = parser.FieldType_Default
 					}
 				}
 				if a.ID <= 0 {
-					warns = append(warns, fmt.Sprintf("non-positive ID %d of argument %q in %q.%q",
-						a.ID, a.Name, svc.Name, f.Name))
+					d := warnf(t, a.Location, CodeNonPositiveID,
+						"non-positive ID %d of argument %q in %q.%q", a.ID, a.Name, svc.Name, f.Name)
+					diags = append(diags, d.WithFix("assign a positive ID to the argument"))
 				}
 			}
 			for _, a := range f.Throws {
 				switch a.Requiredness {
 				case parser.FieldType_Required:
-					warns = append(warns, fmt.Sprintf("exception %q in %q.%q: throw field must be optional, ignoring specified requiredness.",
+					diags = append(diags, warnf(t, a.Location, CodeExceptionRequiredness,
+						"exception %q in %q.%q: throw field must be optional, ignoring specified requiredness.",
 						a.Name, svc.Name, f.Name))
 					if !c.FixWarnings {
 						continue
@@ -232,8 +286,8 @@ func (c *checker) CheckFunctions(t *parser.Thrift) (warns []string, err error) {
 			}
 		}
 	}
-	if argOpt != "" {
-		warns = append(warns, argOpt)
+	if argOpt != nil {
+		diags = append(diags, argOpt)
 	}
 	return
 }
@@ -0,0 +1,96 @@
+// Copyright 2025 CloudWeGo Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semantic_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/thriftgo/parser"
+	"github.com/cloudwego/thriftgo/pkg/test"
+	"github.com/cloudwego/thriftgo/semantic"
+)
+
+const testDiagnose = `struct A {
+    1: i32 a
+    1: i32 b
+    0: i32 c
+}
+
+union U {
+    1: required i32 x
+}
+
+service S {
+    oneway i32 f()
+    void f()
+}
+`
+
+func TestDiagnose(t *testing.T) {
+	ast, err := parser.ParseString("main.thrift", testDiagnose)
+	test.Assert(t, err == nil, err)
+
+	checker := semantic.NewDiagnosticChecker(semantic.Options{})
+	diags := checker.Diagnose(ast)
+
+	var codes []string
+	for _, d := range diags {
+		codes = append(codes, d.Code)
+		test.Assert(t, d.Filename == "main.thrift" && d.Location != nil, d)
+		test.Assert(t, d.Source == "semantic", d)
+	}
+	test.Assert(t, strings.Join(codes, ",") == strings.Join([]string{
+		semantic.CodeDuplicatedFieldID,
+		semantic.CodeNonPositiveID,
+		semantic.CodeUnionRequiredness,
+		semantic.CodeOnewayNotVoid,
+		semantic.CodeDuplicatedFunction,
+	}, ","), codes)
+
+	dup := diags[0]
+	test.Assert(t, dup.Severity == parser.Severity_Error)
+	test.Assert(t, dup.Location.StartLine == 3, dup.Location)
+	test.Assert(t, len(dup.Related) == 1 && dup.Related[0].Location.StartLine == 2, dup.Related)
+	test.Assert(t, dup.Error() == `main.thrift:3:5: duplicated field ID 1 in struct "A"`, dup.Error())
+	test.Assert(t, len(diags.Filter(parser.Severity_Error)) == 3)
+	test.Assert(t, diags.Err() != nil)
+
+	warns, err := checker.CheckAll(ast)
+	test.Assert(t, len(warns) == 2, warns)
+	test.Assert(t, err != nil && strings.HasPrefix(err.Error(), "[IDL grammar error] main.thrift:3:5"), err)
+}
+
+func TestResolveDiagnostic(t *testing.T) {
+	base, err := parser.ParseString("base.thrift", "struct Base {\n    1: Missing m\n}\n")
+	test.Assert(t, err == nil, err)
+	ast, err := parser.ParseString("main.thrift", "include \"base.thrift\"\nstruct A {}\n")
+	test.Assert(t, err == nil, err)
+	ast.Includes[0].Reference = base
+
+	var d *parser.Diagnostic
+	err = semantic.ResolveSymbols(ast)
+	test.Assert(t, errors.As(err, &d), err)
+	test.Assert(t, d.Code == semantic.CodeUndefinedSymbol && d.Source == "semantic", d)
+	test.Assert(t, d.Filename == "base.thrift" && d.Location.StartLine == 2, d)
+	test.Assert(t, strings.Contains(d.Message, `undefined type: "Missing"`), d.Message)
+	test.Assert(t, len(d.Related) == 1 && d.Related[0].Filename == "main.thrift", d.Related)
+
+	ast, err = parser.ParseString("dup.thrift", "struct A {}\nenum A {}\n")
+	test.Assert(t, err == nil, err)
+	err = semantic.ResolveSymbols(ast)
+	test.Assert(t, errors.As(err, &d) && d.Code == semantic.CodeDuplicatedName && d.Location.StartLine == 1, err)
+}
@@ -15,6 +15,7 @@
 package semantic
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -25,7 +26,8 @@ import (
 // and build a name-to-category mapping for all locally-defined symbols.
 // If a type, a value or a service refers to another one from an external IDL, the
 // index of that IDL in the include list will be recorded.
-// ResolveSymbols stops when it encounters any error.
+// ResolveSymbols stops when it encounters any error, which is a
+// *parser.Diagnostic locating the problem.
 func ResolveSymbols(ast *parser.Thrift) error {
 	if ast.Name2Category != nil {
 		return nil
@@ -35,6 +37,13 @@ func ResolveSymbols(ast *parser.Thrift) error {
 	return r.ResolveAST()
 }
 
+// Codes of the diagnostics reported by ResolveSymbols.
+const (
+	CodeUnresolvedInclude = "unresolved-include"
+	CodeUndefinedSymbol   = "undefined-symbol"
+	CodeUnresolvedTypedef = "unresolved-typedef"
+)
+
 // typedefPair contains a type and the AST it belongs to. This struct is used
 // to record types that resolved to a typedef instead of a concrete type so
 // a post process is required to do further resolution.
@@ -57,9 +66,21 @@ func guard(err error) (ok bool) {
 	return true
 }
 
-func (r *resolver) AddName(name string, category parser.Category) error {
+func (r *resolver) errorf(loc *parser.Location, code, format string, args ...interface{}) *parser.Diagnostic {
+	return errorf(r.ast, loc, code, format, args...)
+}
+
+// wrap converts an error in resolving the definition at loc into a diagnostic.
+func (r *resolver) wrap(err error, loc *parser.Location, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return r.errorf(loc, CodeUndefinedSymbol, format+": %s", append(args, err)...)
+}
+
+func (r *resolver) AddName(name string, category parser.Category, loc *parser.Location) error {
 	if _, exist := r.ast.Name2Category[name]; exist {
-		return fmt.Errorf("%s: multiple definition of %q", r.ast.Filename, name)
+		return r.errorf(loc, CodeDuplicatedName, "multiple definition of %q", name)
 	}
 	r.ast.Name2Category[name] = category
 	return nil
@@ -69,31 +90,31 @@ func (r *resolver) AddName(name string, category parser.Category) error {
 // It panics when encounters any error.
 func (r *resolver) RegisterNames() {
 	r.ast.ForEachTypedef(func(v *parser.Typedef) bool {
-		return guard(r.AddName(v.Alias, parser.Category_Typedef))
+		return guard(r.AddName(v.Alias, parser.Category_Typedef, v.Location))
 	})
 
 	r.ast.ForEachConstant(func(v *parser.Constant) bool {
-		return guard(r.AddName(v.Name, parser.Category_Constant))
+		return guard(r.AddName(v.Name, parser.Category_Constant, v.Location))
 	})
 
 	r.ast.ForEachEnum(func(v *parser.Enum) bool {
-		return guard(r.AddName(v.Name, parser.Category_Enum))
+		return guard(r.AddName(v.Name, parser.Category_Enum, v.Location))
 	})
 
 	r.ast.ForEachStructLike(func(v *parser.StructLike) bool {
 		switch v.Category {
 		case "struct":
-			return guard(r.AddName(v.Name, parser.Category_Struct))
+			return guard(r.AddName(v.Name, parser.Category_Struct, v.Location))
 		case "union":
-			return guard(r.AddName(v.Name, parser.Category_Union))
+			return guard(r.AddName(v.Name, parser.Category_Union, v.Location))
 		case "exception":
-			return guard(r.AddName(v.Name, parser.Category_Exception))
+			return guard(r.AddName(v.Name, parser.Category_Exception, v.Location))
 		}
 		return false
 	})
 
 	r.ast.ForEachService(func(v *parser.Service) bool {
-		return guard(r.AddName(v.Name, parser.Category_Service))
+		return guard(r.AddName(v.Name, parser.Category_Service, v.Location))
 	})
 }
 
@@ -101,20 +122,27 @@ func (r *resolver) RegisterNames() {
 func (r *resolver) ResolveAST() (err error) {
 	defer func() {
 		if x := recover(); x != nil {
-			if e, ok := x.(error); ok {
-				err = e
+			var d *parser.Diagnostic
+			if e, ok := x.(error); !ok {
+				err = r.errorf(nil, CodeUndefinedSymbol, "%+v", x)
+			} else if errors.As(e, &d) {
+				err = d
 			} else {
-				err = fmt.Errorf("%+v", x)
+				err = r.errorf(nil, CodeUndefinedSymbol, "%s", e)
 			}
 		}
 	}()
 
 	r.ast.ForEachInclude(func(v *parser.Include) bool {
 		if v.Reference == nil {
-			panic(fmt.Errorf("reference %q of %q is not parsed", v.Path, r.ast.Filename))
+			panic(r.errorf(v.Location, CodeUnresolvedInclude, "reference %q is not parsed", v.Path))
 		}
 		if err = ResolveSymbols(v.Reference); err != nil {
-			panic(fmt.Errorf("resolve include %q: %w from file %s", v.Path, err, r.ast.Filename))
+			var d *parser.Diagnostic
+			if !errors.As(err, &d) {
+				d = r.errorf(v.Location, CodeUnresolvedInclude, "resolve include %q: %s", v.Path, err)
+			}
+			panic(d.AddRelated(r.ast.Filename, v.Location, fmt.Sprintf("included as %q", v.Path)))
 		}
 		return true
 	})
@@ -124,11 +152,12 @@ func (r *resolver) ResolveAST() (err error) {
 	r.RegisterNames()
 
 	r.ast.ForEachTypedef(func(v *parser.Typedef) bool {
-		return guard(r.ResolveType(v.Type))
+		return guard(r.wrap(r.ResolveType(v.Type), v.Location, "resolve typedef %q", v.Alias))
 	})
 
 	r.ast.ForEachConstant(func(v *parser.Constant) bool {
-		return guard(r.ResolveType(v.Type)) && guard(r.ResolveConstValue(v.Value))
+		return guard(r.wrap(r.ResolveType(v.Type), v.Location, "resolve type of %q", v.Name)) &&
+			guard(r.wrap(r.ResolveConstValue(v.Value), v.Location, "resolve value of %q", v.Name))
 	})
 
 	r.ast.ForEachStructLike(func(v *parser.StructLike) bool {
@@ -163,7 +192,7 @@ func (r *resolver) ResolveBaseService(v *parser.Service) error {
 		if c, exist := r.ast.Name2Category[tmp[0]]; exist && c == parser.Category_Service {
 			break
 		}
-		return fmt.Errorf("base service %q not found for %q", v.Extends, v.Name)
+		return r.errorf(v.Location, CodeUndefinedSymbol, "base service %q not found for %q", v.Extends, v.Name)
 	case 2:
 		for idx, inc := range r.ast.Includes {
 			if IDLPrefix(inc.Path) == tmp[0] {
@@ -178,7 +207,7 @@ func (r *resolver) ResolveBaseService(v *parser.Service) error {
 			}
 		}
 		if v.Reference == nil {
-			return fmt.Errorf("base service %q not found for %q", v.Extends, v.Name)
+			return r.errorf(v.Location, CodeUndefinedSymbol, "base service %q not found for %q", v.Extends, v.Name)
 		}
 	}
 	return nil
@@ -186,11 +215,11 @@ func (r *resolver) ResolveBaseService(v *parser.Service) error {
 
 func (r *resolver) ResolveStructField(s string, f *parser.Field) (err error) {
 	if err = r.ResolveType(f.Type); err != nil {
-		return fmt.Errorf("resolve field %q of %q: %w from file %s", f.Name, s, err, r.ast.Filename)
+		return r.wrap(err, f.Location, "resolve field %q of %q", f.Name, s)
 	}
 	if f.IsSetDefault() {
 		if err = r.ResolveConstValue(f.Default); err != nil {
-			return fmt.Errorf("resolve default value of %q of %q: %w from file %s", f.Name, s, err, r.ast.Filename)
+			return r.wrap(err, f.Default.Location, "resolve default value of %q of %q", f.Name, s)
 		}
 	}
 	return
@@ -199,17 +228,17 @@ func (r *resolver) ResolveStructField(s string, f *parser.Field) (err error) {
 func (r *resolver) ResolveFunction(s string, f *parser.Function) (err error) {
 	if !f.Void {
 		if err = r.ResolveType(f.FunctionType); err != nil {
-			return fmt.Errorf("function %q of service %q: %w from file %s", f.Name, s, err, r.ast.Filename)
+			return r.wrap(err, f.Location, "function %q of service %q", f.Name, s)
 		}
 	}
 	for _, v := range f.Arguments {
 		if err := r.ResolveType(v.Type); err != nil {
-			return fmt.Errorf("resolve argument %q of %q of %q: %w from file %s", v.Name, f.Name, s, err, r.ast.Filename)
+			return r.wrap(err, v.Location, "resolve argument %q of %q of %q", v.Name, f.Name, s)
 		}
 	}
 	for _, v := range f.Throws {
 		if err := r.ResolveType(v.Type); err != nil {
-			return fmt.Errorf("resolve exception %q of %q of %q: %w from file %s", v.Name, f.Name, s, err, r.ast.Filename)
+			return r.wrap(err, v.Location, "resolve exception %q of %q of %q", v.Name, f.Name, s)
 		}
 	}
 	return
@@ -225,6 +254,7 @@ var categoryMap = map[string]parser.Category{
 	"double": parser.Category_Double,
 	"string": parser.Category_String,
 	"binary": parser.Category_Binary,
+	"uuid":   parser.Category_UUID,
 	"map":    parser.Category_Map,
 	"list":   parser.Category_List,
 	"set":    parser.Category_Set,
@@ -234,7 +264,7 @@ var yes bool = true
 
 func (r *resolver) ResolveType(t *parser.Type) (err error) {
 	switch t.Name {
-	case "bool", "byte", "i8", "i16", "i32", "i64", "double", "string", "binary":
+	case "bool", "byte", "i8", "i16", "i32", "i64", "double", "string", "binary", "uuid":
 		t.Category = categoryMap[t.Name]
 	case "map", "list", "set":
 		t.Category = categoryMap[t.Name]
@@ -450,7 +480,7 @@ func (r *resolver) ResolveTypedefs() error {
 					"%q in %q", t.Type.Name, t.AST.Filename,
 				))
 			}
-			return fmt.Errorf("typedefs can not be resolved: %s", strings.Join(ss, ", "))
+			return r.errorf(nil, CodeUnresolvedTypedef, "typedefs can not be resolved: %s", strings.Join(ss, ", "))
 		}
 		tds = tmp
 		cnt = len(tds)
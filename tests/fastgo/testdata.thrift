@@ -20,6 +20,7 @@ enum Numberz
 }
 
 typedef i64 UserID
+typedef uuid RequestID
 
 struct Msg
 {
@@ -121,4 +122,16 @@ struct TestTypes {
   201: map<i32, list<i32>> Mix201;
   202: required map<i32, list<i32>> Mix202;
   203: optional map<i32, list<i32>> Mix203;
+
+  211: uuid UUID0;
+  212: required uuid UUID1;
+  213: optional uuid UUID2;
+  214: optional uuid UUID3 = "00112233-4455-6677-8899-aabbccddeeff";
+
+  221: RequestID RID0;
+  222: optional RequestID RID1;
+
+  231: list<uuid> List231;
+  232: set<uuid> Set232;
+  233: map<uuid, list<uuid>> Map233;
 }
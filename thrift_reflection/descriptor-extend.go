@@ -414,6 +414,8 @@ func (td *TypeDescriptor) GetGoType() (reflect.Type, error) {
 			return reflect.TypeOf(float64(0)), nil
 		case "binary":
 			return reflect.TypeOf([]byte{0}), nil
+		case "uuid":
+			return reflect.TypeOf([16]byte{}), nil
 		case "string":
 			return reflect.TypeOf(string("")), nil
 		default:
@@ -32,6 +32,7 @@ var builtinTypes = map[string]bool{
 	"double": true,
 	"string": true,
 	"binary": true,
+	"uuid":   true,
 	"list":   true,
 	"map":    true,
 	"set":    true,
@@ -17,19 +17,27 @@ package dir_utils
 import (
 	"os"
 	"path/filepath"
+	"sync/atomic"
 )
 
-var globalwd string
+// globalwd holds the working directory set by SetGlobalwd as a string.
+var globalwd atomic.Value
 
 func SetGlobalwd(wd string) {
-	globalwd = wd
+	globalwd.Store(wd)
+}
+
+func getGlobalwd() string {
+	wd, _ := globalwd.Load().(string)
+	return wd
 }
 
 func HasGlobalWd() bool {
-	return globalwd != ""
+	return getGlobalwd() != ""
 }
 
 func Getwd() (string, error) {
+	globalwd := getGlobalwd()
 	if globalwd == "" {
 		return os.Getwd()
 	}
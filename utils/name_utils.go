@@ -29,6 +29,7 @@ var basicMap = map[string]bool{
 	"string": true,
 	"byte":   true,
 	"binary": true,
+	"uuid":   true,
 	"bool":   true,
 }
 